@@ -0,0 +1,55 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"github.com/Vanv1k/BeautyTON/internal/config"
+	"github.com/Vanv1k/BeautyTON/internal/infrastructure/database/postgres"
+	"github.com/Vanv1k/BeautyTON/internal/usecase"
+)
+
+// runCommand runs a maintenance subcommand instead of the server.
+func runCommand(cfg *config.Config, args []string) {
+	switch args[0] {
+	case "bootstrap-admin":
+		bootstrapAdmin(cfg, args[1:])
+	case "print-config":
+		// Секреты скрыты, вывод можно прикладывать к обращениям
+		fmt.Print(cfg.Redacted())
+	default:
+		fatal("unknown command", "command", args[0])
+	}
+}
+
+// bootstrapAdmin grants the admin role to a user who has signed in through
+// Telegram at least once, so that the first admin can manage the others.
+func bootstrapAdmin(cfg *config.Config, args []string) {
+	flags := flag.NewFlagSet("bootstrap-admin", flag.ExitOnError)
+	telegramID := flags.Int64("telegram-id", 0, "Telegram ID of the user to make admin")
+	flags.Parse(args)
+	if *telegramID == 0 {
+		fatal("bootstrap-admin: -telegram-id is required")
+	}
+
+	pg, err := postgres.NewPostgresRepo(cfg.Postgres)
+	if err != nil {
+		fatal("failed to init postgres", "error", err)
+	}
+	adminUsecase := usecase.NewAdminUsecase(
+		postgres.NewUserRepository(pg),
+		postgres.NewServiceRepository(pg),
+		postgres.NewReviewRepository(pg),
+		postgres.NewPhotoModerationRepository(pg),
+		postgres.NewAdminActionRepository(pg),
+		postgres.NewAuthSessionRepository(pg),
+		postgres.NewFileMetadataRepository(pg),
+	)
+
+	user, err := adminUsecase.BootstrapAdmin(context.Background(), *telegramID)
+	if err != nil {
+		fatal("bootstrap-admin failed", "error", err)
+	}
+	fmt.Printf("User %s (telegram id %d) is now an admin\n", user.ID, *telegramID)
+}
@@ -1,19 +1,32 @@
 package main
 
 import (
-	"fmt"
+	"context"
+	"errors"
+	"flag"
 	"log"
+	"log/slog"
 	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
 	"github.com/joho/godotenv"
 
 	"github.com/Vanv1k/BeautyTON/internal/config"
+	"github.com/Vanv1k/BeautyTON/internal/domain/entity"
+	"github.com/Vanv1k/BeautyTON/internal/infrastructure/auth"
+	"github.com/Vanv1k/BeautyTON/internal/infrastructure/database/memory"
 	"github.com/Vanv1k/BeautyTON/internal/infrastructure/database/postgres"
 	"github.com/Vanv1k/BeautyTON/internal/infrastructure/http/handler"
 	"github.com/Vanv1k/BeautyTON/internal/infrastructure/http/router"
-	"github.com/Vanv1k/BeautyTON/internal/infrastructure/storage/s3"
+	"github.com/Vanv1k/BeautyTON/internal/infrastructure/imaging"
+	"github.com/Vanv1k/BeautyTON/internal/infrastructure/logger"
+	"github.com/Vanv1k/BeautyTON/internal/infrastructure/storage"
+	"github.com/Vanv1k/BeautyTON/internal/infrastructure/tracing"
+	"github.com/Vanv1k/BeautyTON/internal/lifecycle"
+	"github.com/Vanv1k/BeautyTON/internal/metrics"
 	"github.com/Vanv1k/BeautyTON/internal/usecase"
 
 	_ "github.com/Vanv1k/BeautyTON/docs" // docs is generated by Swagger
@@ -32,28 +45,48 @@ import (
 // @license.url http://www.apache.org/licenses/LICENSE-2.0.html
 
 // @host localhost:8080
-// @BasePath /
+// @BasePath /v1
 
 func main() {
-	cfg := config.Load()
+	// .env должен быть прочитан до сборки конфигурации
+	envFileErr := godotenv.Load()
 
-	if err := godotenv.Load(); err != nil {
-		log.Println("No .env file found, relying on system environment variables")
+	cfg, args, err := config.Load(os.Args[1:])
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	appLogger, err := logger.New(cfg.Log, os.Stdout)
+	if err != nil {
+		log.Fatalf("log: %v", err)
+	}
+	slog.SetDefault(appLogger)
+
+	if envFileErr != nil {
+		slog.Info("no .env file found, relying on system environment variables")
 	}
 
-	botToken := os.Getenv("TELEGRAM_BOT_TOKEN")
-	if botToken == "" {
-		log.Fatal("TELEGRAM_BOT_TOKEN not set")
+	if len(args) > 0 {
+		runCommand(cfg, args)
+		return
+	}
+
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg.Tracing)
+	if err != nil {
+		fatal("failed to init tracing", "error", err)
 	}
 
 	pg, err := postgres.NewPostgresRepo(cfg.Postgres)
 	if err != nil {
-		panic(fmt.Errorf("failed to init postgres: %w", err))
+		fatal("failed to init postgres", "error", err)
 	}
 
-	fileRepo, err := s3.NewFileRepository(cfg.S3)
+	fileRepo, err := storage.NewFileRepository(cfg.Storage)
 	if err != nil {
-		panic(fmt.Errorf("failed to init s3: %w", err))
+		fatal("failed to init storage", "error", err)
 	}
 
 	// TODO: use google wire to move dependencies
@@ -70,21 +103,74 @@ func main() {
 	paymentRepo := postgres.NewPaymentRepository(pg)
 	cityRepo := postgres.NewCityRepository(pg)
 	countryRepo := postgres.NewCountryRepository(pg)
+	promotionRepo := postgres.NewPromotionRepository(pg)
+	idempotencyKeyRepo := postgres.NewIdempotencyKeyRepository(pg)
+	authSessionRepo := postgres.NewAuthSessionRepository(pg)
+	photoModerationRepo := postgres.NewPhotoModerationRepository(pg)
+	fileMetadataRepo := postgres.NewFileMetadataRepository(pg)
+	adminActionRepo := postgres.NewAdminActionRepository(pg)
+	auditLogRepo := postgres.NewAuditLogRepository(pg)
+	// Лимиты в памяти подходят для одного экземпляра, для нескольких нужен общий Postgres
+	rateLimitRepo := memory.NewRateLimitRepository()
+	if cfg.RateLimit.Store == "postgres" {
+		rateLimitRepo = postgres.NewRateLimitRepository(pg)
+	}
+
+	signingKeys := make([]auth.SigningKey, len(cfg.Auth.SigningKeys))
+	for i, key := range cfg.Auth.SigningKeys {
+		signingKeys[i] = auth.SigningKey{ID: key.ID, Secret: []byte(key.Secret)}
+	}
+	tokenSigner, err := auth.NewTokenSigner(signingKeys)
+	if err != nil {
+		fatal("invalid AUTH_SIGNING_KEYS", "error", err)
+	}
 
-	userUsecase := usecase.NewUserUsecase(userRepo, fileRepo)
+	imageProcessor := imaging.NewProcessor()
+	fileStore := usecase.NewFileStore(fileRepo, fileMetadataRepo, imageProcessor, cfg.Storage.PublicURL, cfg.Storage.UploadTTL, cfg.Storage.DownloadTTL)
+	userUsecase := usecase.NewUserUsecase(userRepo, fileStore, photoModerationRepo)
 	userPreferencesUsecase := usecase.NewUserPreferencesUsecase(userPreferencesRepo, userRepo, serviceCategoryRepo)
-	masterProfileUsecase := usecase.NewMasterProfileUsecase(masterProfileRepo, userRepo)
+	promotionUsecase := usecase.NewPromotionUsecase(promotionRepo, masterProfileRepo, cfg.Promotion.Positions, cfg.Promotion.ImpressionCostTON)
+	masterProfileUsecase := usecase.NewMasterProfileUsecase(masterProfileRepo, userRepo, promotionUsecase)
 	subscriptionUsecase := usecase.NewSubscriptionUsecase(subscriptionRepo, userRepo)
 	myMasterUsecase := usecase.NewMyMasterUsecase(myMasterRepo, userRepo)
-	serviceUsecase := usecase.NewServiceUsecase(serviceRepo, userRepo, serviceCategoryRepo, fileRepo)
+	serviceUsecase := usecase.NewServiceUsecase(serviceRepo, userRepo, serviceCategoryRepo, fileStore, photoModerationRepo)
 	serviceCategoryUsecase := usecase.NewServiceCategoryUsecase(serviceCategoryRepo)
 	bookingUsecase := usecase.NewBookingUsecase(bookingRepo, userRepo, serviceRepo)
 	scheduleSlotUsecase := usecase.NewScheduleSlotUsecase(scheduleSlotrepo, masterProfileRepo, bookingRepo)
 	reviewUsecase := usecase.NewReviewUsecase(reviewRepo, bookingRepo)
-	paymentUsecase := usecase.NewPaymentUsecase(paymentRepo, userRepo)
+	paymentUsecase := usecase.NewPaymentUsecase(paymentRepo, userRepo, bookingRepo, promotionRepo, cfg.Payments.Currencies)
 	cityUsecase := usecase.NewCityUsecase(cityRepo, countryRepo)
 	countryUsecase := usecase.NewCountryUsecase(countryRepo)
-	fileUsecase := usecase.NewFileUsecase(fileRepo)
+	fileUsecase := usecase.NewFileUsecase(fileStore, cfg.Storage.OrphanGrace)
+	authUsecase := usecase.NewAuthUsecase(
+		authSessionRepo,
+		userRepo,
+		tokenSigner,
+		cfg.Auth.BotToken,
+		cfg.Auth.InitDataMaxAge,
+		cfg.Auth.AccessTokenTTL,
+		cfg.Auth.RefreshTokenTTL,
+	)
+	idempotencyUsecase := usecase.NewIdempotencyUsecase(idempotencyKeyRepo, cfg.Idempotency.TTL, cfg.Idempotency.LockTimeout)
+	adminUsecase := usecase.NewAdminUsecase(
+		userRepo,
+		serviceRepo,
+		reviewRepo,
+		photoModerationRepo,
+		adminActionRepo,
+		authSessionRepo,
+		fileMetadataRepo,
+	)
+	auditUsecase := usecase.NewAuditUsecase(auditLogRepo, cfg.Audit.Retention)
+	rateLimitUsecase := usecase.NewRateLimitUsecase(rateLimitRepo, map[string]entity.RateLimit{
+		entity.RateLimitGroupIP:       entity.RateLimit(cfg.RateLimit.IP),
+		entity.RateLimitGroupDefault:  entity.RateLimit(cfg.RateLimit.Default),
+		entity.RateLimitGroupAuth:     entity.RateLimit(cfg.RateLimit.Auth),
+		entity.RateLimitGroupUploads:  entity.RateLimit(cfg.RateLimit.Uploads),
+		entity.RateLimitGroupBookings: entity.RateLimit(cfg.RateLimit.Bookings),
+		entity.RateLimitGroupReviews:  entity.RateLimit(cfg.RateLimit.Reviews),
+		entity.RateLimitGroupClicks:   entity.RateLimit(cfg.RateLimit.Clicks),
+	})
 
 	userHandler := handler.NewUserHandler(userUsecase)
 	userPreferencesHandler := handler.NewUserPreferencesHandler(userPreferencesUsecase)
@@ -100,36 +186,129 @@ func main() {
 	cityHandler := handler.NewCityHandler(cityUsecase)
 	countryHandler := handler.NewCountryHandler(countryUsecase)
 	fileHandler := handler.NewFileHandler(fileUsecase)
+	promotionHandler := handler.NewPromotionHandler(promotionUsecase)
+	authHandler := handler.NewAuthHandler(authUsecase)
+	adminHandler := handler.NewAdminHandler(adminUsecase)
+	auditHandler := handler.NewAuditHandler(auditUsecase)
+	healthHandler := handler.NewHealthHandler(
+		handler.HealthCheck{Name: "postgres", Check: pg.Ping},
+		handler.HealthCheck{Name: "storage", Check: fileRepo.Ping},
+	)
 
 	// Инициализация роутера
-	r := router.NewRouter(
-		userHandler,
-		userPreferencesHandler,
-		masterProfileHandler,
-		subscriptionHandler,
-		myMasterHandler,
-		serviceHandler,
-		serviceCategoryHandler,
-		bookingHandler,
-		scheduleSlotHandler,
-		reviewHandler,
-		paymentHandler,
-		cityHandler,
-		countryHandler,
-		fileHandler,
-		userUsecase,
-		botToken,
-	)
+	r := router.NewRouter(router.Options{
+		CORS:               cfg.CORS,
+		RateLimit:          cfg.RateLimit,
+		API:                cfg.API,
+		HealthHandler:      healthHandler,
+		AuthUsecase:        authUsecase,
+		AdminUsecase:       adminUsecase,
+		IdempotencyUsecase: idempotencyUsecase,
+		RateLimitUsecase:   rateLimitUsecase,
+	}, router.Version{
+		Prefix: "/v1",
+		Modules: []handler.RouteRegistrar{
+			authHandler,
+			userHandler,
+			userPreferencesHandler,
+			masterProfileHandler,
+			subscriptionHandler,
+			myMasterHandler,
+			serviceHandler,
+			serviceCategoryHandler,
+			bookingHandler,
+			scheduleSlotHandler,
+			reviewHandler,
+			paymentHandler,
+			cityHandler,
+			countryHandler,
+			fileHandler,
+			promotionHandler,
+			adminHandler,
+			auditHandler,
+		},
+	})
+
+	// Метрики отдаются на отдельном порту, без авторизации Telegram
+	adminMux := http.NewServeMux()
+	adminMux.Handle("/metrics", metrics.Handler())
+	adminServer := &http.Server{
+		Addr:        ":" + cfg.Server.AdminPort,
+		Handler:     adminMux,
+		ReadTimeout: cfg.Server.ReadTimeout,
+	}
 
-	// Запуск сервера
 	server := &http.Server{
-		Addr:         ":" + cfg.Port,
+		Addr:         ":" + cfg.Server.Port,
 		Handler:      r,
-		ReadTimeout:  10 * time.Second,
-		WriteTimeout: 10 * time.Second,
+		ReadTimeout:  cfg.Server.ReadTimeout,
+		WriteTimeout: cfg.Server.WriteTimeout,
 	}
-	fmt.Printf("Starting server on :8080\n")
-	if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-		panic(fmt.Errorf("failed to start server: %w", err))
+
+	// Компоненты останавливаются в обратном порядке: сначала сервер
+	// дожидается запросов, затем воркеры, и только потом закрывается база
+	components := lifecycle.NewManager()
+	components.Add(lifecycle.Component{Name: "tracing", Stop: shutdownTracing})
+	components.Add(lifecycle.Component{Name: "postgres", Stop: func(context.Context) error { return pg.Close() }})
+	// Фоновое завершение закончившихся рекламных кампаний
+	components.Add(lifecycle.Worker("promotion expiry", func(ctx context.Context) {
+		promotionUsecase.RunExpiry(ctx, cfg.Workers.PromotionExpiry)
+	}))
+	// Фоновое удаление просроченных ключей идемпотентности
+	components.Add(lifecycle.Worker("idempotency cleanup", func(ctx context.Context) {
+		idempotencyUsecase.RunCleanup(ctx, cfg.Workers.IdempotencyCleanup)
+	}))
+	// Фоновое удаление истекших сессий
+	components.Add(lifecycle.Worker("auth cleanup", func(ctx context.Context) {
+		authUsecase.RunCleanup(ctx, cfg.Workers.AuthCleanup)
+	}))
+	// Фоновое удаление устаревших записей журнала аудита
+	components.Add(lifecycle.Worker("audit retention", func(ctx context.Context) {
+		auditUsecase.RunRetention(ctx, cfg.Workers.AuditRetention)
+	}))
+	// Фоновое удаление заполнившихся корзин лимитов
+	components.Add(lifecycle.Worker("rate limit cleanup", func(ctx context.Context) {
+		rateLimitUsecase.RunCleanup(ctx, cfg.Workers.RateLimitCleanup)
+	}))
+	components.Add(lifecycle.Worker("file cleanup", func(ctx context.Context) {
+		fileUsecase.RunCleanup(ctx, cfg.Workers.FileCleanup)
+	}))
+	components.Add(lifecycle.Server("admin server", adminServer))
+	components.Add(lifecycle.Server("server", server))
+	// Проба готовности падает раньше, чем сервер перестает принимать соединения
+	components.Add(lifecycle.Component{
+		Name: "readiness",
+		Stop: func(ctx context.Context) error {
+			healthHandler.SetDraining()
+			select {
+			case <-time.After(cfg.Server.Shutdown.DrainDelay):
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		},
+	})
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	if err := components.Start(ctx); err != nil {
+		fatal("failed to start", "error", err)
 	}
+	<-ctx.Done()
+	stop()
+
+	slog.Info("shutting down", "timeout", cfg.Server.Shutdown.Timeout)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.Server.Shutdown.Timeout)
+	defer cancel()
+	if err := components.Stop(shutdownCtx); err != nil {
+		fatal("failed to shut down cleanly", "error", err)
+	}
+	slog.Info("server stopped")
+}
+
+// fatal logs an error that prevents the application from running and exits.
+func fatal(msg string, args ...any) {
+	slog.Error(msg, args...)
+	os.Exit(1)
 }
@@ -0,0 +1,13 @@
+package config
+
+import "time"
+
+type APIConfig struct {
+	// LegacyRoutes also serves /v1 at the root for clients that have not
+	// moved to the versioned paths yet
+	LegacyRoutes bool `yaml:"legacy_routes" env:"API_LEGACY_ROUTES"`
+	// DeprecatedAt and Sunset are announced by the root routes in the
+	// Deprecation and Sunset headers
+	DeprecatedAt time.Time `yaml:"deprecated_at" env:"API_DEPRECATED_AT"`
+	Sunset       time.Time `yaml:"sunset" env:"API_SUNSET"`
+}
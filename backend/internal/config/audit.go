@@ -0,0 +1,8 @@
+package config
+
+import "time"
+
+type AuditConfig struct {
+	// Retention is how long audit log entries are kept
+	Retention time.Duration `yaml:"retention" env:"AUDIT_RETENTION"`
+}
@@ -0,0 +1,18 @@
+package config
+
+import "time"
+
+type AuthConfig struct {
+	// BotToken validates Telegram initData
+	BotToken string `yaml:"bot_token" env:"TELEGRAM_BOT_TOKEN" secret:"true"`
+	// SigningKeys verify access tokens, the first one also signs them
+	SigningKeys     []SigningKey  `yaml:"signing_keys" env:"AUTH_SIGNING_KEYS" secret:"true"`
+	InitDataMaxAge  time.Duration `yaml:"init_data_max_age" env:"AUTH_INIT_DATA_MAX_AGE"`
+	AccessTokenTTL  time.Duration `yaml:"access_token_ttl" env:"AUTH_ACCESS_TOKEN_TTL"`
+	RefreshTokenTTL time.Duration `yaml:"refresh_token_ttl" env:"AUTH_REFRESH_TOKEN_TTL"`
+}
+
+type SigningKey struct {
+	ID     string
+	Secret string
+}
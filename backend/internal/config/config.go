@@ -1,79 +1,213 @@
 package config
 
 import (
-	"fmt"
+	"flag"
 	"os"
-	"strconv"
 	"strings"
 	"time"
 )
 
+// Config is assembled from layers, each overriding the previous one:
+// defaults, the YAML file, environment variables (APP_ENV-prefixed ones,
+// e.g. PROD_DB_HOST, win over plain ones) and command line flags.
+//
+// Every setting is named by its YAML path, which is also its flag, e.g.
+// server.port and -server.port, and by the environment variable in its env
+// tag. Settings tagged secret are redacted when the config is printed.
 type Config struct {
-	Port     string
-	Postgres PostgresConfig
-	S3       S3Config
+	// Env selects the prefixed variables and the defaults, e.g. dev or prod
+	Env         string            `yaml:"-"`
+	Server      ServerConfig      `yaml:"server"`
+	API         APIConfig         `yaml:"api"`
+	Auth        AuthConfig        `yaml:"auth"`
+	CORS        CORSConfig        `yaml:"cors"`
+	Postgres    PostgresConfig    `yaml:"postgres"`
+	Storage     StorageConfig     `yaml:"storage"`
+	Payments    PaymentsConfig    `yaml:"payments"`
+	Promotion   PromotionConfig   `yaml:"promotion"`
+	Idempotency IdempotencyConfig `yaml:"idempotency"`
+	Audit       AuditConfig       `yaml:"audit"`
+	RateLimit   RateLimitConfig   `yaml:"rate_limit"`
+	Workers     WorkersConfig     `yaml:"workers"`
+	Log         LogConfig         `yaml:"log"`
+	Tracing     TracingConfig     `yaml:"tracing"`
 }
 
-func Load() *Config {
-	env := os.Getenv("APP_ENV")
-	fmt.Println("asdv ", env)
-	if env == "" {
-		env = "dev"
+func defaults(env string) *Config {
+	// Локально удобнее читаемые логи со всеми запросами к базе
+	logLevel, logFormat := "info", "json"
+	origins := []string{"https://miniapp.beautyton.com", "https://dev.miniapp.beautyton.com"}
+	if env == "dev" {
+		logLevel, logFormat = "debug", "text"
+		// Локальный фронтенд и туннели pinggy для проверки в Telegram
+		origins = append(origins, "http://localhost:3000", "https://*.pinggy.link")
 	}
-	fmt.Println(getEnv("DB_NAME", "beautyton", env))
 
 	return &Config{
-		Port: getEnv("PORT", "8080", env),
+		Env: env,
+		Server: ServerConfig{
+			Port:         "8080",
+			AdminPort:    "9090",
+			ReadTimeout:  10 * time.Second,
+			WriteTimeout: 10 * time.Second,
+			Shutdown: ShutdownConfig{
+				DrainDelay: 0,
+				Timeout:    30 * time.Second,
+			},
+		},
+		API: APIConfig{
+			LegacyRoutes: true,
+			DeprecatedAt: time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC),
+			Sunset:       time.Date(2027, time.April, 1, 0, 0, 0, 0, time.UTC),
+		},
+		Auth: AuthConfig{
+			InitDataMaxAge:  5 * time.Minute,
+			AccessTokenTTL:  15 * time.Minute,
+			RefreshTokenTTL: 720 * time.Hour,
+		},
+		CORS: CORSConfig{
+			AllowedOrigins: origins,
+			MaxAge:         10 * time.Minute,
+		},
 		Postgres: PostgresConfig{
-			Host:            getEnv("DB_HOST", "localhost", env),
-			Port:            getEnv("DB_PORT", "5432", env),
-			User:            getEnv("DB_USER", "postgres", env),
-			Password:        getEnv("DB_PASSWORD", "password", env),
-			DBName:          getEnv("DB_NAME", "beautyton", env),
-			SSLMode:         getEnv("DB_SSLMODE", "disable", env),
-			MaxOpenConns:    mustAtoi(getEnv("DB_MAX_OPEN_CONNS", "150", env)),
-			MaxIdleConns:    mustAtoi(getEnv("DB_MAX_IDLE_CONNS", "10", env)),
-			ConnMaxLifetime: mustParseDuration(getEnv("DB_CONN_MAX_LIFETIME", "1h", env)),
+			Host:               "localhost",
+			Port:               "5432",
+			User:               "postgres",
+			Password:           "password",
+			DBName:             "beautyton",
+			SSLMode:            "disable",
+			MaxOpenConns:       150,
+			MaxIdleConns:       10,
+			ConnMaxLifetime:    time.Hour,
+			SlowQueryThreshold: 200 * time.Millisecond,
+		},
+		Storage: StorageConfig{
+			Backend: "s3",
+			S3: S3Config{
+				Bucket: "beautyton-bucket",
+			},
+			Filesystem: FilesystemConfig{
+				Root: "data/files",
+			},
+			OrphanGrace: 24 * time.Hour,
+			UploadTTL:   15 * time.Minute,
+			DownloadTTL: time.Hour,
+		},
+		Payments: PaymentsConfig{
+			Currencies: []string{"TON", "XTR"},
 		},
-		S3: S3Config{
-			AccessKeyID:     getEnv("AWS_ACCESS_KEY_ID", "", env),
-			SecretAccessKey: getEnv("AWS_SECRET_ACCESS_KEY", "", env),
-			Region:          getEnv("AWS_REGION", "", env),
-			Bucket:          getEnv("AWS_S3_BUCKET", "beautyton-bucket", env),
-			Endpoint:        getEnv("AWS_S3_ENDPOINT", "", env),
+		Promotion: PromotionConfig{
+			Positions:         []int{0, 4, 9},
+			ImpressionCostTON: 0.001,
+		},
+		Idempotency: IdempotencyConfig{
+			TTL:         24 * time.Hour,
+			LockTimeout: time.Minute,
+		},
+		Audit: AuditConfig{
+			Retention: 8760 * time.Hour,
+		},
+		RateLimit: RateLimitConfig{
+			Store:    "memory",
+			IP:       Rate{Requests: 600, Period: time.Minute},
+			Default:  Rate{Requests: 300, Period: time.Minute},
+			Auth:     Rate{Requests: 30, Period: time.Minute},
+			Uploads:  Rate{Requests: 20, Period: time.Hour},
+			Bookings: Rate{Requests: 30, Period: time.Hour},
+			Reviews:  Rate{Requests: 10, Period: time.Hour},
+			Clicks:   Rate{Requests: 60, Period: time.Hour},
+		},
+		Workers: WorkersConfig{
+			PromotionExpiry:    time.Minute,
+			IdempotencyCleanup: time.Hour,
+			AuthCleanup:        time.Hour,
+			AuditRetention:     24 * time.Hour,
+			RateLimitCleanup:   10 * time.Minute,
+			FileCleanup:        time.Hour,
+		},
+		Log: LogConfig{
+			Level:  logLevel,
+			Format: logFormat,
+		},
+		Tracing: TracingConfig{
+			Exporter:    "none",
+			ServiceName: "beautyton-backend",
+			SampleRatio: 1,
 		},
 	}
 }
 
-func getEnv(key, fallback string, env string) string {
+// Load builds the config from all layers and validates it. args are the
+// command line arguments without the program name; the ones left after the
+// flags are returned, e.g. a maintenance command. A *ValidationError lists
+// every invalid setting at once.
+func Load(args []string) (*Config, []string, error) {
+	env := os.Getenv("APP_ENV")
+	if env == "" {
+		env = "dev"
+	}
+	cfg := defaults(env)
+	settings := settingsOf(cfg)
+
+	flags := flag.NewFlagSet("beautyton", flag.ContinueOnError)
+	configFile := flags.String("config", os.Getenv("CONFIG_FILE"), "path to a YAML config file (CONFIG_FILE)")
+	type override struct {
+		setting setting
+		value   string
+	}
+	var overrides []override
+	for _, s := range settings {
+		flags.Func(s.path, "overrides "+s.env, func(value string) error {
+			overrides = append(overrides, override{s, value})
+			return nil
+		})
+	}
+	if err := flags.Parse(args); err != nil {
+		return nil, nil, err
+	}
+
+	var problems []string
+	if *configFile != "" {
+		problems = append(problems, loadFile(*configFile, settings)...)
+	}
+	for _, s := range settings {
+		if value, source, ok := lookupEnv(s.env, env); ok {
+			if err := s.set(value); err != nil {
+				problems = append(problems, source+": "+err.Error())
+			}
+		}
+	}
+	for _, o := range overrides {
+		if err := o.setting.set(o.value); err != nil {
+			problems = append(problems, "-"+o.setting.path+": "+err.Error())
+		}
+	}
+	// Неразобранные значения остаются прежними, поэтому проверка не повторяет их ошибки
+	problems = append(problems, cfg.validate()...)
+	if len(problems) > 0 {
+		return nil, nil, &ValidationError{Problems: problems}
+	}
+	return cfg, flags.Args(), nil
+}
+
+// lookupEnv returns the value of key, preferring the variable prefixed with
+// the environment, and the name of the variable it was read from.
+func lookupEnv(key, env string) (string, string, bool) {
 	// Сначала проверяем переменную с префиксом окружения (например PROD_DB_HOST)
 	prefixedKey := strings.ToUpper(env) + "_" + key
-	fmt.Println("s", prefixedKey)
 	if val, exists := os.LookupEnv(prefixedKey); exists {
-		return val
+		return val, prefixedKey, true
 	}
 
 	// Затем проверяем переменную без префикса
 	if val, exists := os.LookupEnv(key); exists {
-		return val
-	}
-
-	// Возвращаем значение по умолчанию
-	return fallback
-}
-
-func mustAtoi(val string) int {
-	i, err := strconv.Atoi(val)
-	if err != nil {
-		panic(fmt.Sprintf("invalid int value: %s, err: %v", val, err))
+		return val, key, true
 	}
-	return i
+	return "", "", false
 }
 
-func mustParseDuration(val string) time.Duration {
-	d, err := time.ParseDuration(val)
-	if err != nil {
-		panic(fmt.Sprintf("invalid duration value: %s, err: %v", val, err))
-	}
-	return d
+// String prints the config as YAML with secrets redacted, so it is safe to
+// log.
+func (c *Config) String() string {
+	return c.Redacted()
 }
@@ -0,0 +1,179 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// setEnv sets the variables for the test, and unsets those given an empty
+// value, restoring all of them afterwards.
+func setEnv(t *testing.T, vars map[string]string) {
+	t.Helper()
+	for key, value := range vars {
+		t.Setenv(key, value)
+		if value == "" {
+			os.Unsetenv(key)
+		}
+	}
+}
+
+// writeFile writes a YAML config file for the test.
+func writeFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("write config file: %v", err)
+	}
+	return path
+}
+
+func TestLoadLayersOverrideEachOther(t *testing.T) {
+	setEnv(t, map[string]string{
+		"APP_ENV":             "dev",
+		"CONFIG_FILE":         "",
+		"TELEGRAM_BOT_TOKEN":  "token",
+		"AUTH_SIGNING_KEYS":   "k1:secret",
+		"PORT":                "8082",
+		"DEV_PORT":            "",
+		"LOG_LEVEL":           "error",
+		"DEV_LOG_LEVEL":       "",
+		"SERVER_READ_TIMEOUT": "",
+		"DB_HOST":             "plain-host",
+		"DEV_DB_HOST":         "prefixed-host",
+	})
+	path := writeFile(t, `
+server:
+  port: 8081
+  read_timeout: 20s
+log:
+  level: warn
+postgres:
+  host: yaml-host
+`)
+
+	cfg, args, err := Load([]string{"-config", path, "-server.port=8083", "print-config"})
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	// Only the file sets the timeout
+	if cfg.Server.ReadTimeout != 20*time.Second {
+		t.Errorf("server.read_timeout is %s, want 20s from the file", cfg.Server.ReadTimeout)
+	}
+	// The variable overrides the file
+	if cfg.Log.Level != "error" {
+		t.Errorf("log.level is %q, want error from LOG_LEVEL", cfg.Log.Level)
+	}
+	// The flag overrides the variable and the file
+	if cfg.Server.Port != "8083" {
+		t.Errorf("server.port is %q, want 8083 from the flag", cfg.Server.Port)
+	}
+	// The variable prefixed with the environment overrides the plain one
+	if cfg.Postgres.Host != "prefixed-host" {
+		t.Errorf("postgres.host is %q, want prefixed-host from DEV_DB_HOST", cfg.Postgres.Host)
+	}
+	// Settings no layer sets keep their defaults
+	if cfg.Server.WriteTimeout != 10*time.Second {
+		t.Errorf("server.write_timeout is %s, want the default 10s", cfg.Server.WriteTimeout)
+	}
+	if len(args) != 1 || args[0] != "print-config" {
+		t.Errorf("remaining arguments are %q, want the command", args)
+	}
+}
+
+func TestLoadReportsEveryProblem(t *testing.T) {
+	setEnv(t, map[string]string{
+		"APP_ENV":                "dev",
+		"CONFIG_FILE":            "",
+		"TELEGRAM_BOT_TOKEN":     "",
+		"DEV_TELEGRAM_BOT_TOKEN": "",
+		"AUTH_SIGNING_KEYS":      "",
+		"DEV_AUTH_SIGNING_KEYS":  "",
+		"PORT":                   "",
+		"DEV_PORT":               "",
+		"LOG_LEVEL":              "",
+		"DEV_LOG_LEVEL":          "",
+		"DB_MAX_OPEN_CONNS":      "many",
+		"DEV_DB_MAX_OPEN_CONNS":  "",
+	})
+	path := writeFile(t, `
+server:
+  read_timeout: soon
+  colour: blue
+log:
+  level: loud
+`)
+
+	_, _, err := Load([]string{"-config", path, "-server.port=0", "-server.write_timeout=later"})
+	var validation *ValidationError
+	if !errors.As(err, &validation) {
+		t.Fatalf("load: got %v, want a validation error", err)
+	}
+	// The problems of every layer are reported, then those of validation
+	want := []string{
+		path + ": unknown setting server.colour",
+		path + `: server.read_timeout: invalid duration "soon"`,
+		`DB_MAX_OPEN_CONNS: invalid integer "many"`,
+		`-server.write_timeout: invalid duration "later"`,
+		`server.port (PORT): invalid port "0"`,
+		"auth.bot_token (TELEGRAM_BOT_TOKEN): is required",
+		"auth.signing_keys (AUTH_SIGNING_KEYS): at least one key is required",
+		`log.level (LOG_LEVEL): must be debug, info, warn or error, got "loud"`,
+	}
+	if strings.Join(validation.Problems, "\n") != strings.Join(want, "\n") {
+		t.Errorf("got problems:\n%s\nwant:\n%s", strings.Join(validation.Problems, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestRedactedHidesSecrets(t *testing.T) {
+	cfg := defaults("prod")
+	cfg.Auth.BotToken = "bot-secret"
+	cfg.Auth.SigningKeys = []SigningKey{{ID: "k1", Secret: "key-secret"}, {ID: "k2", Secret: "old-secret"}}
+	cfg.Postgres.Password = "db-secret"
+	cfg.Postgres.Host = "db.internal"
+
+	out := cfg.Redacted()
+	for _, secret := range []string{"bot-secret", "key-secret", "old-secret", "db-secret"} {
+		if strings.Contains(out, secret) {
+			t.Errorf("printed config contains the secret %q:\n%s", secret, out)
+		}
+	}
+
+	var doc struct {
+		Auth struct {
+			BotToken    string   `yaml:"bot_token"`
+			SigningKeys []string `yaml:"signing_keys"`
+		} `yaml:"auth"`
+		Postgres struct {
+			Host     string `yaml:"host"`
+			Password string `yaml:"password"`
+		} `yaml:"postgres"`
+		Storage struct {
+			S3 struct {
+				SecretAccessKey string `yaml:"secret_access_key"`
+			} `yaml:"s3"`
+		} `yaml:"storage"`
+	}
+	if err := yaml.Unmarshal([]byte(out), &doc); err != nil {
+		t.Fatalf("printed config is not YAML: %v\n%s", err, out)
+	}
+	if doc.Auth.BotToken != redacted || doc.Postgres.Password != redacted {
+		t.Errorf("bot token is %q and database password %q, want both redacted", doc.Auth.BotToken, doc.Postgres.Password)
+	}
+	// Key IDs are kept for rotation
+	if len(doc.Auth.SigningKeys) != 2 || doc.Auth.SigningKeys[0] != "k1:"+redacted || doc.Auth.SigningKeys[1] != "k2:"+redacted {
+		t.Errorf("signing keys are printed as %q, want their IDs with redacted secrets", doc.Auth.SigningKeys)
+	}
+	// An unset secret is shown as unset, other settings as they are
+	if doc.Storage.S3.SecretAccessKey != "" {
+		t.Errorf("unset S3 secret is printed as %q, want empty", doc.Storage.S3.SecretAccessKey)
+	}
+	if doc.Postgres.Host != "db.internal" {
+		t.Errorf("postgres host is printed as %q, want db.internal", doc.Postgres.Host)
+	}
+}
@@ -0,0 +1,16 @@
+package config
+
+import "time"
+
+type CORSConfig struct {
+	// AllowedOrigins are the origins allowed to call the API from a browser:
+	// exact ones such as https://miniapp.beautyton.com, ones with a wildcard
+	// subdomain such as https://*.pinggy.link, or * for any origin
+	AllowedOrigins []string `yaml:"allowed_origins" env:"CORS_ALLOWED_ORIGINS"`
+	// AllowCredentials lets browsers send cookies and read responses to
+	// credentialed requests. The API authenticates with headers, so it is
+	// off by default
+	AllowCredentials bool `yaml:"allow_credentials" env:"CORS_ALLOW_CREDENTIALS"`
+	// MaxAge is how long browsers may cache a preflight response
+	MaxAge time.Duration `yaml:"max_age" env:"CORS_MAX_AGE"`
+}
@@ -0,0 +1,11 @@
+package config
+
+import "time"
+
+type IdempotencyConfig struct {
+	// TTL is how long a completed response is replayed for duplicates
+	TTL time.Duration `yaml:"ttl" env:"IDEMPOTENCY_TTL"`
+	// LockTimeout bounds an in-progress request, after it the key may be
+	// taken over by a retry
+	LockTimeout time.Duration `yaml:"lock_timeout" env:"IDEMPOTENCY_LOCK_TIMEOUT"`
+}
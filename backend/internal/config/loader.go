@@ -0,0 +1,306 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const redacted = "[REDACTED]"
+
+var (
+	durationType    = reflect.TypeOf(time.Duration(0))
+	signingKeysType = reflect.TypeOf([]SigningKey(nil))
+	rateType        = reflect.TypeOf(Rate{})
+	timeType        = reflect.TypeOf(time.Time{})
+)
+
+// setting is a single value of the config, such as server.port.
+type setting struct {
+	path   string
+	env    string
+	secret bool
+	value  reflect.Value
+}
+
+// settingsOf lists the settings of cfg in declaration order. Their values
+// point into cfg.
+func settingsOf(cfg *Config) []setting {
+	var settings []setting
+	collectSettings(reflect.ValueOf(cfg).Elem(), "", &settings)
+	return settings
+}
+
+func collectSettings(v reflect.Value, prefix string, settings *[]setting) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := field.Tag.Get("yaml")
+		if name == "" || name == "-" {
+			continue
+		}
+		if prefix != "" {
+			name = prefix + "." + name
+		}
+		if field.Type.Kind() == reflect.Struct && field.Type != rateType && field.Type != timeType {
+			collectSettings(v.Field(i), name, settings)
+			continue
+		}
+		*settings = append(*settings, setting{
+			path:   name,
+			env:    field.Tag.Get("env"),
+			secret: field.Tag.Get("secret") == "true",
+			value:  v.Field(i),
+		})
+	}
+}
+
+// set parses a value given as text, lists are comma-separated. On error the
+// setting keeps its previous value.
+func (s setting) set(text string) error {
+	switch s.value.Type() {
+	case durationType:
+		d, err := time.ParseDuration(strings.TrimSpace(text))
+		if err != nil {
+			return fmt.Errorf("invalid duration %q", text)
+		}
+		s.value.SetInt(int64(d))
+		return nil
+	case timeType:
+		t, err := parseTime(strings.TrimSpace(text))
+		if err != nil {
+			return fmt.Errorf("invalid time %q, want 2006-01-02 or RFC 3339", text)
+		}
+		s.value.Set(reflect.ValueOf(t))
+		return nil
+	case signingKeysType:
+		keys, err := parseSigningKeys(text)
+		if err != nil {
+			return err
+		}
+		s.value.Set(reflect.ValueOf(keys))
+		return nil
+	case rateType:
+		rate, err := parseRate(text)
+		if err != nil {
+			return err
+		}
+		s.value.Set(reflect.ValueOf(rate))
+		return nil
+	}
+
+	switch s.value.Kind() {
+	case reflect.String:
+		s.value.SetString(text)
+	case reflect.Int:
+		i, err := strconv.Atoi(strings.TrimSpace(text))
+		if err != nil {
+			return fmt.Errorf("invalid integer %q", text)
+		}
+		s.value.SetInt(int64(i))
+	case reflect.Bool:
+		b, err := strconv.ParseBool(strings.TrimSpace(text))
+		if err != nil {
+			return fmt.Errorf("invalid boolean %q", text)
+		}
+		s.value.SetBool(b)
+	case reflect.Float64:
+		f, err := strconv.ParseFloat(strings.TrimSpace(text), 64)
+		if err != nil {
+			return fmt.Errorf("invalid number %q", text)
+		}
+		s.value.SetFloat(f)
+	case reflect.Slice:
+		parts := splitList(text)
+		list := reflect.MakeSlice(s.value.Type(), len(parts), len(parts))
+		for i, part := range parts {
+			switch s.value.Type().Elem().Kind() {
+			case reflect.String:
+				list.Index(i).SetString(part)
+			case reflect.Int:
+				n, err := strconv.Atoi(part)
+				if err != nil {
+					return fmt.Errorf("invalid integer %q", part)
+				}
+				list.Index(i).SetInt(int64(n))
+			}
+		}
+		s.value.Set(list)
+	default:
+		panic("config: unsupported setting type " + s.value.Type().String())
+	}
+	return nil
+}
+
+func splitList(text string) []string {
+	var parts []string
+	for _, part := range strings.Split(text, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return parts
+}
+
+// parseTime accepts a date, taken as midnight UTC, or an RFC 3339 time.
+func parseTime(text string) (time.Time, error) {
+	if t, err := time.Parse(time.DateOnly, text); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, text)
+}
+
+// parseSigningKeys parses a comma-separated list of id:secret pairs.
+func parseSigningKeys(text string) ([]SigningKey, error) {
+	var keys []SigningKey
+	for _, part := range splitList(text) {
+		id, secret, ok := strings.Cut(part, ":")
+		if !ok || id == "" || secret == "" {
+			// Секрет не попадает в сообщение об ошибке
+			return nil, fmt.Errorf("invalid signing key %q, want id:secret", id)
+		}
+		keys = append(keys, SigningKey{ID: id, Secret: secret})
+	}
+	return keys, nil
+}
+
+// loadFile applies a YAML file laid out like the config, e.g.
+//
+//	server:
+//	  port: 8080
+//
+// Lists may be YAML sequences or comma-separated strings.
+func loadFile(path string, settings []setting) []string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return []string{err.Error()}
+	}
+	var doc map[string]any
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return []string{fmt.Sprintf("%s: %v", path, err)}
+	}
+
+	byPath := make(map[string]setting, len(settings))
+	for _, s := range settings {
+		byPath[s.path] = s
+	}
+	var problems []string
+	var walk func(node map[string]any, prefix string)
+	walk = func(node map[string]any, prefix string) {
+		// Порядок ключей в map случайный, а ошибки должны выводиться стабильно
+		keys := make([]string, 0, len(node))
+		for key := range node {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			name := key
+			if prefix != "" {
+				name = prefix + "." + key
+			}
+			s, ok := byPath[name]
+			if !ok {
+				if section, isMap := node[key].(map[string]any); isMap {
+					walk(section, name)
+					continue
+				}
+				problems = append(problems, fmt.Sprintf("%s: unknown setting %s", path, name))
+				continue
+			}
+			if err := s.set(yamlText(node[key])); err != nil {
+				problems = append(problems, fmt.Sprintf("%s: %s: %v", path, name, err))
+			}
+		}
+	}
+	walk(doc, "")
+	return problems
+}
+
+func yamlText(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case []any:
+		parts := make([]string, len(v))
+		for i, item := range v {
+			parts[i] = fmt.Sprint(item)
+		}
+		return strings.Join(parts, ",")
+	}
+	return fmt.Sprint(value)
+}
+
+// Redacted prints the config as YAML in the layout read by the config file.
+// Secrets that are set are replaced with [REDACTED].
+func (c *Config) Redacted() string {
+	var b strings.Builder
+	var sections []string
+	for _, s := range settingsOf(c) {
+		parts := strings.Split(s.path, ".")
+		common := 0
+		for common < len(sections) && common < len(parts)-1 && sections[common] == parts[common] {
+			common++
+		}
+		for i := common; i < len(parts)-1; i++ {
+			fmt.Fprintf(&b, "%s%s:\n", strings.Repeat("  ", i), parts[i])
+		}
+		sections = parts[:len(parts)-1]
+		fmt.Fprintf(&b, "%s%s: %s\n", strings.Repeat("  ", len(parts)-1), parts[len(parts)-1], s.format())
+	}
+	return b.String()
+}
+
+func (s setting) format() string {
+	switch s.value.Type() {
+	case durationType, rateType:
+		return strconv.Quote(fmt.Sprint(s.value.Interface()))
+	case timeType:
+		t := s.value.Interface().(time.Time)
+		if t.Equal(t.Truncate(24 * time.Hour)) {
+			return strconv.Quote(t.Format(time.DateOnly))
+		}
+		return strconv.Quote(t.Format(time.RFC3339))
+	case signingKeysType:
+		// Идентификаторы ключей нужны для ротации, сами ключи скрыты
+		keys := s.value.Interface().([]SigningKey)
+		parts := make([]string, len(keys))
+		for i, key := range keys {
+			parts[i] = strconv.Quote(key.ID + ":" + redacted)
+		}
+		return "[" + strings.Join(parts, ", ") + "]"
+	}
+
+	switch s.value.Kind() {
+	case reflect.String:
+		if s.secret && s.value.String() != "" {
+			return strconv.Quote(redacted)
+		}
+		return strconv.Quote(s.value.String())
+	case reflect.Slice:
+		parts := make([]string, s.value.Len())
+		for i := range parts {
+			parts[i] = fmt.Sprint(s.value.Index(i).Interface())
+			if s.value.Type().Elem().Kind() == reflect.String {
+				parts[i] = strconv.Quote(parts[i])
+			}
+		}
+		return "[" + strings.Join(parts, ", ") + "]"
+	}
+	return fmt.Sprint(s.value.Interface())
+}
+
+// ValidationError lists every invalid setting found while loading the
+// config.
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return "invalid configuration:\n  " + strings.Join(e.Problems, "\n  ")
+}
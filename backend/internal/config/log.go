@@ -0,0 +1,8 @@
+package config
+
+type LogConfig struct {
+	// Level is one of debug, info, warn, error
+	Level string `yaml:"level" env:"LOG_LEVEL"`
+	// Format is json or text
+	Format string `yaml:"format" env:"LOG_FORMAT"`
+}
@@ -0,0 +1,7 @@
+package config
+
+type PaymentsConfig struct {
+	// Currencies are the accepted payment currencies, e.g. TON and XTR
+	// (Telegram Stars)
+	Currencies []string `yaml:"currencies" env:"PAYMENT_CURRENCIES"`
+}
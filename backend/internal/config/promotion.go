@@ -0,0 +1,8 @@
+package config
+
+type PromotionConfig struct {
+	// Positions are zero-based indexes of the first catalog page where
+	// promoted masters are injected
+	Positions         []int   `yaml:"positions" env:"PROMO_POSITIONS"`
+	ImpressionCostTON float64 `yaml:"impression_cost_ton" env:"PROMO_IMPRESSION_COST_TON"`
+}
@@ -0,0 +1,58 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type RateLimitConfig struct {
+	// Store is memory for a single instance or postgres to share limits
+	// between instances
+	Store string `yaml:"store" env:"RATE_LIMIT_STORE"`
+	// TrustForwardedFor takes the client IP from X-Forwarded-For, enable it
+	// only behind a proxy that sets it
+	TrustForwardedFor bool `yaml:"trust_forwarded_for" env:"RATE_LIMIT_TRUST_FORWARDED_FOR"`
+	// IP applies to every request per client IP before authentication, so
+	// requests with invalid credentials are limited too
+	IP Rate `yaml:"ip" env:"RATE_LIMIT_IP"`
+	// Default applies to every authenticated route, the other groups apply
+	// on top of it
+	Default  Rate `yaml:"default" env:"RATE_LIMIT_DEFAULT"`
+	Auth     Rate `yaml:"auth" env:"RATE_LIMIT_AUTH"`
+	Uploads  Rate `yaml:"uploads" env:"RATE_LIMIT_UPLOADS"`
+	Bookings Rate `yaml:"bookings" env:"RATE_LIMIT_BOOKINGS"`
+	Reviews  Rate `yaml:"reviews" env:"RATE_LIMIT_REVIEWS"`
+	Clicks   Rate `yaml:"clicks" env:"RATE_LIMIT_CLICKS"`
+}
+
+// Rate allows Requests per Period, written as 10/1m, or off.
+type Rate struct {
+	Requests int
+	Period   time.Duration
+}
+
+func parseRate(text string) (Rate, error) {
+	text = strings.TrimSpace(text)
+	if text == "off" {
+		return Rate{}, nil
+	}
+	requests, period, ok := strings.Cut(text, "/")
+	n, err := strconv.Atoi(requests)
+	if !ok || err != nil || n <= 0 {
+		return Rate{}, fmt.Errorf("invalid rate %q, want requests/period such as 10/1m, or off", text)
+	}
+	d, err := time.ParseDuration(period)
+	if err != nil || d <= 0 {
+		return Rate{}, fmt.Errorf("invalid rate %q, want requests/period such as 10/1m, or off", text)
+	}
+	return Rate{Requests: n, Period: d}, nil
+}
+
+func (r Rate) String() string {
+	if r.Requests == 0 {
+		return "off"
+	}
+	return strconv.Itoa(r.Requests) + "/" + r.Period.String()
+}
@@ -1,9 +1,9 @@
 package config
 
 type S3Config struct {
-	AccessKeyID     string
-	SecretAccessKey string
-	Region          string
-	Bucket          string
-	Endpoint        string
+	AccessKeyID     string `yaml:"access_key_id" env:"AWS_ACCESS_KEY_ID"`
+	SecretAccessKey string `yaml:"secret_access_key" env:"AWS_SECRET_ACCESS_KEY" secret:"true"`
+	Region          string `yaml:"region" env:"AWS_REGION"`
+	Bucket          string `yaml:"bucket" env:"AWS_S3_BUCKET"`
+	Endpoint        string `yaml:"endpoint" env:"AWS_S3_ENDPOINT"`
 }
@@ -0,0 +1,20 @@
+package config
+
+import "time"
+
+type ServerConfig struct {
+	Port string `yaml:"port" env:"PORT"`
+	// AdminPort serves metrics, it must not be exposed publicly
+	AdminPort    string         `yaml:"admin_port" env:"ADMIN_PORT"`
+	ReadTimeout  time.Duration  `yaml:"read_timeout" env:"SERVER_READ_TIMEOUT"`
+	WriteTimeout time.Duration  `yaml:"write_timeout" env:"SERVER_WRITE_TIMEOUT"`
+	Shutdown     ShutdownConfig `yaml:"shutdown"`
+}
+
+type ShutdownConfig struct {
+	// DrainDelay is how long readiness fails before the server stops
+	// accepting connections, so the load balancer can take it out of rotation
+	DrainDelay time.Duration `yaml:"drain_delay" env:"SHUTDOWN_DRAIN_DELAY"`
+	// Timeout bounds the whole shutdown, including in-flight requests
+	Timeout time.Duration `yaml:"timeout" env:"SHUTDOWN_TIMEOUT"`
+}
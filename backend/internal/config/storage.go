@@ -0,0 +1,27 @@
+package config
+
+import "time"
+
+type StorageConfig struct {
+	// Backend keeps the files: s3, or filesystem and memory for local runs.
+	// The local backends cannot presign requests, files then pass through
+	// the API
+	Backend    string           `yaml:"backend" env:"STORAGE_BACKEND"`
+	S3         S3Config         `yaml:"s3"`
+	Filesystem FilesystemConfig `yaml:"filesystem"`
+	// OrphanGrace is how long a file nobody refers to is kept before it is
+	// deleted, e.g. a replaced photo or an upload that failed halfway
+	OrphanGrace time.Duration `yaml:"orphan_grace" env:"STORAGE_ORPHAN_GRACE"`
+	// PublicURL is the base URL of a CDN serving the bucket. Photos link to
+	// it when set, to presigned URLs otherwise
+	PublicURL string `yaml:"public_url" env:"STORAGE_PUBLIC_URL"`
+	// UploadTTL is how long a presigned upload may be used
+	UploadTTL time.Duration `yaml:"upload_ttl" env:"STORAGE_UPLOAD_TTL"`
+	// DownloadTTL is how long a presigned download URL may be used
+	DownloadTTL time.Duration `yaml:"download_ttl" env:"STORAGE_DOWNLOAD_TTL"`
+}
+
+type FilesystemConfig struct {
+	// Root is the directory the files are kept in
+	Root string `yaml:"root" env:"STORAGE_FILESYSTEM_ROOT"`
+}
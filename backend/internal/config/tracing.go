@@ -0,0 +1,10 @@
+package config
+
+type TracingConfig struct {
+	// Exporter is none, stdout or otlp. The OTLP endpoint and headers are
+	// read from the standard OTEL_EXPORTER_OTLP_* variables
+	Exporter    string `yaml:"exporter" env:"TRACING_EXPORTER"`
+	ServiceName string `yaml:"service_name" env:"TRACING_SERVICE_NAME"`
+	// SampleRatio is the share of new traces that are recorded
+	SampleRatio float64 `yaml:"sample_ratio" env:"TRACING_SAMPLE_RATIO"`
+}
@@ -0,0 +1,151 @@
+package config
+
+import (
+	"fmt"
+	"log/slog"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// validator collects problems, each named by its setting and variable so
+// that it can be fixed in any layer.
+type validator struct {
+	envs     map[string]string
+	problems []string
+}
+
+func (v *validator) check(ok bool, path, format string, args ...any) {
+	if ok {
+		return
+	}
+	name := path
+	if env := v.envs[path]; env != "" {
+		name += " (" + env + ")"
+	}
+	v.problems = append(v.problems, name+": "+fmt.Sprintf(format, args...))
+}
+
+func (v *validator) positive(d time.Duration, path string) {
+	v.check(d > 0, path, "must be positive, got %s", d)
+}
+
+func (c *Config) validate() []string {
+	v := &validator{envs: map[string]string{}}
+	for _, s := range settingsOf(c) {
+		v.envs[s.path] = s.env
+	}
+
+	v.check(validPort(c.Server.Port), "server.port", "invalid port %q", c.Server.Port)
+	v.check(validPort(c.Server.AdminPort), "server.admin_port", "invalid port %q", c.Server.AdminPort)
+	v.check(c.Server.AdminPort != c.Server.Port, "server.admin_port", "must differ from server.port")
+	v.positive(c.Server.ReadTimeout, "server.read_timeout")
+	v.positive(c.Server.WriteTimeout, "server.write_timeout")
+	v.check(c.Server.Shutdown.DrainDelay >= 0, "server.shutdown.drain_delay", "must not be negative")
+	v.positive(c.Server.Shutdown.Timeout, "server.shutdown.timeout")
+
+	if c.API.LegacyRoutes {
+		v.check(!c.API.DeprecatedAt.IsZero(), "api.deprecated_at", "is required with api.legacy_routes")
+		v.check(c.API.Sunset.After(c.API.DeprecatedAt), "api.sunset", "must be after api.deprecated_at")
+	}
+
+	v.check(c.Auth.BotToken != "", "auth.bot_token", "is required")
+	v.check(len(c.Auth.SigningKeys) > 0, "auth.signing_keys", "at least one key is required")
+	seen := map[string]bool{}
+	for _, key := range c.Auth.SigningKeys {
+		v.check(!seen[key.ID], "auth.signing_keys", "duplicate key id %q", key.ID)
+		seen[key.ID] = true
+	}
+	v.positive(c.Auth.InitDataMaxAge, "auth.init_data_max_age")
+	v.positive(c.Auth.AccessTokenTTL, "auth.access_token_ttl")
+	v.check(c.Auth.RefreshTokenTTL > c.Auth.AccessTokenTTL, "auth.refresh_token_ttl", "must be longer than auth.access_token_ttl")
+
+	for _, origin := range c.CORS.AllowedOrigins {
+		v.check(validOrigin(origin), "cors.allowed_origins", "invalid origin %q, want scheme://host[:port] or scheme://*.domain", origin)
+	}
+	v.check(!slices.Contains(c.CORS.AllowedOrigins, "*") || !c.CORS.AllowCredentials,
+		"cors.allowed_origins", "* cannot be used with cors.allow_credentials")
+	v.check(c.CORS.MaxAge >= 0, "cors.max_age", "must not be negative")
+
+	v.check(c.Postgres.Host != "", "postgres.host", "is required")
+	v.check(validPort(c.Postgres.Port), "postgres.port", "invalid port %q", c.Postgres.Port)
+	v.check(c.Postgres.DBName != "", "postgres.name", "is required")
+	v.check(c.Postgres.MaxOpenConns > 0, "postgres.max_open_conns", "must be positive")
+	v.check(c.Postgres.MaxIdleConns >= 0 && c.Postgres.MaxIdleConns <= c.Postgres.MaxOpenConns,
+		"postgres.max_idle_conns", "must be between 0 and postgres.max_open_conns")
+	v.check(c.Postgres.ConnMaxLifetime >= 0, "postgres.conn_max_lifetime", "must not be negative")
+
+	v.check(slices.Contains([]string{"s3", "filesystem", "memory"}, c.Storage.Backend), "storage.backend", "must be s3, filesystem or memory, got %q", c.Storage.Backend)
+	v.check(c.Storage.Backend != "s3" || c.Storage.S3.Bucket != "", "storage.s3.bucket", "is required")
+	v.check(c.Storage.Backend != "filesystem" || c.Storage.Filesystem.Root != "", "storage.filesystem.root", "is required")
+	v.positive(c.Storage.OrphanGrace, "storage.orphan_grace")
+	v.check(c.Storage.PublicURL == "" || validBaseURL(c.Storage.PublicURL), "storage.public_url", "invalid URL %q, want scheme://host[/path]", c.Storage.PublicURL)
+	// S3 не принимает подписи дольше недели
+	v.check(c.Storage.UploadTTL > 0 && c.Storage.UploadTTL <= 7*24*time.Hour, "storage.upload_ttl", "must be positive and at most 168h")
+	v.check(c.Storage.DownloadTTL > 0 && c.Storage.DownloadTTL <= 7*24*time.Hour, "storage.download_ttl", "must be positive and at most 168h")
+
+	v.check(len(c.Payments.Currencies) > 0, "payments.currencies", "at least one currency is required")
+
+	for _, position := range c.Promotion.Positions {
+		v.check(position >= 0, "promotion.positions", "position %d must not be negative", position)
+	}
+	v.check(c.Promotion.ImpressionCostTON >= 0, "promotion.impression_cost_ton", "must not be negative")
+
+	v.positive(c.Idempotency.TTL, "idempotency.ttl")
+	v.positive(c.Idempotency.LockTimeout, "idempotency.lock_timeout")
+	v.positive(c.Audit.Retention, "audit.retention")
+
+	v.check(c.RateLimit.Store == "memory" || c.RateLimit.Store == "postgres", "rate_limit.store", "must be memory or postgres, got %q", c.RateLimit.Store)
+
+	// Тикер воркера паникует на неположительном интервале
+	v.positive(c.Workers.PromotionExpiry, "workers.promotion_expiry")
+	v.positive(c.Workers.IdempotencyCleanup, "workers.idempotency_cleanup")
+	v.positive(c.Workers.AuthCleanup, "workers.auth_cleanup")
+	v.positive(c.Workers.AuditRetention, "workers.audit_retention")
+	v.positive(c.Workers.RateLimitCleanup, "workers.rate_limit_cleanup")
+	v.positive(c.Workers.FileCleanup, "workers.file_cleanup")
+
+	var level slog.Level
+	v.check(level.UnmarshalText([]byte(c.Log.Level)) == nil, "log.level", "must be debug, info, warn or error, got %q", c.Log.Level)
+	v.check(c.Log.Format == "json" || c.Log.Format == "text", "log.format", "must be json or text, got %q", c.Log.Format)
+
+	switch c.Tracing.Exporter {
+	case "none", "stdout", "otlp":
+	default:
+		v.check(false, "tracing.exporter", "must be none, stdout or otlp, got %q", c.Tracing.Exporter)
+	}
+	v.check(c.Tracing.ServiceName != "", "tracing.service_name", "is required")
+	v.check(c.Tracing.SampleRatio >= 0 && c.Tracing.SampleRatio <= 1, "tracing.sample_ratio", "must be between 0 and 1")
+
+	return v.problems
+}
+
+// validOrigin accepts an origin without a path, optionally with a wildcard
+// as its first label.
+func validOrigin(origin string) bool {
+	if origin == "*" {
+		return true
+	}
+	u, err := url.Parse(origin)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.User != nil ||
+		(u.Path != "" && u.Path != "/") || u.RawQuery != "" || u.Fragment != "" {
+		return false
+	}
+	host := strings.TrimPrefix(u.Hostname(), "*.")
+	return host != "" && !strings.Contains(host, "*")
+}
+
+// validBaseURL accepts an absolute http(s) URL that paths can be appended
+// to.
+func validBaseURL(base string) bool {
+	u, err := url.Parse(base)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != "" &&
+		u.RawQuery == "" && u.Fragment == ""
+}
+
+func validPort(port string) bool {
+	n, err := strconv.Atoi(port)
+	return err == nil && n > 0 && n < 65536
+}
@@ -0,0 +1,13 @@
+package config
+
+import "time"
+
+// WorkersConfig holds how often each background worker runs.
+type WorkersConfig struct {
+	PromotionExpiry    time.Duration `yaml:"promotion_expiry" env:"PROMO_EXPIRY_INTERVAL"`
+	IdempotencyCleanup time.Duration `yaml:"idempotency_cleanup" env:"IDEMPOTENCY_CLEANUP_INTERVAL"`
+	AuthCleanup        time.Duration `yaml:"auth_cleanup" env:"AUTH_CLEANUP_INTERVAL"`
+	AuditRetention     time.Duration `yaml:"audit_retention" env:"AUDIT_CLEANUP_INTERVAL"`
+	RateLimitCleanup   time.Duration `yaml:"rate_limit_cleanup" env:"RATE_LIMIT_CLEANUP_INTERVAL"`
+	FileCleanup        time.Duration `yaml:"file_cleanup" env:"FILE_CLEANUP_INTERVAL"`
+}
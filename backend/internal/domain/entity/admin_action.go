@@ -0,0 +1,28 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AdminAction is an entry of the audit trail of changes made through the
+// admin API.
+type AdminAction struct {
+	ID         uuid.UUID `gorm:"type:uuid;primaryKey"`
+	AdminID    uuid.UUID `gorm:"type:uuid;column:admin_id;not null;index"`
+	Action     string    `gorm:"type:varchar;not null;index"`
+	EntityType string    `gorm:"type:varchar;column:entity_type"`
+	EntityID   string    `gorm:"type:varchar;column:entity_id"`
+	Details    string    `gorm:"type:text"`
+	CreatedAt  time.Time `gorm:"column:created_at;index"`
+}
+
+type AdminActionFilter struct {
+	AdminID    *uuid.UUID
+	Action     string
+	EntityType string
+	EntityID   string
+	Page       int
+	PageSize   int
+}
@@ -0,0 +1,34 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AuditLog is an append-only record of a created, updated or deleted row.
+// Before and After hold JSON objects keyed by column: the whole row for
+// creates and deletes, only the changed columns for updates.
+type AuditLog struct {
+	ID         uuid.UUID   `gorm:"type:uuid;primaryKey"`
+	ActorID    *uuid.UUID  `gorm:"type:uuid;column:actor_id;index"`
+	Action     AuditAction `gorm:"type:varchar;not null"`
+	EntityType string      `gorm:"type:varchar;column:entity_type;not null;index:idx_audit_logs_entity"`
+	EntityID   string      `gorm:"type:varchar;column:entity_id;not null;index:idx_audit_logs_entity"`
+	Before     *string     `gorm:"type:jsonb"`
+	After      *string     `gorm:"type:jsonb"`
+	RequestID  string      `gorm:"type:varchar;column:request_id;index"`
+	CreatedAt  time.Time   `gorm:"column:created_at;not null;index"`
+}
+
+type AuditLogFilter struct {
+	ActorID    *uuid.UUID
+	Action     AuditAction
+	EntityType string
+	EntityID   string
+	RequestID  string
+	From       *time.Time
+	To         *time.Time
+	Page       int
+	PageSize   int
+}
@@ -0,0 +1,48 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AuthSession is a login of a user made by exchanging Telegram initData. A
+// revoked session rejects its access tokens and refresh tokens.
+type AuthSession struct {
+	ID         uuid.UUID  `gorm:"type:uuid;primaryKey"`
+	UserID     uuid.UUID  `gorm:"type:uuid;column:user_id;not null;index"`
+	TelegramID int64      `gorm:"column:telegram_id;not null"`
+	CreatedAt  time.Time  `gorm:"column:created_at"`
+	ExpiresAt  time.Time  `gorm:"column:expires_at;not null;index"`
+	RevokedAt  *time.Time `gorm:"column:revoked_at"`
+}
+
+// RefreshToken is a single-use token of a session. Only its SHA-256 hash is
+// stored; using it issues the next token of the session.
+type RefreshToken struct {
+	ID        uuid.UUID  `gorm:"type:uuid;primaryKey"`
+	SessionID uuid.UUID  `gorm:"type:uuid;column:session_id;not null;index"`
+	TokenHash string     `gorm:"type:char(64);column:token_hash;not null;uniqueIndex"`
+	CreatedAt time.Time  `gorm:"column:created_at"`
+	ExpiresAt time.Time  `gorm:"column:expires_at;not null"`
+	UsedAt    *time.Time `gorm:"column:used_at"`
+}
+
+// AccessClaims are carried by a signed access token.
+type AccessClaims struct {
+	SessionID  uuid.UUID
+	UserID     uuid.UUID
+	TelegramID int64
+	Role       UserRole
+	IssuedAt   time.Time
+	ExpiresAt  time.Time
+}
+
+// AuthTokens is a pair of tokens issued for a session.
+type AuthTokens struct {
+	SessionID        uuid.UUID
+	AccessToken      string
+	AccessExpiresAt  time.Time
+	RefreshToken     string
+	RefreshExpiresAt time.Time
+}
@@ -15,4 +15,15 @@ type Booking struct {
 	Status      BookingStatus `gorm:"type:varchar"`
 	CreatedAt   time.Time     `gorm:"column:created_at"`
 	UpdatedAt   time.Time     `gorm:"column:updated_at"`
+	Version     int64         `gorm:"not null;default:1"`
+
+	// Model booking: made under the service model offer, requires the client
+	// to allow the master to use photos of the result in the portfolio
+	IsModelBooking   bool `gorm:"column:is_model_booking;not null;default:false"`
+	PortfolioConsent bool `gorm:"column:portfolio_consent;not null;default:false"`
+
+	// Price the client pays: the model price of the service for a model
+	// booking, its regular price otherwise. It is set when the service is
+	// chosen
+	Price float64 `gorm:"type:decimal(10,2)"`
 }
@@ -6,6 +6,11 @@ type BookingStatus string
 type ScheduleSlotStatus string
 type SlotType string
 type UserRole string
+type MasterProfileSort string
+type PromotionStatus string
+type ModerationStatus string
+type PhotoOwnerType string
+type AuditAction string
 
 const (
 	PaymentTypePayment PaymentType = "payment"
@@ -30,4 +35,30 @@ const (
 
 	UserRoleMaster UserRole = "master"
 	UserRoleClient UserRole = "client"
+	UserRoleAdmin  UserRole = "admin"
+
+	MasterProfileSortRelevance    MasterProfileSort = "relevance"
+	MasterProfileSortRating       MasterProfileSort = "rating"
+	MasterProfileSortReviewCount  MasterProfileSort = "reviews"
+	MasterProfileSortPriceAsc     MasterProfileSort = "price_asc"
+	MasterProfileSortPriceDesc    MasterProfileSort = "price_desc"
+	MasterProfileSortDistance     MasterProfileSort = "distance"
+	MasterProfileSortNewest       MasterProfileSort = "newest"
+	MasterProfileSortAvailability MasterProfileSort = "availability"
+
+	PromotionStatusPending  PromotionStatus = "pending"
+	PromotionStatusActive   PromotionStatus = "active"
+	PromotionStatusExpired  PromotionStatus = "expired"
+	PromotionStatusCanceled PromotionStatus = "canceled"
+
+	ModerationStatusPending  ModerationStatus = "pending"
+	ModerationStatusApproved ModerationStatus = "approved"
+	ModerationStatusRejected ModerationStatus = "rejected"
+
+	PhotoOwnerUser    PhotoOwnerType = "user"
+	PhotoOwnerService PhotoOwnerType = "service"
+
+	AuditActionCreate AuditAction = "create"
+	AuditActionUpdate AuditAction = "update"
+	AuditActionDelete AuditAction = "delete"
 )
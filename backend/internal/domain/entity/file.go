@@ -1,8 +1,116 @@
 package entity
 
+import (
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// FilePurpose decides who may read a file.
+type FilePurpose string
+
+const (
+	// Photos are shown in the catalog, so any user may read them
+	FilePurposeAvatar       FilePurpose = "avatar"
+	FilePurposeServicePhoto FilePurpose = "service_photo"
+	// Attachments are private to their owner
+	FilePurposeAttachment FilePurpose = "attachment"
+)
+
+// Public reports whether any user may read files of the purpose. Others are
+// readable by their owner and admins only.
+func (p FilePurpose) Public() bool {
+	return p == FilePurposeAvatar || p == FilePurposeServicePhoto
+}
+
+// File is the registry entry of a stored object; ID is its key in the
+// storage. A file nothing refers to any more is deleted after a grace
+// period.
 type File struct {
-	ID       string
-	Name     string
-	Size     int64
-	MimeType string
+	ID       string      `gorm:"type:varchar;primaryKey"`
+	Name     string      `gorm:"type:varchar"`
+	OwnerID  uuid.UUID   `gorm:"type:uuid;column:owner_id;not null;index"`
+	Purpose  FilePurpose `gorm:"type:varchar;not null"`
+	Size     int64       `gorm:"not null"`
+	MimeType string      `gorm:"type:varchar;column:mime_type"`
+	// Checksum is the hex-encoded SHA-256 of the content
+	Checksum string `gorm:"type:varchar"`
+	// Variants are the smaller sizes stored next to a photo, see ObjectKey
+	Variants []ImageVariant `gorm:"type:jsonb;serializer:json"`
+	// ReferencedBy is the record using the file, see FileReference. It is
+	// empty while the file is uploading and once it has been released
+	ReferencedBy string    `gorm:"type:varchar;column:referenced_by;index"`
+	CreatedAt    time.Time `gorm:"column:created_at"`
+	// UpdatedAt changes with the reference, the grace period of an
+	// unreferenced file counts from it
+	UpdatedAt time.Time `gorm:"column:updated_at"`
+
+	// ETag, ModifiedAt and Range describe an object read from the storage,
+	// they are not registered. Range is the part that was read, nil for the
+	// whole object
+	ETag       string     `gorm:"-"`
+	ModifiedAt time.Time  `gorm:"-"`
+	Range      *ByteRange `gorm:"-"`
+}
+
+// ObjectKey is the storage key of a variant of the file. The full variant,
+// and any variant the file does not have, is the file itself.
+func (f *File) ObjectKey(variant ImageVariant) string {
+	if variant == ImageVariantFull || !f.HasVariant(variant) {
+		return f.ID
+	}
+	return f.ID + "." + string(variant)
+}
+
+// HasVariant reports whether a smaller size of the file is stored.
+func (f *File) HasVariant(variant ImageVariant) bool {
+	return slices.Contains(f.Variants, variant)
+}
+
+// FileReference names a record that uses files, e.g. user:<id>.
+func FileReference(kind PhotoOwnerType, id uuid.UUID) string {
+	return string(kind) + ":" + id.String()
+}
+
+// ByteRange selects bytes of a file like an HTTP Range header does: Start
+// to End inclusive, Start to the end of the file when End is negative, or
+// the last -Start bytes when Start is negative.
+type ByteRange struct {
+	Start int64
+	End   int64
+}
+
+// Resolve returns the first and last byte the range selects in a file of
+// size bytes, or false when it selects none.
+func (r ByteRange) Resolve(size int64) (ByteRange, bool) {
+	if r.Start < 0 {
+		if size == 0 {
+			return ByteRange{}, false
+		}
+		return ByteRange{Start: max(0, size+r.Start), End: size - 1}, true
+	}
+	if r.Start >= size || (r.End >= 0 && r.End < r.Start) {
+		return ByteRange{}, false
+	}
+	end := size - 1
+	if r.End >= 0 && r.End < end {
+		end = r.End
+	}
+	return ByteRange{Start: r.Start, End: end}, true
+}
+
+// Length is the number of bytes in a resolved range.
+func (r ByteRange) Length() int64 {
+	return r.End - r.Start + 1
+}
+
+// PresignedRequest is a request a client sends to the storage directly
+// until it expires, so the content does not pass through the API. Headers
+// are signed along with the URL and must be sent as given.
+type PresignedRequest struct {
+	Method    string
+	URL       string
+	Headers   map[string]string
+	ExpiresAt time.Time
 }
@@ -0,0 +1,27 @@
+package entity
+
+import "time"
+
+type IdempotencyKeyStatus string
+
+const (
+	IdempotencyKeyStatusInProgress IdempotencyKeyStatus = "in_progress"
+	IdempotencyKeyStatusCompleted  IdempotencyKeyStatus = "completed"
+)
+
+// IdempotencyKey records a mutating request sent with an Idempotency-Key
+// header. Keys are scoped to the Telegram user who sent them. While the
+// request is in progress ExpiresAt bounds the lock, so a crashed request
+// does not block retries forever; once completed it holds the response
+// replayed to duplicates until the TTL passes.
+type IdempotencyKey struct {
+	UserID          int64                `gorm:"primaryKey;autoIncrement:false;column:user_id"`
+	Key             string               `gorm:"type:varchar(255);primaryKey"`
+	Fingerprint     string               `gorm:"type:char(64);not null"`
+	Status          IdempotencyKeyStatus `gorm:"type:varchar;not null"`
+	ResponseStatus  int                  `gorm:"column:response_status"`
+	ResponseHeaders string               `gorm:"type:text;column:response_headers"`
+	ResponseBody    []byte               `gorm:"type:bytea;column:response_body"`
+	CreatedAt       time.Time            `gorm:"column:created_at"`
+	ExpiresAt       time.Time            `gorm:"column:expires_at;not null;index"`
+}
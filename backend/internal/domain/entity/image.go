@@ -0,0 +1,26 @@
+package entity
+
+// ImageVariant is a size a photo is stored in.
+type ImageVariant string
+
+const (
+	// ImageVariantThumb is a small square crop for lists and avatars
+	ImageVariantThumb ImageVariant = "thumb"
+	// ImageVariantCard fits catalog cards
+	ImageVariantCard ImageVariant = "card"
+	// ImageVariantFull is the whole photo, bounded for the screen. It is
+	// stored under the file ID itself
+	ImageVariantFull ImageVariant = "full"
+)
+
+// ImageVariants lists every variant made of an uploaded photo.
+var ImageVariants = []ImageVariant{ImageVariantThumb, ImageVariantCard, ImageVariantFull}
+
+// Image is one encoded variant of a photo, stripped of metadata.
+type Image struct {
+	Variant  ImageVariant
+	MimeType string
+	Width    int
+	Height   int
+	Data     []byte
+}
@@ -13,6 +13,56 @@ type MasterProfile struct {
 	Bio       string     `gorm:"type:varchar"`
 	Status    string     `gorm:"type:varchar"`
 	Rating    float64    `gorm:"type:decimal"`
+	Address   string     `gorm:"type:varchar"`
+	Latitude  *float64   `gorm:"type:double precision;index:idx_master_profiles_location"`
+	Longitude *float64   `gorm:"type:double precision;index:idx_master_profiles_location"`
 	CreatedAt time.Time  `gorm:"column:created_at"`
 	UpdatedAt time.Time  `gorm:"column:updated_at"`
+	Version   int64      `gorm:"not null;default:1"`
+}
+
+// NearbyMasterProfile is a master profile found by a location-aware query
+// together with its great-circle distance from the search point.
+type NearbyMasterProfile struct {
+	MasterProfile `gorm:"embedded"`
+	DistanceKm    float64 `gorm:"column:distance_km"`
+}
+
+// MasterProfileFilter describes catalog search criteria. Lat and Lon are
+// required for sorting by distance. LookingForModels keeps only masters with
+// an open model offer or a free model slot.
+type MasterProfileFilter struct {
+	Query            string
+	Category         string
+	City             string
+	PriceFrom        int
+	PriceTo          int
+	Rating           float64
+	LookingForModels bool
+	Lat              *float64
+	Lon              *float64
+	Sort             MasterProfileSort
+}
+
+// CatalogMasterProfile is a master profile returned by the catalog query
+// together with the value it was sorted by. Promoted entries are injected by
+// a promotion campaign and labelled with IsPromoted and PromotionID.
+type CatalogMasterProfile struct {
+	MasterProfile `gorm:"embedded"`
+	SortKey       float64    `gorm:"column:sort_key" json:"-"`
+	IsPromoted    bool       `gorm:"-"`
+	PromotionID   *uuid.UUID `gorm:"-"`
+}
+
+// MasterProfileCursor points at the last profile of a catalog page. Profiles
+// are ordered by (Key, ID), so the next page starts strictly after it.
+// Filter is a hash of the filter the page was listed with: a sort key is only
+// comparable within the same query, location and filters. Exclude lists the
+// masters promoted on the first page, left out of the following ones.
+type MasterProfileCursor struct {
+	Sort    MasterProfileSort `json:"s"`
+	Filter  string            `json:"f"`
+	Key     float64           `json:"k"`
+	ID      uuid.UUID         `json:"id"`
+	Exclude []uuid.UUID       `json:"x,omitempty"`
 }
@@ -10,6 +10,8 @@ type Payment struct {
 	ID               uuid.UUID     `gorm:"type:uuid;primaryKey"`
 	ClientID         *uuid.UUID    `gorm:"type:uuid;column:client_id"`
 	MasterID         *uuid.UUID    `gorm:"type:uuid;column:master_id"`
+	BookingID        *uuid.UUID    `gorm:"type:uuid;column:booking_id;index"`
+	PromotionID      *uuid.UUID    `gorm:"type:uuid;column:promotion_id;index"`
 	Amount           float64       `gorm:"type:decimal(10,2)"`
 	Currency         string        `gorm:"type:varchar(10)"`
 	Type             PaymentType   `gorm:"type:varchar"`
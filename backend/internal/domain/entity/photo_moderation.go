@@ -0,0 +1,21 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PhotoModeration queues an uploaded user or service photo for review. A
+// rejected photo is removed from its owner.
+type PhotoModeration struct {
+	ID          uuid.UUID        `gorm:"type:uuid;primaryKey"`
+	FileID      string           `gorm:"type:varchar;column:file_id;not null"`
+	OwnerType   PhotoOwnerType   `gorm:"type:varchar;column:owner_type;not null"`
+	OwnerID     uuid.UUID        `gorm:"type:uuid;column:owner_id;not null"`
+	Status      ModerationStatus `gorm:"type:varchar;not null;index"`
+	Reason      string           `gorm:"type:varchar"`
+	ModeratorID *uuid.UUID       `gorm:"type:uuid;column:moderator_id"`
+	CreatedAt   time.Time        `gorm:"column:created_at"`
+	ModeratedAt *time.Time       `gorm:"column:moderated_at"`
+}
@@ -0,0 +1,34 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Promotion is a paid campaign placing a master into catalog results. CityID
+// and CategoryID narrow the catalog searches it is shown in; nil matches any.
+// A campaign is pending until a TON payment of its budget completes.
+type Promotion struct {
+	ID          uuid.UUID       `gorm:"type:uuid;primaryKey"`
+	MasterID    uuid.UUID       `gorm:"type:uuid;column:master_id;not null;index"`
+	CityID      *uuid.UUID      `gorm:"type:uuid;column:city_id"`
+	CategoryID  *uuid.UUID      `gorm:"type:uuid;column:category_id"`
+	StartsAt    time.Time       `gorm:"column:starts_at;not null"`
+	EndsAt      time.Time       `gorm:"column:ends_at;not null"`
+	BudgetTON   float64         `gorm:"type:decimal(18,9);column:budget_ton;not null"`
+	SpentTON    float64         `gorm:"type:decimal(18,9);column:spent_ton;not null;default:0"`
+	Impressions int64           `gorm:"not null;default:0"`
+	Clicks      int64           `gorm:"not null;default:0"`
+	Status      PromotionStatus `gorm:"type:varchar;index"`
+	CreatedAt   time.Time       `gorm:"column:created_at"`
+	UpdatedAt   time.Time       `gorm:"column:updated_at"`
+}
+
+// PromotionClick records that a user clicked a promoted listing. A user
+// counts once per campaign, repeated clicks do not add to Clicks.
+type PromotionClick struct {
+	PromotionID uuid.UUID `gorm:"type:uuid;primaryKey;column:promotion_id"`
+	UserID      uuid.UUID `gorm:"type:uuid;primaryKey;column:user_id"`
+	CreatedAt   time.Time `gorm:"column:created_at"`
+}
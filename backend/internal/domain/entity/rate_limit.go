@@ -0,0 +1,33 @@
+package entity
+
+import "time"
+
+// Route groups limited separately. Every request counts against the IP
+// group before authentication and every authenticated request against the
+// default group, the others are stricter limits on top of it.
+const (
+	RateLimitGroupIP       = "ip"
+	RateLimitGroupDefault  = "default"
+	RateLimitGroupAuth     = "auth"
+	RateLimitGroupUploads  = "uploads"
+	RateLimitGroupBookings = "bookings"
+	RateLimitGroupReviews  = "reviews"
+	RateLimitGroupClicks   = "clicks"
+)
+
+// RateLimit allows Requests per Period. Requests come from a token bucket
+// holding up to Requests tokens and refilled evenly over Period, so short
+// bursts are allowed while the average rate stays bounded. A zero limit
+// disables limiting.
+type RateLimit struct {
+	Requests int
+	Period   time.Duration
+}
+
+// RateLimitBucket is the token bucket of one subject, e.g. a user, in one
+// route group. Tokens are refilled lazily on the next take.
+type RateLimitBucket struct {
+	Key       string    `gorm:"type:varchar(255);primaryKey"`
+	Tokens    float64   `gorm:"type:double precision;not null"`
+	UpdatedAt time.Time `gorm:"column:updated_at;not null;index"`
+}
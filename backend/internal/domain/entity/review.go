@@ -2,9 +2,13 @@ package entity
 
 import "github.com/google/uuid"
 
+// Review is shown publicly once approved by a moderator. Reviews created
+// before moderation existed count as approved.
 type Review struct {
-	ID        uuid.UUID `gorm:"type:uuid;primaryKey"`
-	BookingID uuid.UUID `gorm:"type:uuid;column:booking_id"`
-	Rating    int       `gorm:"type:integer"`
-	Comment   string    `gorm:"type:text"`
+	ID               uuid.UUID        `gorm:"type:uuid;primaryKey"`
+	BookingID        uuid.UUID        `gorm:"type:uuid;column:booking_id"`
+	Rating           int              `gorm:"type:integer"`
+	Comment          string           `gorm:"type:text"`
+	ModerationStatus ModerationStatus `gorm:"type:varchar;column:moderation_status;not null;default:approved;index"`
+	ModerationReason string           `gorm:"type:varchar;column:moderation_reason"`
 }
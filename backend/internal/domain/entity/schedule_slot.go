@@ -15,6 +15,8 @@ type ScheduleSlot struct {
 	EndTime   time.Time          `gorm:"type:timestamp;not null"`
 	Status    ScheduleSlotStatus `gorm:"type:schedule_slot_status;not null"`
 	SlotType  SlotType           `gorm:"type:slot_type;not null"`
+	ForModels bool               `gorm:"column:for_models;not null;default:false"`
 	CreatedAt time.Time          `gorm:"type:timestamp;not null;default:now()"`
 	UpdatedAt time.Time          `gorm:"type:timestamp;not null;default:now()"`
+	Version   int64              `gorm:"not null;default:1"`
 }
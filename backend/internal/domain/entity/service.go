@@ -16,4 +16,11 @@ type Service struct {
 	Price       float64    `gorm:"type:decimal(10,2)"`
 	Duration    string     `gorm:"type:varchar"`
 	CreatedAt   time.Time  `gorm:"column:created_at"`
+	Version     int64      `gorm:"not null;default:1"`
+
+	// Model offer: the master looks for models to practice on this service
+	// at ModelPrice (0 means free), for at most ModelBookingsLimit bookings
+	ModelOffer         bool     `gorm:"column:model_offer;not null;default:false"`
+	ModelPrice         *float64 `gorm:"type:decimal(10,2);column:model_price"`
+	ModelBookingsLimit int      `gorm:"column:model_bookings_limit;not null;default:0"`
 }
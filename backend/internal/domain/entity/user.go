@@ -16,4 +16,11 @@ type User struct {
 	CreatedAt time.Time `gorm:"column:created_at"`
 	UpdatedAt time.Time `gorm:"column:updated_at"`
 	TonWallet string    `gorm:"type:varchar;column:ton_wallet"`
+	Version   int64     `gorm:"not null;default:1"`
+
+	// A banned user is blocked until unbanned, a suspended one until
+	// SuspendedUntil passes
+	BannedAt       *time.Time `gorm:"column:banned_at"`
+	SuspendedUntil *time.Time `gorm:"column:suspended_until"`
+	BlockReason    string     `gorm:"type:varchar;column:block_reason"`
 }
@@ -2,6 +2,102 @@ package errors
 
 import "errors"
 
+// Code is a machine-readable error kind returned to clients. The HTTP layer
+// maps every code to a status.
+type Code string
+
+const (
+	CodeBadRequest           Code = "bad_request"
+	CodeValidation           Code = "validation"
+	CodeUnauthorized         Code = "unauthorized"
+	CodePaymentRequired      Code = "payment_required"
+	CodeForbidden            Code = "forbidden"
+	CodeNotFound             Code = "not_found"
+	CodeConflict             Code = "conflict"
+	CodePreconditionFailed   Code = "precondition_failed"
+	CodePreconditionRequired Code = "precondition_required"
+	CodeUnprocessable        Code = "unprocessable"
+	CodeTooManyRequests      Code = "too_many_requests"
+	CodeInternal             Code = "internal"
+	CodeNotImplemented       Code = "not_implemented"
+	CodeRangeNotSatisfiable  Code = "range_not_satisfiable"
+)
+
+// Error is a domain error safe to show to clients. Message is in English and
+// doubles as the key of its translations. Details carries structured data,
+// e.g. the list of invalid fields.
+type Error struct {
+	Code    Code
+	Message string
+	Details interface{}
+	Err     error
+}
+
+func New(code Code, message string) *Error {
+	return &Error{Code: code, Message: message}
+}
+
+func BadRequest(message string) *Error           { return New(CodeBadRequest, message) }
+func Validation(message string) *Error           { return New(CodeValidation, message) }
+func Unauthorized(message string) *Error         { return New(CodeUnauthorized, message) }
+func PaymentRequired(message string) *Error      { return New(CodePaymentRequired, message) }
+func Forbidden(message string) *Error            { return New(CodeForbidden, message) }
+func NotFound(message string) *Error             { return New(CodeNotFound, message) }
+func Conflict(message string) *Error             { return New(CodeConflict, message) }
+func PreconditionFailed(message string) *Error   { return New(CodePreconditionFailed, message) }
+func PreconditionRequired(message string) *Error { return New(CodePreconditionRequired, message) }
+func Unprocessable(message string) *Error        { return New(CodeUnprocessable, message) }
+func TooManyRequests(message string) *Error      { return New(CodeTooManyRequests, message) }
+func NotImplemented(message string) *Error       { return New(CodeNotImplemented, message) }
+
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return e.Message + ": " + e.Err.Error()
+	}
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Is matches errors with the same code and message, so copies made by
+// WithDetails and Wrap still match their sentinel.
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Code == e.Code && t.Message == e.Message
+}
+
+// WithDetails returns a copy of the error carrying details.
+func (e *Error) WithDetails(details interface{}) *Error {
+	c := *e
+	c.Details = details
+	return &c
+}
+
+// Wrap returns a copy of the error with err as its cause. The cause is
+// logged but never shown to clients.
+func (e *Error) Wrap(err error) *Error {
+	c := *e
+	c.Err = err
+	return &c
+}
+
+// As returns the domain error in err's chain, if any.
+func As(err error) (*Error, bool) {
+	var e *Error
+	if errors.As(err, &e) {
+		return e, true
+	}
+	return nil, false
+}
+
 var (
-	ErrRecordNotFound = errors.New("record not found")
+	ErrRecordNotFound            = NotFound("record not found")
+	ErrModelBookingsLimitReached = Conflict("model bookings limit reached")
+	ErrAccessDenied              = Forbidden("access denied")
+	ErrVersionConflict           = PreconditionFailed("resource was modified by another request")
+	ErrIfMatchRequired           = PreconditionRequired("If-Match header is required")
+	ErrPresignNotSupported       = NotImplemented("storage does not support presigned requests")
+	ErrRangeNotSatisfiable       = New(CodeRangeNotSatisfiable, "range not satisfiable")
 )
@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/Vanv1k/BeautyTON/internal/domain/entity"
+)
+
+type AdminActionRepository interface {
+	Create(ctx context.Context, action *entity.AdminAction) error
+	List(ctx context.Context, filter entity.AdminActionFilter) ([]entity.AdminAction, int64, error)
+}
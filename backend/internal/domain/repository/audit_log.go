@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/Vanv1k/BeautyTON/internal/domain/entity"
+)
+
+// AuditLogRepository reads the audit log. Entries are written by the
+// database layer itself as part of every change, and are only ever removed
+// by retention.
+type AuditLogRepository interface {
+	List(ctx context.Context, filter entity.AuditLogFilter) ([]entity.AuditLog, int64, error)
+	DeleteBefore(ctx context.Context, before time.Time) (int64, error)
+}
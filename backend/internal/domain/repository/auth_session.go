@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/Vanv1k/BeautyTON/internal/domain/entity"
+)
+
+type AuthSessionRepository interface {
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.AuthSession, error)
+	Create(ctx context.Context, session *entity.AuthSession, token *entity.RefreshToken) error
+	GetRefreshToken(ctx context.Context, tokenHash string) (*entity.RefreshToken, error)
+	// UseRefreshToken marks the token as used and stores next in its session.
+	// It returns ErrRecordNotFound if the token was used concurrently.
+	UseRefreshToken(ctx context.Context, id uuid.UUID, next *entity.RefreshToken) error
+	Revoke(ctx context.Context, id uuid.UUID) error
+	RevokeByUser(ctx context.Context, userID uuid.UUID) error
+	DeleteExpired(ctx context.Context) (int64, error)
+}
@@ -11,6 +11,8 @@ import (
 type BookingRepository interface {
 	GetByID(ctx context.Context, id uuid.UUID) (*entity.Booking, error)
 	Create(ctx context.Context, booking *entity.Booking) error
+	CreateModelBooking(ctx context.Context, booking *entity.Booking) error
 	Update(ctx context.Context, booking *entity.Booking) error
+	UpdateModelBooking(ctx context.Context, booking *entity.Booking) error
 	Delete(ctx context.Context, id uuid.UUID) error
 }
@@ -3,11 +3,29 @@ package repository
 import (
 	"context"
 	"io"
+	"time"
 
 	"github.com/Vanv1k/BeautyTON/internal/domain/entity"
 )
 
 type FileRepository interface {
 	Upload(ctx context.Context, file *entity.File, content io.Reader) error
-	Get(ctx context.Context, id string) (*entity.File, io.Reader, error)
+	// Get reads an object, only the bytes in rng when it is set. The file
+	// describes the whole object, its Range the bytes read. A missing object
+	// is ErrRecordNotFound, a range outside it ErrRangeNotSatisfiable.
+	Get(ctx context.Context, id string, rng *entity.ByteRange) (*entity.File, io.ReadCloser, error)
+	// Stat returns the size, MIME type and, when the storage knows it, the
+	// checksum of an object without reading it. A missing object is
+	// ErrRecordNotFound.
+	Stat(ctx context.Context, id string) (*entity.File, error)
+	// PresignUpload issues a request storing file.ID. The storage accepts
+	// only file.Size bytes of file.MimeType and, when file.Checksum is set,
+	// only content with that checksum.
+	PresignUpload(ctx context.Context, file *entity.File, ttl time.Duration) (*entity.PresignedRequest, error)
+	// PresignDownload issues a request reading an object.
+	PresignDownload(ctx context.Context, id string, ttl time.Duration) (*entity.PresignedRequest, error)
+	// Delete removes the object, deleting a missing one is not an error.
+	Delete(ctx context.Context, id string) error
+	// Ping checks that the storage is reachable.
+	Ping(ctx context.Context) error
 }
@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/Vanv1k/BeautyTON/internal/domain/entity"
+)
+
+// FileMetadataRepository is the registry of the objects kept by
+// FileRepository.
+type FileMetadataRepository interface {
+	GetByID(ctx context.Context, id string) (*entity.File, error)
+	Create(ctx context.Context, file *entity.File) error
+	Update(ctx context.Context, file *entity.File) error
+	// Release clears the reference of the file if it is still held by
+	// reference.
+	Release(ctx context.Context, id, reference string) error
+	// ReleaseAll clears every reference held by reference, e.g. when the
+	// referencing record is deleted.
+	ReleaseAll(ctx context.Context, reference string) error
+	// ListUnreferenced returns up to limit files released before the given
+	// time.
+	ListUnreferenced(ctx context.Context, before time.Time, limit int) ([]entity.File, error)
+	// DeleteUnreferenced deletes the entry only if it is still unreferenced
+	// since before the given time and reports whether it did.
+	DeleteUnreferenced(ctx context.Context, id string, before time.Time) (bool, error)
+}
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/Vanv1k/BeautyTON/internal/domain/entity"
+)
+
+type IdempotencyKeyRepository interface {
+	// Acquire stores key as in progress unless a live record with the same
+	// user and key exists, in which case that record is returned instead.
+	Acquire(ctx context.Context, key *entity.IdempotencyKey) (*entity.IdempotencyKey, error)
+	Complete(ctx context.Context, key *entity.IdempotencyKey) error
+	Release(ctx context.Context, userID int64, key string) error
+	DeleteExpired(ctx context.Context) (int64, error)
+}
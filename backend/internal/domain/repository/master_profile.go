@@ -10,9 +10,14 @@ import (
 
 type MasterProfileRepository interface {
 	GetByID(ctx context.Context, id uuid.UUID) (*entity.MasterProfile, error)
+	GetByUserID(ctx context.Context, userID uuid.UUID) (*entity.MasterProfile, error)
 	Create(ctx context.Context, profile *entity.MasterProfile) error
 	Update(ctx context.Context, profile *entity.MasterProfile) error
 	Delete(ctx context.Context, id uuid.UUID) error
-	List(ctx context.Context, query string, category string, city string,
-		priceFrom int, priceTo int, rating float64, page int, pageSize int) ([]entity.MasterProfile, int64, error)
+	List(ctx context.Context, filter entity.MasterProfileFilter, cursor *entity.MasterProfileCursor,
+		page int, pageSize int) ([]entity.CatalogMasterProfile, int64, error)
+	// ListMatching returns the profiles among ids that List would return for
+	// the filter
+	ListMatching(ctx context.Context, filter entity.MasterProfileFilter, ids []uuid.UUID) ([]entity.MasterProfile, error)
+	ListNearby(ctx context.Context, lat, lon, radiusKm float64, excludeID *uuid.UUID, limit int) ([]entity.NearbyMasterProfile, error)
 }
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/Vanv1k/BeautyTON/internal/domain/entity"
+)
+
+type PhotoModerationRepository interface {
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.PhotoModeration, error)
+	Create(ctx context.Context, moderation *entity.PhotoModeration) error
+	Update(ctx context.Context, moderation *entity.PhotoModeration) error
+	ListByStatus(ctx context.Context, status entity.ModerationStatus, page, pageSize int) ([]entity.PhotoModeration, int64, error)
+}
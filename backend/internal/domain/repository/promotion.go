@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/Vanv1k/BeautyTON/internal/domain/entity"
+)
+
+type PromotionRepository interface {
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.Promotion, error)
+	Create(ctx context.Context, promotion *entity.Promotion) error
+	Update(ctx context.Context, promotion *entity.Promotion) error
+	// Activate starts a pending campaign once it is paid. Campaigns in other
+	// statuses are left as they are.
+	Activate(ctx context.Context, id uuid.UUID) error
+	ListActive(ctx context.Context, city, category string, limit int) ([]entity.Promotion, error)
+	RecordImpressions(ctx context.Context, ids []uuid.UUID, costTON float64) error
+	// RecordClick counts a click of the user on the promotion. Only the
+	// first click of each user counts.
+	RecordClick(ctx context.Context, id, userID uuid.UUID) error
+	ExpireFinished(ctx context.Context) (int64, error)
+}
@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/Vanv1k/BeautyTON/internal/domain/entity"
+)
+
+type RateLimitRepository interface {
+	// Take removes a token from the bucket stored under key, creating a full
+	// one if there is none. If the bucket is empty it is left unchanged and
+	// the time until the next token is returned.
+	Take(ctx context.Context, key string, limit entity.RateLimit) (retryAfter time.Duration, err error)
+	// DeleteIdle removes buckets not used since before. They have refilled,
+	// so removing them does not change any limit.
+	DeleteIdle(ctx context.Context, before time.Time) (int64, error)
+}
@@ -13,4 +13,5 @@ type ReviewRepository interface {
 	Create(ctx context.Context, review *entity.Review) error
 	Update(ctx context.Context, review *entity.Review) error
 	Delete(ctx context.Context, id uuid.UUID) error
+	ListByModerationStatus(ctx context.Context, status entity.ModerationStatus, page, pageSize int) ([]entity.Review, int64, error)
 }
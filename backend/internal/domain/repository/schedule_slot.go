@@ -13,6 +13,6 @@ type ScheduleSlotRepository interface {
 	Get(ctx context.Context, id uuid.UUID) (*entity.ScheduleSlot, error)
 	Update(ctx context.Context, slot *entity.ScheduleSlot) error
 	Delete(ctx context.Context, id uuid.UUID) error
-	List(ctx context.Context, masterID uuid.UUID) ([]entity.ScheduleSlot, error)
+	List(ctx context.Context, masterID uuid.UUID, forModels *bool) ([]entity.ScheduleSlot, error)
 	FindByTimeRange(ctx context.Context, masterID uuid.UUID, startTime, endTime time.Time) ([]entity.ScheduleSlot, error)
 }
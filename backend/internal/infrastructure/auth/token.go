@@ -0,0 +1,152 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Vanv1k/BeautyTON/internal/domain/entity"
+)
+
+var (
+	ErrMalformedToken = errors.New("malformed token")
+	ErrUnknownKey     = errors.New("unknown signing key")
+	ErrBadSignature   = errors.New("invalid token signature")
+	ErrTokenExpired   = errors.New("token expired")
+)
+
+// SigningKey is an HMAC key identified by the kid header of the tokens it
+// signs.
+type SigningKey struct {
+	ID     string
+	Secret []byte
+}
+
+// TokenSigner signs access tokens as HS256 JWTs. The first key signs new
+// tokens, all keys verify, so a key is rotated by putting a new one first
+// and dropping the old one once the tokens it signed have expired.
+type TokenSigner struct {
+	active SigningKey
+	keys   map[string][]byte
+}
+
+func NewTokenSigner(keys []SigningKey) (*TokenSigner, error) {
+	if len(keys) == 0 {
+		return nil, errors.New("no signing keys")
+	}
+	signer := &TokenSigner{active: keys[0], keys: make(map[string][]byte, len(keys))}
+	for _, key := range keys {
+		if key.ID == "" || len(key.Secret) == 0 {
+			return nil, fmt.Errorf("signing key %q is empty", key.ID)
+		}
+		signer.keys[key.ID] = key.Secret
+	}
+	return signer, nil
+}
+
+type header struct {
+	Alg string `json:"alg"`
+	Typ string `json:"typ"`
+	Kid string `json:"kid"`
+}
+
+type claims struct {
+	Sub  string `json:"sub"`
+	Sid  string `json:"sid"`
+	Tg   int64  `json:"tg"`
+	Role string `json:"role"`
+	Iat  int64  `json:"iat"`
+	Exp  int64  `json:"exp"`
+}
+
+func (s *TokenSigner) Sign(c *entity.AccessClaims) (string, error) {
+	h, err := json.Marshal(header{Alg: "HS256", Typ: "JWT", Kid: s.active.ID})
+	if err != nil {
+		return "", err
+	}
+	p, err := json.Marshal(claims{
+		Sub:  c.UserID.String(),
+		Sid:  c.SessionID.String(),
+		Tg:   c.TelegramID,
+		Role: string(c.Role),
+		Iat:  c.IssuedAt.Unix(),
+		Exp:  c.ExpiresAt.Unix(),
+	})
+	if err != nil {
+		return "", err
+	}
+	unsigned := encode(h) + "." + encode(p)
+	return unsigned + "." + encode(sign(s.active.Secret, unsigned)), nil
+}
+
+func (s *TokenSigner) Verify(token string) (*entity.AccessClaims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, ErrMalformedToken
+	}
+	var h header
+	if err := decode(parts[0], &h); err != nil || h.Alg != "HS256" {
+		return nil, ErrMalformedToken
+	}
+	secret, ok := s.keys[h.Kid]
+	if !ok {
+		return nil, ErrUnknownKey
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, ErrMalformedToken
+	}
+	if !hmac.Equal(signature, sign(secret, parts[0]+"."+parts[1])) {
+		return nil, ErrBadSignature
+	}
+
+	var c claims
+	if err := decode(parts[1], &c); err != nil {
+		return nil, ErrMalformedToken
+	}
+	userID, err := uuid.Parse(c.Sub)
+	if err != nil {
+		return nil, ErrMalformedToken
+	}
+	sessionID, err := uuid.Parse(c.Sid)
+	if err != nil {
+		return nil, ErrMalformedToken
+	}
+	expiresAt := time.Unix(c.Exp, 0)
+	if !time.Now().Before(expiresAt) {
+		return nil, ErrTokenExpired
+	}
+	return &entity.AccessClaims{
+		SessionID:  sessionID,
+		UserID:     userID,
+		TelegramID: c.Tg,
+		Role:       entity.UserRole(c.Role),
+		IssuedAt:   time.Unix(c.Iat, 0),
+		ExpiresAt:  expiresAt,
+	}, nil
+}
+
+func sign(secret []byte, data string) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+func encode(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decode(s string, v interface{}) error {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, v)
+}
@@ -0,0 +1,57 @@
+// Package memory keeps state in the process. It suits a single instance and
+// local runs; state is lost on restart and not shared between instances.
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/Vanv1k/BeautyTON/internal/domain/entity"
+	"github.com/Vanv1k/BeautyTON/internal/domain/repository"
+)
+
+type RateLimitRepository struct {
+	mu      sync.Mutex
+	buckets map[string]*entity.RateLimitBucket
+}
+
+func NewRateLimitRepository() repository.RateLimitRepository {
+	return &RateLimitRepository{buckets: map[string]*entity.RateLimitBucket{}}
+}
+
+func (r *RateLimitRepository) Take(ctx context.Context, key string, limit entity.RateLimit) (time.Duration, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	capacity := float64(limit.Requests)
+	rate := capacity / limit.Period.Seconds()
+	bucket, ok := r.buckets[key]
+	if !ok {
+		bucket = &entity.RateLimitBucket{Key: key, Tokens: capacity, UpdatedAt: now}
+		r.buckets[key] = bucket
+	}
+
+	tokens := min(capacity, bucket.Tokens+now.Sub(bucket.UpdatedAt).Seconds()*rate)
+	if tokens < 1 {
+		return time.Duration((1 - tokens) / rate * float64(time.Second)), nil
+	}
+	bucket.Tokens = tokens - 1
+	bucket.UpdatedAt = now
+	return 0, nil
+}
+
+func (r *RateLimitRepository) DeleteIdle(ctx context.Context, before time.Time) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var deleted int64
+	for key, bucket := range r.buckets {
+		if bucket.UpdatedAt.Before(before) {
+			delete(r.buckets, key)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
@@ -0,0 +1,56 @@
+package postgres
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"github.com/Vanv1k/BeautyTON/internal/domain/entity"
+	"github.com/Vanv1k/BeautyTON/internal/domain/repository"
+)
+
+type AdminActionRepository struct {
+	db *gorm.DB
+}
+
+func NewAdminActionRepository(postgres *Postgres) repository.AdminActionRepository {
+	return &AdminActionRepository{db: postgres.GetDB()}
+}
+
+func (r *AdminActionRepository) Create(ctx context.Context, action *entity.AdminAction) error {
+	return r.db.WithContext(ctx).Create(action).Error
+}
+
+func (r *AdminActionRepository) List(ctx context.Context, filter entity.AdminActionFilter) ([]entity.AdminAction, int64, error) {
+	var actions []entity.AdminAction
+	var total int64
+
+	queryBuilder := r.db.WithContext(ctx).Model(&entity.AdminAction{})
+	if filter.AdminID != nil {
+		queryBuilder = queryBuilder.Where("admin_id = ?", *filter.AdminID)
+	}
+	if filter.Action != "" {
+		queryBuilder = queryBuilder.Where("action = ?", filter.Action)
+	}
+	if filter.EntityType != "" {
+		queryBuilder = queryBuilder.Where("entity_type = ?", filter.EntityType)
+	}
+	if filter.EntityID != "" {
+		queryBuilder = queryBuilder.Where("entity_id = ?", filter.EntityID)
+	}
+
+	if err := queryBuilder.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (filter.Page - 1) * filter.PageSize
+	if err := queryBuilder.
+		Order("created_at DESC").
+		Offset(offset).
+		Limit(filter.PageSize).
+		Find(&actions).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return actions, total, nil
+}
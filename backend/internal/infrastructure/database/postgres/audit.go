@@ -0,0 +1,304 @@
+package postgres
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/Vanv1k/BeautyTON/internal/domain/entity"
+)
+
+const (
+	auditSkipKey   = "audit:skip"
+	auditBeforeKey = "audit:before"
+)
+
+// auditExcludedTables are not audited: the audit trails themselves and
+// short-lived credentials and caches.
+var auditExcludedTables = map[string]bool{
+	"audit_logs":         true,
+	"admin_actions":      true,
+	"idempotency_keys":   true,
+	"auth_sessions":      true,
+	"refresh_tokens":     true,
+	"rate_limit_buckets": true,
+}
+
+// registerAuditCallbacks makes every create, update and delete made through
+// GORM write audit log entries on the same connection, so a change made in a
+// transaction is rolled back together with its entries. The actor and
+// request ID are taken from the statement context.
+func registerAuditCallbacks(db *gorm.DB) error {
+	callbacks := []error{
+		db.Callback().Create().After("gorm:create").Register("audit:after_create", auditCreate),
+		db.Callback().Update().Before("gorm:update").Register("audit:before_update", auditSnapshot),
+		db.Callback().Update().After("gorm:update").Register("audit:after_update", auditUpdate),
+		db.Callback().Delete().Before("gorm:delete").Register("audit:before_delete", auditSnapshot),
+		db.Callback().Delete().After("gorm:delete").Register("audit:after_delete", auditDelete),
+	}
+	for _, err := range callbacks {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// migrateAudit makes audit_logs append-only. Rows may still be deleted by
+// retention.
+func migrateAudit(db *gorm.DB) error {
+	statements := []string{
+		`CREATE OR REPLACE FUNCTION audit_logs_append_only() RETURNS trigger AS $$
+		BEGIN
+			RAISE EXCEPTION 'audit_logs is append-only';
+		END;
+		$$ LANGUAGE plpgsql`,
+		"DROP TRIGGER IF EXISTS audit_logs_append_only ON audit_logs",
+		"CREATE TRIGGER audit_logs_append_only BEFORE UPDATE ON audit_logs FOR EACH ROW EXECUTE FUNCTION audit_logs_append_only()",
+	}
+	for _, stmt := range statements {
+		if err := db.Exec(stmt).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// withoutAudit skips the audit log for high-volume counters.
+func withoutAudit(db *gorm.DB) *gorm.DB {
+	return db.Set(auditSkipKey, true)
+}
+
+// audited reports whether the statement changes an audited table. Raw SQL
+// and tables with composite primary keys are not audited.
+func audited(db *gorm.DB) bool {
+	stmt := db.Statement
+	if db.Error != nil || stmt.Schema == nil || len(stmt.Schema.PrimaryFields) != 1 || auditExcludedTables[stmt.Table] {
+		return false
+	}
+	if skip, ok := db.Get(auditSkipKey); ok && skip == true {
+		return false
+	}
+	return true
+}
+
+func auditCreate(db *gorm.DB) {
+	if !audited(db) || db.Statement.RowsAffected == 0 {
+		return
+	}
+	var entries []entity.AuditLog
+	value := reflect.Indirect(db.Statement.ReflectValue)
+	switch value.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			entries = append(entries, newAuditLog(db, entity.AuditActionCreate, nil, auditValues(db, reflect.Indirect(value.Index(i)))))
+		}
+	case reflect.Struct:
+		entries = append(entries, newAuditLog(db, entity.AuditActionCreate, nil, auditValues(db, value)))
+	}
+	writeAuditLogs(db, entries)
+}
+
+// auditSnapshot keeps the rows an update or delete is about to change. They
+// are locked until the end of the transaction, so the snapshot stays
+// current until the change is written.
+func auditSnapshot(db *gorm.DB) {
+	if !audited(db) {
+		return
+	}
+	stmt := db.Statement
+	query := db.Session(&gorm.Session{NewDB: true}).Model(reflect.New(stmt.Schema.ModelType).Interface())
+	conditions := 0
+	if where, ok := stmt.Clauses["WHERE"]; ok {
+		query = query.Clauses(where.Expression)
+		conditions++
+	}
+	// The primary key of the model is only added to the statement later
+	model := reflect.Indirect(reflect.ValueOf(stmt.Model))
+	if model.Kind() == reflect.Struct && model.Type() == stmt.Schema.ModelType {
+		field := stmt.Schema.PrimaryFields[0]
+		if value, zero := field.ValueOf(stmt.Context, model); !zero {
+			query = query.Where(clause.Eq{Column: clause.PrimaryColumn, Value: value})
+			conditions++
+		}
+	}
+	// Without conditions GORM refuses the statement anyway
+	if conditions == 0 {
+		return
+	}
+
+	rows, err := loadAuditRows(db, query.Clauses(clause.Locking{Strength: "UPDATE"}))
+	if err != nil {
+		db.AddError(fmt.Errorf("failed to read rows for audit log: %w", err))
+		return
+	}
+	db.InstanceSet(auditBeforeKey, rows)
+}
+
+func auditUpdate(db *gorm.DB) {
+	before, ok := auditBefore(db)
+	if !ok || len(before) == 0 || db.Statement.RowsAffected == 0 {
+		return
+	}
+	keys := make([]interface{}, len(before))
+	for i, row := range before {
+		keys[i] = row[db.Statement.Schema.PrimaryFields[0].DBName]
+	}
+	query := db.Session(&gorm.Session{NewDB: true}).
+		Model(reflect.New(db.Statement.Schema.ModelType).Interface()).
+		Where(clause.IN{Column: clause.PrimaryColumn, Values: keys})
+	after, err := loadAuditRows(db, query)
+	if err != nil {
+		db.AddError(fmt.Errorf("failed to read rows for audit log: %w", err))
+		return
+	}
+	afterByID := make(map[string]map[string]interface{}, len(after))
+	for _, row := range after {
+		afterByID[auditEntityID(db, row)] = row
+	}
+
+	var entries []entity.AuditLog
+	for _, row := range before {
+		next, ok := afterByID[auditEntityID(db, row)]
+		if !ok {
+			continue
+		}
+		changedBefore, changedAfter := auditDiff(row, next)
+		if len(changedAfter) == 0 {
+			continue
+		}
+		entry := newAuditLog(db, entity.AuditActionUpdate, changedBefore, changedAfter)
+		entry.EntityID = auditEntityID(db, row)
+		entries = append(entries, entry)
+	}
+	writeAuditLogs(db, entries)
+}
+
+func auditDelete(db *gorm.DB) {
+	before, ok := auditBefore(db)
+	if !ok || db.Statement.RowsAffected == 0 {
+		return
+	}
+	entries := make([]entity.AuditLog, 0, len(before))
+	for _, row := range before {
+		entries = append(entries, newAuditLog(db, entity.AuditActionDelete, row, nil))
+	}
+	writeAuditLogs(db, entries)
+}
+
+func auditBefore(db *gorm.DB) ([]map[string]interface{}, bool) {
+	if !audited(db) {
+		return nil, false
+	}
+	value, ok := db.InstanceGet(auditBeforeKey)
+	if !ok {
+		return nil, false
+	}
+	return value.([]map[string]interface{}), true
+}
+
+// loadAuditRows runs a query for rows of the table changed by db.
+func loadAuditRows(db, query *gorm.DB) ([]map[string]interface{}, error) {
+	rows := reflect.New(reflect.SliceOf(db.Statement.Schema.ModelType))
+	if err := query.Find(rows.Interface()).Error; err != nil {
+		return nil, err
+	}
+	values := make([]map[string]interface{}, rows.Elem().Len())
+	for i := range values {
+		values[i] = auditValues(db, rows.Elem().Index(i))
+	}
+	return values, nil
+}
+
+// auditValues returns the columns of a row by name.
+func auditValues(db *gorm.DB, row reflect.Value) map[string]interface{} {
+	values := make(map[string]interface{}, len(db.Statement.Schema.DBNames))
+	for _, name := range db.Statement.Schema.DBNames {
+		value, _ := db.Statement.Schema.FieldsByDBName[name].ValueOf(db.Statement.Context, row)
+		values[name] = normalizeAuditValue(value)
+	}
+	return values
+}
+
+// normalizeAuditValue rounds times to the precision Postgres stores, so that
+// a value read back compares equal to the one written.
+func normalizeAuditValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case time.Time:
+		return v.UTC().Truncate(time.Microsecond)
+	case *time.Time:
+		if v == nil {
+			return nil
+		}
+		return v.UTC().Truncate(time.Microsecond)
+	}
+	return value
+}
+
+// auditDiff returns the columns that differ between two versions of a row.
+func auditDiff(before, after map[string]interface{}) (map[string]interface{}, map[string]interface{}) {
+	changedBefore := map[string]interface{}{}
+	changedAfter := map[string]interface{}{}
+	for name, value := range after {
+		previous, _ := json.Marshal(before[name])
+		next, _ := json.Marshal(value)
+		if !bytes.Equal(previous, next) {
+			changedBefore[name] = before[name]
+			changedAfter[name] = value
+		}
+	}
+	return changedBefore, changedAfter
+}
+
+func auditEntityID(db *gorm.DB, values map[string]interface{}) string {
+	return fmt.Sprint(values[db.Statement.Schema.PrimaryFields[0].DBName])
+}
+
+func newAuditLog(db *gorm.DB, action entity.AuditAction, before, after map[string]interface{}) entity.AuditLog {
+	ctx := db.Statement.Context
+	entry := entity.AuditLog{
+		ID:         uuid.New(),
+		Action:     action,
+		EntityType: db.Statement.Table,
+		Before:     auditJSON(before),
+		After:      auditJSON(after),
+	}
+	if before != nil {
+		entry.EntityID = auditEntityID(db, before)
+	} else {
+		entry.EntityID = auditEntityID(db, after)
+	}
+	// Changes made by background workers have no actor
+	if actorID, ok := ctx.Value("user_id").(uuid.UUID); ok {
+		entry.ActorID = &actorID
+	}
+	entry.RequestID, _ = ctx.Value("request_id").(string)
+	return entry
+}
+
+func auditJSON(values map[string]interface{}) *string {
+	if values == nil {
+		return nil
+	}
+	data, err := json.Marshal(values)
+	if err != nil {
+		data, _ = json.Marshal(map[string]string{"error": err.Error()})
+	}
+	s := string(data)
+	return &s
+}
+
+func writeAuditLogs(db *gorm.DB, entries []entity.AuditLog) {
+	if len(entries) == 0 {
+		return
+	}
+	if err := db.Session(&gorm.Session{NewDB: true}).Create(&entries).Error; err != nil {
+		db.AddError(fmt.Errorf("failed to write audit log: %w", err))
+	}
+}
@@ -0,0 +1,67 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/Vanv1k/BeautyTON/internal/domain/entity"
+	"github.com/Vanv1k/BeautyTON/internal/domain/repository"
+)
+
+type AuditLogRepository struct {
+	db *gorm.DB
+}
+
+func NewAuditLogRepository(postgres *Postgres) repository.AuditLogRepository {
+	return &AuditLogRepository{db: postgres.GetDB()}
+}
+
+func (r *AuditLogRepository) List(ctx context.Context, filter entity.AuditLogFilter) ([]entity.AuditLog, int64, error) {
+	var logs []entity.AuditLog
+	var total int64
+
+	queryBuilder := r.db.WithContext(ctx).Model(&entity.AuditLog{})
+	if filter.ActorID != nil {
+		queryBuilder = queryBuilder.Where("actor_id = ?", *filter.ActorID)
+	}
+	if filter.Action != "" {
+		queryBuilder = queryBuilder.Where("action = ?", filter.Action)
+	}
+	if filter.EntityType != "" {
+		queryBuilder = queryBuilder.Where("entity_type = ?", filter.EntityType)
+	}
+	if filter.EntityID != "" {
+		queryBuilder = queryBuilder.Where("entity_id = ?", filter.EntityID)
+	}
+	if filter.RequestID != "" {
+		queryBuilder = queryBuilder.Where("request_id = ?", filter.RequestID)
+	}
+	if filter.From != nil {
+		queryBuilder = queryBuilder.Where("created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		queryBuilder = queryBuilder.Where("created_at < ?", *filter.To)
+	}
+
+	if err := queryBuilder.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (filter.Page - 1) * filter.PageSize
+	if err := queryBuilder.
+		Order("created_at DESC").
+		Offset(offset).
+		Limit(filter.PageSize).
+		Find(&logs).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return logs, total, nil
+}
+
+func (r *AuditLogRepository) DeleteBefore(ctx context.Context, before time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Where("created_at < ?", before).Delete(&entity.AuditLog{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,102 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/Vanv1k/BeautyTON/internal/domain/entity"
+	"github.com/Vanv1k/BeautyTON/internal/domain/errors"
+	"github.com/Vanv1k/BeautyTON/internal/domain/repository"
+)
+
+type AuthSessionRepository struct {
+	db *gorm.DB
+}
+
+func NewAuthSessionRepository(postgres *Postgres) repository.AuthSessionRepository {
+	return &AuthSessionRepository{db: postgres.GetDB()}
+}
+
+func (r *AuthSessionRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.AuthSession, error) {
+	var session entity.AuthSession
+	if err := r.db.WithContext(ctx).First(&session, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrRecordNotFound
+		}
+		return nil, err
+	}
+	return &session, nil
+}
+
+func (r *AuthSessionRepository) Create(ctx context.Context, session *entity.AuthSession, token *entity.RefreshToken) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(session).Error; err != nil {
+			return err
+		}
+		return tx.Create(token).Error
+	})
+}
+
+func (r *AuthSessionRepository) GetRefreshToken(ctx context.Context, tokenHash string) (*entity.RefreshToken, error) {
+	var token entity.RefreshToken
+	if err := r.db.WithContext(ctx).Where("token_hash = ?", tokenHash).First(&token).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrRecordNotFound
+		}
+		return nil, err
+	}
+	return &token, nil
+}
+
+// UseRefreshToken only marks a token that is still unused, so of concurrent
+// refreshes with the same token exactly one succeeds.
+func (r *AuthSessionRepository) UseRefreshToken(ctx context.Context, id uuid.UUID, next *entity.RefreshToken) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&entity.RefreshToken{}).
+			Where("id = ? AND used_at IS NULL", id).
+			Update("used_at", time.Now())
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.ErrRecordNotFound
+		}
+		if err := tx.Create(next).Error; err != nil {
+			return err
+		}
+		return tx.Model(&entity.AuthSession{}).
+			Where("id = ?", next.SessionID).
+			Update("expires_at", next.ExpiresAt).Error
+	})
+}
+
+func (r *AuthSessionRepository) Revoke(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Model(&entity.AuthSession{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", time.Now()).Error
+}
+
+func (r *AuthSessionRepository) RevokeByUser(ctx context.Context, userID uuid.UUID) error {
+	return r.db.WithContext(ctx).Model(&entity.AuthSession{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", time.Now()).Error
+}
+
+// DeleteExpired deletes sessions that can no longer be refreshed together
+// with their refresh tokens.
+func (r *AuthSessionRepository) DeleteExpired(ctx context.Context) (int64, error) {
+	var deleted int64
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		expired := tx.Model(&entity.AuthSession{}).Select("id").Where("expires_at <= now()")
+		if err := tx.Where("session_id IN (?)", expired).Delete(&entity.RefreshToken{}).Error; err != nil {
+			return err
+		}
+		result := tx.Where("expires_at <= now()").Delete(&entity.AuthSession{})
+		deleted = result.RowsAffected
+		return result.Error
+	})
+	return deleted, err
+}
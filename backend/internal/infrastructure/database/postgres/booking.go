@@ -4,6 +4,7 @@ import (
 	"context"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"github.com/Vanv1k/BeautyTON/internal/domain/entity"
 	"github.com/Vanv1k/BeautyTON/internal/domain/errors"
@@ -30,14 +31,83 @@ func (r *BookingRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.
 }
 
 func (r *BookingRepository) Create(ctx context.Context, booking *entity.Booking) error {
+	if booking.Version == 0 {
+		booking.Version = 1
+	}
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return tx.Create(booking).Error
+	})
+}
+
+// CreateModelBooking creates a booking under the service model offer. The
+// service row is locked while counting, so concurrent requests cannot exceed
+// the model bookings limit.
+func (r *BookingRepository) CreateModelBooking(ctx context.Context, booking *entity.Booking) error {
+	if booking.Version == 0 {
+		booking.Version = 1
+	}
 	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		service, err := reserveModelPlace(tx, booking.ServiceID, booking.ID)
+		if err != nil {
+			return err
+		}
+		// The offer may have changed since the price was set
+		if service.ModelPrice != nil {
+			booking.Price = *service.ModelPrice
+		}
+
 		return tx.Create(booking).Error
 	})
 }
 
+// UpdateModelBooking updates a model booking. A booking that takes a new
+// place under the model offer, because it leaves the canceled status or moves
+// to another service, is checked against the limit as on creation.
+func (r *BookingRepository) UpdateModelBooking(ctx context.Context, booking *entity.Booking) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var stored entity.Booking
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&stored, booking.ID).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return errors.ErrRecordNotFound
+			}
+			return err
+		}
+		held := stored.IsModelBooking && stored.Status != entity.BookingStatusCanceled && stored.ServiceID == booking.ServiceID
+		if booking.Status != entity.BookingStatusCanceled && !held {
+			if _, err := reserveModelPlace(tx, booking.ServiceID, booking.ID); err != nil {
+				return err
+			}
+		}
+		return updateVersioned(tx, booking, &booking.Version)
+	})
+}
+
+// reserveModelPlace locks the service and checks that its model offer has a
+// place left for the booking, not counting the booking itself.
+func reserveModelPlace(tx *gorm.DB, serviceID, bookingID uuid.UUID) (*entity.Service, error) {
+	var service entity.Service
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&service, serviceID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrRecordNotFound
+		}
+		return nil, err
+	}
+
+	var count int64
+	if err := tx.Model(&entity.Booking{}).
+		Where("service_id = ? AND id <> ? AND is_model_booking AND status <> ?", service.ID, bookingID, entity.BookingStatusCanceled).
+		Count(&count).Error; err != nil {
+		return nil, err
+	}
+	if !service.ModelOffer || count >= int64(service.ModelBookingsLimit) {
+		return nil, errors.ErrModelBookingsLimitReached
+	}
+	return &service, nil
+}
+
 func (r *BookingRepository) Update(ctx context.Context, booking *entity.Booking) error {
 	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		return tx.Save(booking).Error
+		return updateVersioned(tx, booking, &booking.Version)
 	})
 }
 
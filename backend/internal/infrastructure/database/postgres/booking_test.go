@@ -0,0 +1,77 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Vanv1k/BeautyTON/internal/domain/entity"
+	er "github.com/Vanv1k/BeautyTON/internal/domain/errors"
+)
+
+func TestUpdateModelBookingChecksTheLimit(t *testing.T) {
+	db := openTestDB(t)
+	truncate(t, db, "bookings", "services")
+	repo := &BookingRepository{db: db.GetDB()}
+	ctx := context.Background()
+
+	modelPrice := 300.0
+	service := &entity.Service{ID: uuid.New(), Title: "Manicure", Price: 1000, Version: 1, ModelOffer: true, ModelPrice: &modelPrice, ModelBookingsLimit: 1}
+	if err := db.GetDB().Create(service).Error; err != nil {
+		t.Fatalf("create service: %v", err)
+	}
+	booking := func() *entity.Booking {
+		return &entity.Booking{
+			ID:               uuid.New(),
+			ClientID:         uuid.New(),
+			MasterID:         uuid.New(),
+			ServiceID:        service.ID,
+			BookingTime:      time.Now().Add(24 * time.Hour),
+			Status:           entity.BookingStatusPending,
+			IsModelBooking:   true,
+			PortfolioConsent: true,
+		}
+	}
+
+	first := booking()
+	if err := repo.CreateModelBooking(ctx, first); err != nil {
+		t.Fatalf("create first booking: %v", err)
+	}
+	first.Status = entity.BookingStatusCanceled
+	if err := repo.UpdateModelBooking(ctx, first); err != nil {
+		t.Fatalf("cancel first booking: %v", err)
+	}
+	second := booking()
+	if err := repo.CreateModelBooking(ctx, second); err != nil {
+		t.Fatalf("create second booking: %v", err)
+	}
+
+	// The place freed by the cancellation is taken
+	first.Status = entity.BookingStatusPending
+	if err := repo.UpdateModelBooking(ctx, first); !errors.Is(err, er.ErrModelBookingsLimitReached) {
+		t.Fatalf("restore first booking: got %v, want model bookings limit reached", err)
+	}
+	// A booking holding a place keeps it
+	second.Status = entity.BookingStatusConfirmed
+	if err := repo.UpdateModelBooking(ctx, second); err != nil {
+		t.Fatalf("confirm second booking: %v", err)
+	}
+
+	// Moving to another service takes a place there
+	other := &entity.Service{ID: uuid.New(), Title: "Pedicure", Price: 1000, Version: 1, ModelOffer: true, ModelPrice: &modelPrice, ModelBookingsLimit: 1}
+	if err := db.GetDB().Create(other).Error; err != nil {
+		t.Fatalf("create other service: %v", err)
+	}
+	third := booking()
+	third.ServiceID = other.ID
+	if err := repo.CreateModelBooking(ctx, third); err != nil {
+		t.Fatalf("create third booking: %v", err)
+	}
+	second.ServiceID = other.ID
+	if err := repo.UpdateModelBooking(ctx, second); !errors.Is(err, er.ErrModelBookingsLimitReached) {
+		t.Fatalf("move second booking to a full service: got %v, want model bookings limit reached", err)
+	}
+}
@@ -0,0 +1,81 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/Vanv1k/BeautyTON/internal/domain/entity"
+	"github.com/Vanv1k/BeautyTON/internal/domain/errors"
+	"github.com/Vanv1k/BeautyTON/internal/domain/repository"
+)
+
+type FileMetadataRepository struct {
+	db *gorm.DB
+}
+
+func NewFileMetadataRepository(postgres *Postgres) repository.FileMetadataRepository {
+	return &FileMetadataRepository{db: postgres.GetDB()}
+}
+
+func (r *FileMetadataRepository) GetByID(ctx context.Context, id string) (*entity.File, error) {
+	var file entity.File
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&file).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrRecordNotFound
+		}
+		return nil, err
+	}
+	return &file, nil
+}
+
+func (r *FileMetadataRepository) Create(ctx context.Context, file *entity.File) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return tx.Create(file).Error
+	})
+}
+
+// Update fails with ErrRecordNotFound once the entry has been collected, so
+// a file is never referenced after its object is gone.
+func (r *FileMetadataRepository) Update(ctx context.Context, file *entity.File) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(file).Select("*").Omit("created_at").Updates(file)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.ErrRecordNotFound
+		}
+		return nil
+	})
+}
+
+func (r *FileMetadataRepository) Release(ctx context.Context, id, reference string) error {
+	return r.db.WithContext(ctx).Model(&entity.File{}).
+		Where("id = ? AND referenced_by = ?", id, reference).
+		Update("referenced_by", "").Error
+}
+
+func (r *FileMetadataRepository) ReleaseAll(ctx context.Context, reference string) error {
+	return r.db.WithContext(ctx).Model(&entity.File{}).
+		Where("referenced_by = ?", reference).
+		Update("referenced_by", "").Error
+}
+
+func (r *FileMetadataRepository) ListUnreferenced(ctx context.Context, before time.Time, limit int) ([]entity.File, error) {
+	var files []entity.File
+	err := r.db.WithContext(ctx).
+		Where("referenced_by = '' AND updated_at < ?", before).
+		Order("updated_at ASC").
+		Limit(limit).
+		Find(&files).Error
+	return files, err
+}
+
+func (r *FileMetadataRepository) DeleteUnreferenced(ctx context.Context, id string, before time.Time) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Where("id = ? AND referenced_by = '' AND updated_at < ?", id, before).
+		Delete(&entity.File{})
+	return result.RowsAffected > 0, result.Error
+}
@@ -0,0 +1,77 @@
+package postgres
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/Vanv1k/BeautyTON/internal/domain/entity"
+	"github.com/Vanv1k/BeautyTON/internal/domain/repository"
+)
+
+type IdempotencyKeyRepository struct {
+	db *gorm.DB
+}
+
+func NewIdempotencyKeyRepository(postgres *Postgres) repository.IdempotencyKeyRepository {
+	return &IdempotencyKeyRepository{db: postgres.GetDB()}
+}
+
+// Acquire inserts key or takes over an expired record in a single statement,
+// so of concurrent duplicates exactly one wins and the others get the
+// winner's record.
+func (r *IdempotencyKeyRepository) Acquire(ctx context.Context, key *entity.IdempotencyKey) (*entity.IdempotencyKey, error) {
+	for {
+		result := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "user_id"}, {Name: "key"}},
+			DoUpdates: clause.AssignmentColumns([]string{
+				"fingerprint", "status", "response_status", "response_headers", "response_body", "created_at", "expires_at",
+			}),
+			Where: clause.Where{Exprs: []clause.Expression{clause.Expr{SQL: "idempotency_keys.expires_at <= now()"}}},
+		}).Create(key)
+		if result.Error != nil {
+			return nil, result.Error
+		}
+		if result.RowsAffected > 0 {
+			return nil, nil
+		}
+
+		var existing entity.IdempotencyKey
+		err := r.db.WithContext(ctx).
+			Where("user_id = ? AND key = ?", key.UserID, key.Key).
+			First(&existing).Error
+		if err == gorm.ErrRecordNotFound {
+			// Deleted by the cleanup between the two statements
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		return &existing, nil
+	}
+}
+
+func (r *IdempotencyKeyRepository) Complete(ctx context.Context, key *entity.IdempotencyKey) error {
+	return r.db.WithContext(ctx).Model(&entity.IdempotencyKey{}).
+		Where("user_id = ? AND key = ?", key.UserID, key.Key).
+		Updates(map[string]interface{}{
+			"status":           entity.IdempotencyKeyStatusCompleted,
+			"response_status":  key.ResponseStatus,
+			"response_headers": key.ResponseHeaders,
+			"response_body":    key.ResponseBody,
+			"expires_at":       key.ExpiresAt,
+		}).Error
+}
+
+// Release deletes an in-progress record so the request can be retried.
+func (r *IdempotencyKeyRepository) Release(ctx context.Context, userID int64, key string) error {
+	return r.db.WithContext(ctx).
+		Where("user_id = ? AND key = ? AND status = ?", userID, key, entity.IdempotencyKeyStatusInProgress).
+		Delete(&entity.IdempotencyKey{}).Error
+}
+
+func (r *IdempotencyKeyRepository) DeleteExpired(ctx context.Context) (int64, error) {
+	result := r.db.WithContext(ctx).Where("expires_at <= now()").Delete(&entity.IdempotencyKey{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,65 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// queryLogger logs GORM queries through slog: every query at debug level,
+// slow ones as warnings and failed ones as errors. Missing records are
+// expected and not logged as errors.
+type queryLogger struct {
+	slowThreshold time.Duration
+}
+
+func newQueryLogger(slowThreshold time.Duration) logger.Interface {
+	return &queryLogger{slowThreshold: slowThreshold}
+}
+
+// LogMode is ignored, the level is set on the slog logger.
+func (l *queryLogger) LogMode(logger.LogLevel) logger.Interface {
+	return l
+}
+
+func (l *queryLogger) Info(ctx context.Context, msg string, args ...interface{}) {
+	slog.InfoContext(ctx, fmt.Sprintf(msg, args...))
+}
+
+func (l *queryLogger) Warn(ctx context.Context, msg string, args ...interface{}) {
+	slog.WarnContext(ctx, fmt.Sprintf(msg, args...))
+}
+
+func (l *queryLogger) Error(ctx context.Context, msg string, args ...interface{}) {
+	slog.ErrorContext(ctx, fmt.Sprintf(msg, args...))
+}
+
+func (l *queryLogger) Trace(ctx context.Context, begin time.Time, fc func() (sql string, rowsAffected int64), err error) {
+	elapsed := time.Since(begin)
+	durationMS := float64(elapsed.Microseconds()) / 1000
+	switch {
+	case err != nil && !errors.Is(err, gorm.ErrRecordNotFound):
+		sql, rows := fc()
+		slog.ErrorContext(ctx, "query failed", "sql", sql, "rows", rows, "duration_ms", durationMS, "error", err)
+	case l.slowThreshold > 0 && elapsed > l.slowThreshold:
+		sql, rows := fc()
+		slog.WarnContext(ctx, "slow query", "sql", sql, "rows", rows, "duration_ms", durationMS)
+	case slog.Default().Enabled(ctx, slog.LevelDebug):
+		sql, rows := fc()
+		slog.DebugContext(ctx, "query", "sql", sql, "rows", rows, "duration_ms", durationMS)
+	}
+}
+
+// ParamsFilter keeps query parameters, which may hold personal data, out of
+// the logs unless debug logging is on.
+func (l *queryLogger) ParamsFilter(ctx context.Context, sql string, params ...interface{}) (string, []interface{}) {
+	if slog.Default().Enabled(ctx, slog.LevelDebug) {
+		return sql, params
+	}
+	return sql, nil
+}
@@ -2,6 +2,8 @@ package postgres
 
 import (
 	"context"
+	"math"
+	"strconv"
 	"strings"
 
 	"github.com/google/uuid"
@@ -31,7 +33,21 @@ func (r *MasterProfileRepository) GetByID(ctx context.Context, id uuid.UUID) (*e
 	return &profile, nil
 }
 
+func (r *MasterProfileRepository) GetByUserID(ctx context.Context, userID uuid.UUID) (*entity.MasterProfile, error) {
+	var profile entity.MasterProfile
+	if err := r.db.WithContext(ctx).Where("user_id = ?", userID).First(&profile).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrRecordNotFound
+		}
+		return nil, err
+	}
+	return &profile, nil
+}
+
 func (r *MasterProfileRepository) Create(ctx context.Context, profile *entity.MasterProfile) error {
+	if profile.Version == 0 {
+		profile.Version = 1
+	}
 	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		return tx.Create(profile).Error
 	})
@@ -39,7 +55,7 @@ func (r *MasterProfileRepository) Create(ctx context.Context, profile *entity.Ma
 
 func (r *MasterProfileRepository) Update(ctx context.Context, profile *entity.MasterProfile) error {
 	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		return tx.Save(profile).Error
+		return updateVersioned(tx, profile, &profile.Version)
 	})
 }
 
@@ -49,51 +65,339 @@ func (r *MasterProfileRepository) Delete(ctx context.Context, id uuid.UUID) erro
 	})
 }
 
-func (r *MasterProfileRepository) List(ctx context.Context, query, category, city string, priceFrom, priceTo int, rating float64, page, pageSize int) ([]entity.MasterProfile, int64, error) {
-	var profiles []entity.MasterProfile
+// Full-text search configuration. Catalog texts are a mix of Russian and
+// English, so every document and query is analyzed with both configurations.
+var searchConfigs = []string{"russian", "english"}
+
+// trigramThreshold is the minimal word similarity for a typo-tolerant match.
+const trigramThreshold = 0.3
+
+// searchDocumentSQL is the weighted tsvector of a master: username and
+// service titles rank highest, then categories, bio and service
+// descriptions. It is kept in master_profiles.search_document by the
+// triggers created in migrateSearch.
+var searchDocumentSQL = strings.Join([]string{
+	weightedVectorSQL("master.username", "A"),
+	weightedVectorSQL("services_search.titles", "A"),
+	weightedVectorSQL("services_search.categories", "B"),
+	weightedVectorSQL("master_bio", "C"),
+	weightedVectorSQL("services_search.descriptions", "D"),
+}, " || ")
+
+// searchDocumentFunctionSQL defines the function computing searchDocumentSQL
+// for a master. Services are aggregated into a single row, so the document
+// does not repeat the master texts per service.
+var searchDocumentFunctionSQL = `CREATE OR REPLACE FUNCTION master_search_document(master_user_id uuid, master_bio text)
+RETURNS tsvector LANGUAGE sql STABLE AS $$
+	SELECT ` + searchDocumentSQL + `
+	FROM (SELECT (SELECT username FROM users WHERE users.id = master_user_id) AS username) AS master
+	LEFT JOIN LATERAL (
+		SELECT string_agg(services.title, ' ') AS titles,
+			string_agg(services.description, ' ') AS descriptions,
+			string_agg(DISTINCT service_categories.name, ' ') AS categories
+		FROM services
+		LEFT JOIN service_categories ON service_categories.id = services.category_id
+		WHERE services.user_id = master_user_id
+	) AS services_search ON TRUE
+$$`
+
+// searchMatchSQL matches masters by the search document or by a typo in the
+// username, a service title or a category name. Every branch filters a
+// single indexed column, so the match is collected from the GIN indexes
+// instead of scanning the catalog. The <% operator compares against
+// pg_trgm.word_similarity_threshold, which List sets to trigramThreshold.
+var searchMatchSQL = `master_profiles.id IN (
+	SELECT master_profiles.id FROM master_profiles
+	WHERE master_profiles.search_document @@ ` + searchQuerySQL() + `
+	UNION
+	SELECT master_profiles.id FROM master_profiles
+	JOIN users ON users.id = master_profiles.user_id
+	WHERE @query <% users.username
+	UNION
+	SELECT master_profiles.id FROM master_profiles
+	JOIN services ON services.user_id = master_profiles.user_id
+	WHERE @query <% services.title
+	UNION
+	SELECT master_profiles.id FROM master_profiles
+	JOIN services ON services.user_id = master_profiles.user_id
+	JOIN service_categories ON service_categories.id = services.category_id
+	WHERE @query <% service_categories.name
+)`
+
+// searchSimilaritySQL is the best typo-tolerant similarity of the query to
+// the username, a service title or a category name of a master.
+const searchSimilaritySQL = `GREATEST(word_similarity(@query, coalesce(users.username, '')), COALESCE((
+	SELECT max(GREATEST(word_similarity(@query, services.title), word_similarity(@query, coalesce(service_categories.name, ''))))
+	FROM services
+	LEFT JOIN service_categories ON service_categories.id = services.category_id
+	WHERE services.user_id = master_profiles.user_id
+), 0))`
+
+func weightedVectorSQL(column, weight string) string {
+	parts := make([]string, 0, len(searchConfigs))
+	for _, cfg := range searchConfigs {
+		parts = append(parts, "setweight(to_tsvector('"+cfg+"', coalesce("+column+", '')), '"+weight+"')")
+	}
+	return strings.Join(parts, " || ")
+}
+
+func searchQuerySQL() string {
+	parts := make([]string, 0, len(searchConfigs))
+	for _, cfg := range searchConfigs {
+		parts = append(parts, "websearch_to_tsquery('"+cfg+"', @query)")
+	}
+	return "(" + strings.Join(parts, " || ") + ")"
+}
+
+// lookingForModelsSQL matches masters with a model offer that still has free
+// places or with an upcoming free slot reserved for models.
+const lookingForModelsSQL = `EXISTS (
+	SELECT 1 FROM services
+	WHERE services.user_id = master_profiles.user_id
+		AND services.model_offer
+		AND (SELECT count(*) FROM bookings
+			WHERE bookings.service_id = services.id
+				AND bookings.is_model_booking
+				AND bookings.status <> 'canceled') < services.model_bookings_limit
+) OR EXISTS (
+	SELECT 1 FROM schedule_slots
+	WHERE schedule_slots.master_id = master_profiles.id
+		AND schedule_slots.for_models
+		AND schedule_slots.status = 'free'
+		AND schedule_slots.start_time > now()
+)`
+
+// missingSortKey orders profiles without a value for an ascending sort key
+// (no services, no free slots, no location) after all others.
+const missingSortKey = 1e18
+
+// catalogSort is the SQL expression a catalog sort option orders by. Every
+// expression yields a non-null double so it can be used as a keyset cursor.
+// Relevance and distance depend on the request and are built in List.
+type catalogSort struct {
+	expr string
+	desc bool
+}
+
+var catalogSorts = map[entity.MasterProfileSort]catalogSort{
+	entity.MasterProfileSortRelevance: {
+		desc: true,
+	},
+	entity.MasterProfileSortDistance: {
+		desc: false,
+	},
+	entity.MasterProfileSortRating: {
+		expr: "master_profiles.rating::float8",
+		desc: true,
+	},
+	entity.MasterProfileSortReviewCount: {
+		expr: `(SELECT count(*) FROM reviews
+			JOIN bookings ON bookings.id = reviews.booking_id
+			WHERE bookings.master_id = master_profiles.user_id
+				AND reviews.moderation_status = 'approved')::float8`,
+		desc: true,
+	},
+	entity.MasterProfileSortPriceAsc: {
+		expr: `COALESCE((SELECT min(services.price) FROM services
+			WHERE services.user_id = master_profiles.user_id)::float8, ` + formatSortKey(missingSortKey) + `)`,
+	},
+	entity.MasterProfileSortPriceDesc: {
+		// Prices are non-negative, so -1 puts masters without services last
+		expr: `COALESCE((SELECT min(services.price) FROM services
+			WHERE services.user_id = master_profiles.user_id)::float8, -1)`,
+		desc: true,
+	},
+	entity.MasterProfileSortNewest: {
+		expr: "EXTRACT(EPOCH FROM master_profiles.created_at)::float8",
+		desc: true,
+	},
+	entity.MasterProfileSortAvailability: {
+		expr: `COALESCE(EXTRACT(EPOCH FROM (SELECT min(schedule_slots.start_time) FROM schedule_slots
+			WHERE schedule_slots.master_id = master_profiles.id
+				AND schedule_slots.status = 'free'
+				AND NOT schedule_slots.for_models
+				AND schedule_slots.start_time > now()))::float8, ` + formatSortKey(missingSortKey) + `)`,
+	},
+}
+
+func formatSortKey(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+func (r *MasterProfileRepository) List(ctx context.Context, filter entity.MasterProfileFilter, cursor *entity.MasterProfileCursor, page, pageSize int) ([]entity.CatalogMasterProfile, int64, error) {
+	var profiles []entity.CatalogMasterProfile
 	var total int64
 
-	queryBuilder := r.db.WithContext(ctx).Model(&entity.MasterProfile{}).
-		Joins("LEFT JOIN services ON services.user_id = master_profiles.id").
-		Joins("LEFT JOIN service_categories ON service_categories.id = services.category_id").
-		Joins("LEFT JOIN users ON users.user_id = master_profiles.user_id").
-		Joins("LEFT JOIN cities ON users.city = cities.id")
+	// The trigram threshold is set for the transaction only, so it does not
+	// leak to other queries on the pooled connection
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT set_config('pg_trgm.word_similarity_threshold', ?, true)", formatSortKey(trigramThreshold)).Error; err != nil {
+			return err
+		}
+
+		queryBuilder := catalogQuery(tx, filter)
+		if err := queryBuilder.Count(&total).Error; err != nil {
+			return err
+		}
+
+		switch filter.Sort {
+		case entity.MasterProfileSortRelevance:
+			// Text relevance is blended with the rating: a 5-star master gets
+			// twice the score of an unrated one with the same text match
+			queryBuilder = queryBuilder.Select(
+				"master_profiles.*, ((ts_rank_cd(master_profiles.search_document, "+searchQuerySQL()+") + "+searchSimilaritySQL+") * (1 + master_profiles.rating / 5))::float8 AS sort_key",
+				map[string]interface{}{"query": strings.TrimSpace(filter.Query)},
+			)
+		case entity.MasterProfileSortDistance:
+			queryBuilder = queryBuilder.Select(
+				"master_profiles.*, COALESCE("+haversineDistanceSQL+", "+formatSortKey(missingSortKey)+") AS sort_key",
+				*filter.Lat, *filter.Lat, *filter.Lon,
+			)
+		default:
+			queryBuilder = queryBuilder.Select("master_profiles.*, " + catalogSorts[filter.Sort].expr + " AS sort_key")
+		}
+
+		desc := catalogSorts[filter.Sort].desc
+
+		// Keyset pagination over (sort_key, id) keeps pages stable when
+		// profiles are inserted; offset paging is kept for page-number
+		// navigation
+		catalog := tx.Table("(?) AS catalog", queryBuilder)
+		if desc {
+			catalog = catalog.Order("sort_key DESC").Order("id DESC")
+		} else {
+			catalog = catalog.Order("sort_key ASC").Order("id ASC")
+		}
+		if cursor != nil {
+			if desc {
+				catalog = catalog.Where("(sort_key, id) < (?, ?)", cursor.Key, cursor.ID)
+			} else {
+				catalog = catalog.Where("(sort_key, id) > (?, ?)", cursor.Key, cursor.ID)
+			}
+			if len(cursor.Exclude) > 0 {
+				catalog = catalog.Where("id NOT IN ?", cursor.Exclude)
+			}
+		} else {
+			catalog = catalog.Offset((page - 1) * pageSize)
+		}
+
+		return catalog.Limit(pageSize).Find(&profiles).Error
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return profiles, total, nil
+}
+
+func (r *MasterProfileRepository) ListMatching(ctx context.Context, filter entity.MasterProfileFilter, ids []uuid.UUID) ([]entity.MasterProfile, error) {
+	var profiles []entity.MasterProfile
+	if len(ids) == 0 {
+		return profiles, nil
+	}
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT set_config('pg_trgm.word_similarity_threshold', ?, true)", formatSortKey(trigramThreshold)).Error; err != nil {
+			return err
+		}
+		return catalogQuery(tx, filter).
+			Select("master_profiles.*").
+			Where("master_profiles.id IN ?", ids).
+			Find(&profiles).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return profiles, nil
+}
+
+// catalogQuery selects the master profiles matching the filter. The
+// trigram threshold must be set on db before it is run with a query.
+func catalogQuery(db *gorm.DB, filter entity.MasterProfileFilter) *gorm.DB {
+	queryBuilder := db.Model(&entity.MasterProfile{}).
+		Joins("LEFT JOIN users ON users.id = master_profiles.user_id").
+		Joins("LEFT JOIN cities ON cities.id = users.city")
 
-	if query != "" {
-		queryBuilder = queryBuilder.Where("services.title ILIKE ?", "%"+strings.TrimSpace(query)+"%")
+	if query := strings.TrimSpace(filter.Query); query != "" {
+		queryBuilder = queryBuilder.Where(searchMatchSQL, map[string]interface{}{"query": query})
 	}
-	if category != "" {
-		queryBuilder = queryBuilder.Where("service_categories.name = ?", category)
+	if filter.City != "" {
+		queryBuilder = queryBuilder.Where("cities.name ILIKE ?", "%"+strings.TrimSpace(filter.City)+"%")
 	}
-	if city != "" {
-		queryBuilder = queryBuilder.Where("cities.name ILIKE ?", "%"+strings.TrimSpace(city)+"%")
+	if filter.Rating > 0 {
+		queryBuilder = queryBuilder.Where("master_profiles.rating >= ?", filter.Rating)
 	}
-	if priceFrom > 0 {
-		queryBuilder = queryBuilder.Where("services.price >= ?", priceFrom)
+
+	// Category and price filters must be satisfied by the same service
+	serviceFilter := db.Session(&gorm.Session{NewDB: true}).Table("services").
+		Select("1").
+		Joins("LEFT JOIN service_categories ON service_categories.id = services.category_id").
+		Where("services.user_id = master_profiles.user_id")
+	hasServiceFilter := false
+	if filter.Category != "" {
+		serviceFilter = serviceFilter.Where("service_categories.name = ?", filter.Category)
+		hasServiceFilter = true
 	}
-	if priceTo > 0 {
-		queryBuilder = queryBuilder.Where("services.price <= ?", priceTo)
+	if filter.PriceFrom > 0 {
+		serviceFilter = serviceFilter.Where("services.price >= ?", filter.PriceFrom)
+		hasServiceFilter = true
 	}
-	if rating > 0 {
-		queryBuilder = queryBuilder.Where("master_profiles.rating >= ?", rating)
+	if filter.PriceTo > 0 {
+		serviceFilter = serviceFilter.Where("services.price <= ?", filter.PriceTo)
+		hasServiceFilter = true
+	}
+	if hasServiceFilter {
+		queryBuilder = queryBuilder.Where("EXISTS (?)", serviceFilter)
 	}
 
-	queryBuilder = queryBuilder.Group("master_profiles.id")
+	if filter.LookingForModels {
+		queryBuilder = queryBuilder.Where(lookingForModelsSQL)
+	}
+	return queryBuilder
+}
 
-	if err := queryBuilder.Count(&total).Error; err != nil {
-		return nil, 0, err
+// earthRadiusKm is the mean Earth radius used by the haversine formula.
+const earthRadiusKm = 6371.0
+
+// haversineDistanceSQL computes the great-circle distance in kilometers between
+// master_profiles coordinates and a point given as (lat, lat, lon) arguments.
+// It only relies on built-in math functions, so it runs on vanilla Postgres
+// without PostGIS or earthdistance.
+var haversineDistanceSQL = `2 * ` + strconv.FormatFloat(earthRadiusKm, 'f', -1, 64) + ` * ASIN(SQRT(
+	POWER(SIN(RADIANS(master_profiles.latitude - ?) / 2), 2) +
+	COS(RADIANS(?)) * COS(RADIANS(master_profiles.latitude)) *
+	POWER(SIN(RADIANS(master_profiles.longitude - ?) / 2), 2)))`
+
+func (r *MasterProfileRepository) ListNearby(ctx context.Context, lat, lon, radiusKm float64, excludeID *uuid.UUID, limit int) ([]entity.NearbyMasterProfile, error) {
+	var profiles []entity.NearbyMasterProfile
+
+	// Bounding box prefilter so the index on (latitude, longitude) can be used
+	// before the exact distance is calculated
+	latDelta := radiusKm / (math.Pi * earthRadiusKm / 180)
+	inner := r.db.WithContext(ctx).Model(&entity.MasterProfile{}).
+		Select("master_profiles.*, "+haversineDistanceSQL+" AS distance_km", lat, lat, lon).
+		Where("master_profiles.latitude IS NOT NULL AND master_profiles.longitude IS NOT NULL").
+		Where("master_profiles.latitude BETWEEN ? AND ?", lat-latDelta, lat+latDelta)
+
+	// Longitude degrees shrink towards the poles; skip the longitude box near the
+	// poles and where it would cross the antimeridian
+	if cosLat := math.Cos(lat * math.Pi / 180); cosLat > 0.01 {
+		lonDelta := latDelta / cosLat
+		if lon-lonDelta >= -180 && lon+lonDelta <= 180 {
+			inner = inner.Where("master_profiles.longitude BETWEEN ? AND ?", lon-lonDelta, lon+lonDelta)
+		}
+	}
+	if excludeID != nil {
+		inner = inner.Where("master_profiles.id <> ?", *excludeID)
 	}
 
-	// Fetch paginated profiles
-	offset := (page - 1) * pageSize
-	if err := queryBuilder.
-		Order("master_profiles.name ASC").
-		Offset(offset).
-		Limit(pageSize).
-		Select("master_profiles.*").
+	if err := r.db.WithContext(ctx).
+		Table("(?) AS nearby", inner).
+		Where("distance_km <= ?", radiusKm).
+		Order("distance_km ASC").
+		Limit(limit).
 		Find(&profiles).Error; err != nil {
-		return nil, 0, err
+		return nil, err
 	}
 
-	return profiles, total, nil
+	return profiles, nil
 }
@@ -0,0 +1,182 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/Vanv1k/BeautyTON/internal/domain/entity"
+)
+
+type catalogFixture struct {
+	db       *Postgres
+	repo     *MasterProfileRepository
+	category map[string]uuid.UUID
+}
+
+func newCatalogFixture(t *testing.T) *catalogFixture {
+	t.Helper()
+	db := openTestDB(t)
+	truncate(t, db, "master_profiles", "services", "service_categories", "users", "schedule_slots", "bookings")
+	return &catalogFixture{
+		db:       db,
+		repo:     &MasterProfileRepository{db: db.GetDB()},
+		category: map[string]uuid.UUID{},
+	}
+}
+
+// master creates a master with the given username, bio and rating.
+func (f *catalogFixture) master(t *testing.T, username, bio string, rating float64) *entity.MasterProfile {
+	t.Helper()
+	user := &entity.User{ID: uuid.New(), Username: username, Role: entity.UserRoleMaster, CityID: uuid.New(), Version: 1}
+	if err := f.db.GetDB().Create(user).Error; err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	profile := &entity.MasterProfile{ID: uuid.New(), UserID: &user.ID, QRCode: user.ID.String(), Bio: bio, Rating: rating, Version: 1}
+	if err := f.db.GetDB().Create(profile).Error; err != nil {
+		t.Fatalf("create master profile: %v", err)
+	}
+	return profile
+}
+
+// service adds a service in the named category to a master.
+func (f *catalogFixture) service(t *testing.T, master *entity.MasterProfile, category, title, description string) *entity.Service {
+	t.Helper()
+	categoryID, ok := f.category[category]
+	if !ok {
+		categoryID = uuid.New()
+		if err := f.db.GetDB().Create(&entity.ServiceCategory{ID: categoryID, Name: category}).Error; err != nil {
+			t.Fatalf("create category: %v", err)
+		}
+		f.category[category] = categoryID
+	}
+	service := &entity.Service{ID: uuid.New(), CategoryID: &categoryID, UserID: master.UserID, Title: title, Description: description, Price: 1000, Version: 1}
+	if err := f.db.GetDB().Create(service).Error; err != nil {
+		t.Fatalf("create service: %v", err)
+	}
+	return service
+}
+
+func (f *catalogFixture) search(t *testing.T, query string) []uuid.UUID {
+	t.Helper()
+	profiles, total, err := f.repo.List(context.Background(), entity.MasterProfileFilter{
+		Query: query,
+		Sort:  entity.MasterProfileSortRelevance,
+	}, nil, 1, 50)
+	if err != nil {
+		t.Fatalf("search %q: %v", query, err)
+	}
+	if int(total) != len(profiles) {
+		t.Fatalf("search %q: total %d, got %d profiles", query, total, len(profiles))
+	}
+	ids := make([]uuid.UUID, len(profiles))
+	for i, profile := range profiles {
+		ids[i] = profile.ID
+	}
+	return ids
+}
+
+func assertFound(t *testing.T, query string, got []uuid.UUID, want ...*entity.MasterProfile) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("search %q: got %d masters, want %d", query, len(got), len(want))
+	}
+	for i := range want {
+		if got[i] != want[i].ID {
+			t.Fatalf("search %q: master %d is %s, want %s", query, i, got[i], want[i].ID)
+		}
+	}
+}
+
+func TestCatalogSearchMatchesDocument(t *testing.T) {
+	f := newCatalogFixture(t)
+	nails := f.master(t, "nailqueen", "Делаю маникюр и педикюр у себя дома", 4)
+	f.service(t, nails, "Nails", "Gel polish", "Long lasting coating")
+	hair := f.master(t, "hairstudio", "Colouring and haircuts", 4)
+	f.service(t, hair, "Стрижки", "Мужская стрижка", "Стрижка машинкой и ножницами")
+
+	tests := []struct {
+		name  string
+		query string
+		want  []*entity.MasterProfile
+	}{
+		{name: "russian stem in bio", query: "маникюра", want: []*entity.MasterProfile{nails}},
+		{name: "english stem in bio", query: "haircut", want: []*entity.MasterProfile{hair}},
+		{name: "service title", query: "polish", want: []*entity.MasterProfile{nails}},
+		{name: "service description", query: "ножницы", want: []*entity.MasterProfile{hair}},
+		{name: "category name", query: "nails", want: []*entity.MasterProfile{nails}},
+		{name: "username", query: "hairstudio", want: []*entity.MasterProfile{hair}},
+		{name: "no match", query: "массаж", want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertFound(t, tt.query, f.search(t, tt.query), tt.want...)
+		})
+	}
+}
+
+func TestCatalogSearchToleratesTypos(t *testing.T) {
+	f := newCatalogFixture(t)
+	lashes := f.master(t, "lashmaker", "", 4)
+	f.service(t, lashes, "Ресницы", "Lash extension", "")
+	f.master(t, "barber", "", 4)
+
+	assertFound(t, "lashmakr", f.search(t, "lashmakr"), lashes)
+	assertFound(t, "extensoin", f.search(t, "extensoin"), lashes)
+	assertFound(t, "ресницы", f.search(t, "ресницы"), lashes)
+}
+
+func TestCatalogSearchRanksByRelevanceAndRating(t *testing.T) {
+	f := newCatalogFixture(t)
+	// A title match outweighs a description match
+	description := f.master(t, "second", "", 5)
+	f.service(t, description, "Other", "Consultation", "Brows shaping included")
+	title := f.master(t, "first", "", 5)
+	f.service(t, title, "Other", "Brows shaping", "")
+	assertFound(t, "brows", f.search(t, "brows"), title, description)
+
+	// With the same text match the better rated master goes first
+	f2 := newCatalogFixture(t)
+	low := f2.master(t, "low", "Massage therapist", 1)
+	high := f2.master(t, "high", "Massage therapist", 5)
+	assertFound(t, "massage", f2.search(t, "massage"), high, low)
+}
+
+func TestCatalogSearchDocumentFollowsChanges(t *testing.T) {
+	f := newCatalogFixture(t)
+	master := f.master(t, "studio", "", 4)
+	service := f.service(t, master, "Makeup", "Evening makeup", "")
+	assertFound(t, "makeup", f.search(t, "makeup"), master)
+
+	// Service title
+	service.Title = "Pedicure"
+	if err := f.db.GetDB().Model(service).Update("title", service.Title).Error; err != nil {
+		t.Fatalf("update service: %v", err)
+	}
+	assertFound(t, "pedicure", f.search(t, "pedicure"), master)
+
+	// Category name
+	if err := f.db.GetDB().Model(&entity.ServiceCategory{ID: *service.CategoryID}).Update("name", "Podology").Error; err != nil {
+		t.Fatalf("update category: %v", err)
+	}
+	assertFound(t, "podology", f.search(t, "podology"), master)
+
+	// Bio
+	if err := f.db.GetDB().Model(master).Update("bio", "Waxing").Error; err != nil {
+		t.Fatalf("update master profile: %v", err)
+	}
+	assertFound(t, "waxing", f.search(t, "waxing"), master)
+
+	// Username
+	if err := f.db.GetDB().Model(&entity.User{ID: *master.UserID}).Update("username", "glowroom").Error; err != nil {
+		t.Fatalf("update user: %v", err)
+	}
+	assertFound(t, "glowroom", f.search(t, "glowroom"), master)
+
+	// Deleted service
+	if err := f.db.GetDB().Delete(service).Error; err != nil {
+		t.Fatalf("delete service: %v", err)
+	}
+	assertFound(t, "pedicure", f.search(t, "pedicure"))
+}
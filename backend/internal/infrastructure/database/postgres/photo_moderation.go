@@ -0,0 +1,64 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/Vanv1k/BeautyTON/internal/domain/entity"
+	"github.com/Vanv1k/BeautyTON/internal/domain/errors"
+	"github.com/Vanv1k/BeautyTON/internal/domain/repository"
+)
+
+type PhotoModerationRepository struct {
+	db *gorm.DB
+}
+
+func NewPhotoModerationRepository(postgres *Postgres) repository.PhotoModerationRepository {
+	return &PhotoModerationRepository{db: postgres.GetDB()}
+}
+
+func (r *PhotoModerationRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.PhotoModeration, error) {
+	var moderation entity.PhotoModeration
+	if err := r.db.WithContext(ctx).First(&moderation, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrRecordNotFound
+		}
+		return nil, err
+	}
+	return &moderation, nil
+}
+
+func (r *PhotoModerationRepository) Create(ctx context.Context, moderation *entity.PhotoModeration) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return tx.Create(moderation).Error
+	})
+}
+
+func (r *PhotoModerationRepository) Update(ctx context.Context, moderation *entity.PhotoModeration) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return tx.Save(moderation).Error
+	})
+}
+
+func (r *PhotoModerationRepository) ListByStatus(ctx context.Context, status entity.ModerationStatus, page, pageSize int) ([]entity.PhotoModeration, int64, error) {
+	var moderations []entity.PhotoModeration
+	var total int64
+
+	queryBuilder := r.db.WithContext(ctx).Model(&entity.PhotoModeration{}).Where("status = ?", status)
+	if err := queryBuilder.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * pageSize
+	if err := queryBuilder.
+		Order("created_at ASC").
+		Offset(offset).
+		Limit(pageSize).
+		Find(&moderations).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return moderations, total, nil
+}
@@ -1,12 +1,15 @@
 package postgres
 
 import (
+	"context"
 	"fmt"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 
 	"github.com/Vanv1k/BeautyTON/internal/config"
 	"github.com/Vanv1k/BeautyTON/internal/domain/entity"
+	"github.com/Vanv1k/BeautyTON/internal/domain/errors"
+	"github.com/Vanv1k/BeautyTON/internal/metrics"
 )
 
 type Postgres struct {
@@ -17,7 +20,9 @@ func NewPostgresRepo(cfg config.PostgresConfig) (*Postgres, error) {
 	dsn := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
 		cfg.Host, cfg.Port, cfg.User, cfg.Password, cfg.DBName, cfg.SSLMode)
 
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
+		Logger: newQueryLogger(cfg.SlowQueryThreshold),
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
@@ -29,6 +34,9 @@ func NewPostgresRepo(cfg config.PostgresConfig) (*Postgres, error) {
 	sqlDB.SetMaxOpenConns(cfg.MaxOpenConns)
 	sqlDB.SetMaxIdleConns(cfg.MaxIdleConns)
 	sqlDB.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+	if err := metrics.RegisterDB(sqlDB, cfg.DBName); err != nil {
+		return nil, fmt.Errorf("failed to register database metrics: %w", err)
+	}
 
 	err = db.AutoMigrate(
 		&entity.User{},
@@ -43,14 +51,230 @@ func NewPostgresRepo(cfg config.PostgresConfig) (*Postgres, error) {
 		&entity.Payment{},
 		&entity.City{},
 		&entity.Country{},
+		&entity.Promotion{},
+		&entity.PromotionClick{},
+		&entity.IdempotencyKey{},
+		&entity.AuthSession{},
+		&entity.RefreshToken{},
+		&entity.PhotoModeration{},
+		&entity.AdminAction{},
+		&entity.AuditLog{},
+		&entity.RateLimitBucket{},
+		&entity.File{},
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to migrate database: %w", err)
 	}
 
+	if err := migrateSearch(db); err != nil {
+		return nil, fmt.Errorf("failed to migrate catalog search: %w", err)
+	}
+
+	if err := migrateScheduleSlots(db); err != nil {
+		return nil, fmt.Errorf("failed to migrate schedule slots: %w", err)
+	}
+
+	if err := migrateBookingPrices(db); err != nil {
+		return nil, fmt.Errorf("failed to migrate booking prices: %w", err)
+	}
+
+	if err := migrateFiles(db); err != nil {
+		return nil, fmt.Errorf("failed to migrate file registry: %w", err)
+	}
+
+	if err := migrateAudit(db); err != nil {
+		return nil, fmt.Errorf("failed to migrate audit log: %w", err)
+	}
+
+	if err := registerAuditCallbacks(db); err != nil {
+		return nil, fmt.Errorf("failed to register audit callbacks: %w", err)
+	}
+
+	if err := registerTracingCallbacks(db); err != nil {
+		return nil, fmt.Errorf("failed to register tracing callbacks: %w", err)
+	}
+
 	return &Postgres{db: db}, nil
 }
 
 func (p *Postgres) GetDB() *gorm.DB {
 	return p.db
 }
+
+// Ping checks that the database accepts connections.
+func (p *Postgres) Ping(ctx context.Context) error {
+	sqlDB, err := p.db.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.PingContext(ctx)
+}
+
+// Close closes the connection pool once in-flight queries have finished.
+func (p *Postgres) Close() error {
+	sqlDB, err := p.db.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.Close()
+}
+
+// migrateSearch enables pg_trgm for typo-tolerant catalog search, creates
+// trigram indexes on the short texts matched by the <% operator and keeps
+// the full-text document of every master in master_profiles.search_document.
+// The document is refreshed by triggers whenever a text it is built from
+// changes.
+func migrateSearch(db *gorm.DB) error {
+	statements := []string{
+		"CREATE EXTENSION IF NOT EXISTS pg_trgm",
+		"CREATE INDEX IF NOT EXISTS idx_users_username_trgm ON users USING gin (username gin_trgm_ops)",
+		"CREATE INDEX IF NOT EXISTS idx_services_title_trgm ON services USING gin (title gin_trgm_ops)",
+		"CREATE INDEX IF NOT EXISTS idx_service_categories_name_trgm ON service_categories USING gin (name gin_trgm_ops)",
+		"CREATE INDEX IF NOT EXISTS idx_services_user_id ON services (user_id)",
+		"CREATE INDEX IF NOT EXISTS idx_master_profiles_user_id ON master_profiles (user_id)",
+
+		"ALTER TABLE master_profiles ADD COLUMN IF NOT EXISTS search_document tsvector",
+		"CREATE INDEX IF NOT EXISTS idx_master_profiles_search_document ON master_profiles USING gin (search_document)",
+		searchDocumentFunctionSQL,
+		`CREATE OR REPLACE FUNCTION refresh_master_search_documents(master_user_ids uuid[])
+		RETURNS void LANGUAGE sql AS $$
+			UPDATE master_profiles SET search_document = master_search_document(user_id, bio)
+			WHERE user_id = ANY(master_user_ids)
+		$$`,
+
+		// Профиль пересчитывается сам, остальные таблицы обновляют документы
+		// своих мастеров
+		`CREATE OR REPLACE FUNCTION master_profiles_search_document() RETURNS trigger LANGUAGE plpgsql AS $$
+		BEGIN
+			NEW.search_document := master_search_document(NEW.user_id, NEW.bio);
+			RETURN NEW;
+		END $$`,
+		"DROP TRIGGER IF EXISTS master_profiles_search_document ON master_profiles",
+		`CREATE TRIGGER master_profiles_search_document BEFORE INSERT OR UPDATE OF user_id, bio ON master_profiles
+		FOR EACH ROW EXECUTE FUNCTION master_profiles_search_document()`,
+
+		`CREATE OR REPLACE FUNCTION users_search_document() RETURNS trigger LANGUAGE plpgsql AS $$
+		BEGIN
+			IF TG_OP = 'UPDATE' AND OLD.username IS NOT DISTINCT FROM NEW.username THEN
+				RETURN NULL;
+			END IF;
+			PERFORM refresh_master_search_documents(ARRAY[NEW.id]);
+			RETURN NULL;
+		END $$`,
+		"DROP TRIGGER IF EXISTS users_search_document ON users",
+		`CREATE TRIGGER users_search_document AFTER INSERT OR UPDATE OF username ON users
+		FOR EACH ROW EXECUTE FUNCTION users_search_document()`,
+
+		`CREATE OR REPLACE FUNCTION services_search_document() RETURNS trigger LANGUAGE plpgsql AS $$
+		BEGIN
+			IF TG_OP = 'UPDATE' AND (OLD.user_id, OLD.title, OLD.description, OLD.category_id)
+				IS NOT DISTINCT FROM (NEW.user_id, NEW.title, NEW.description, NEW.category_id) THEN
+				RETURN NULL;
+			END IF;
+			IF TG_OP <> 'INSERT' THEN
+				PERFORM refresh_master_search_documents(ARRAY[OLD.user_id]);
+			END IF;
+			IF TG_OP <> 'DELETE' AND (TG_OP = 'INSERT' OR OLD.user_id IS DISTINCT FROM NEW.user_id) THEN
+				PERFORM refresh_master_search_documents(ARRAY[NEW.user_id]);
+			END IF;
+			RETURN NULL;
+		END $$`,
+		"DROP TRIGGER IF EXISTS services_search_document ON services",
+		`CREATE TRIGGER services_search_document AFTER INSERT OR UPDATE OR DELETE ON services
+		FOR EACH ROW EXECUTE FUNCTION services_search_document()`,
+
+		`CREATE OR REPLACE FUNCTION service_categories_search_document() RETURNS trigger LANGUAGE plpgsql AS $$
+		BEGIN
+			IF TG_OP = 'UPDATE' AND OLD.name IS NOT DISTINCT FROM NEW.name THEN
+				RETURN NULL;
+			END IF;
+			PERFORM refresh_master_search_documents(ARRAY(
+				SELECT DISTINCT services.user_id FROM services WHERE services.category_id = OLD.id));
+			RETURN NULL;
+		END $$`,
+		"DROP TRIGGER IF EXISTS service_categories_search_document ON service_categories",
+		`CREATE TRIGGER service_categories_search_document AFTER UPDATE OR DELETE ON service_categories
+		FOR EACH ROW EXECUTE FUNCTION service_categories_search_document()`,
+
+		// Документы профилей, созданных до появления столбца
+		"UPDATE master_profiles SET search_document = master_search_document(user_id, bio) WHERE search_document IS NULL",
+	}
+	for _, stmt := range statements {
+		if err := db.Exec(stmt).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// migrateScheduleSlots adds the columns introduced since schedule_slots was
+// created, outside of AutoMigrate because of its enum types.
+func migrateScheduleSlots(db *gorm.DB) error {
+	statements := []string{
+		"ALTER TABLE IF EXISTS schedule_slots ADD COLUMN IF NOT EXISTS version bigint NOT NULL DEFAULT 1",
+		"ALTER TABLE IF EXISTS schedule_slots ADD COLUMN IF NOT EXISTS for_models boolean NOT NULL DEFAULT false",
+	}
+	for _, stmt := range statements {
+		if err := db.Exec(stmt).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// migrateBookingPrices prices bookings made before the price was kept, from
+// their service as it is now. Bookings of deleted services are left free.
+func migrateBookingPrices(db *gorm.DB) error {
+	statements := []string{
+		`UPDATE bookings
+		SET price = CASE WHEN bookings.is_model_booking THEN COALESCE(services.model_price, 0) ELSE services.price END
+		FROM services
+		WHERE services.id = bookings.service_id AND bookings.price IS NULL`,
+		"UPDATE bookings SET price = 0 WHERE price IS NULL",
+	}
+	for _, stmt := range statements {
+		if err := db.Exec(stmt).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// migrateFiles registers photos uploaded before the file registry existed,
+// so they stay readable and referenced. Their size and checksum are unknown.
+func migrateFiles(db *gorm.DB) error {
+	statements := []string{
+		`INSERT INTO files (id, name, owner_id, purpose, size, referenced_by, created_at, updated_at)
+		SELECT photo_url, photo_url, id, 'avatar', 0, 'user:' || id, now(), now()
+		FROM users WHERE photo_url <> ''
+		ON CONFLICT (id) DO NOTHING`,
+		`INSERT INTO files (id, name, owner_id, purpose, size, referenced_by, created_at, updated_at)
+		SELECT photo_url, photo_url, COALESCE(user_id, '00000000-0000-0000-0000-000000000000'), 'service_photo', 0, 'service:' || id, now(), now()
+		FROM services WHERE photo_url <> ''
+		ON CONFLICT (id) DO NOTHING`,
+	}
+	for _, stmt := range statements {
+		if err := db.Exec(stmt).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// updateVersioned saves model only if its row still has the version read by
+// the caller and bumps the version on success. A concurrent write makes the
+// update match no rows and returns ErrVersionConflict.
+func updateVersioned(tx *gorm.DB, model interface{}, version *int64) error {
+	expected := *version
+	*version = expected + 1
+	result := tx.Model(model).Where("version = ?", expected).Select("*").Updates(model)
+	if result.Error != nil {
+		*version = expected
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		*version = expected
+		return errors.ErrVersionConflict
+	}
+	return nil
+}
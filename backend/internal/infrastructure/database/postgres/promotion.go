@@ -0,0 +1,128 @@
+package postgres
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/Vanv1k/BeautyTON/internal/domain/entity"
+	"github.com/Vanv1k/BeautyTON/internal/domain/errors"
+	"github.com/Vanv1k/BeautyTON/internal/domain/repository"
+)
+
+type PromotionRepository struct {
+	db *gorm.DB
+}
+
+func NewPromotionRepository(postgres *Postgres) repository.PromotionRepository {
+	return &PromotionRepository{db: postgres.GetDB()}
+}
+
+func (r *PromotionRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Promotion, error) {
+	var promotion entity.Promotion
+	if err := r.db.WithContext(ctx).First(&promotion, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrRecordNotFound
+		}
+		return nil, err
+	}
+	return &promotion, nil
+}
+
+func (r *PromotionRepository) Create(ctx context.Context, promotion *entity.Promotion) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return tx.Create(promotion).Error
+	})
+}
+
+func (r *PromotionRepository) Update(ctx context.Context, promotion *entity.Promotion) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return tx.Save(promotion).Error
+	})
+}
+
+func (r *PromotionRepository) Activate(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Model(&entity.Promotion{}).
+		Where("id = ? AND status = ?", id, entity.PromotionStatusPending).
+		Update("status", entity.PromotionStatusActive).Error
+}
+
+// ListActive returns running campaigns matching the catalog city and category
+// names. Campaigns that spent the smallest share of their budget come first,
+// so impressions are spread between advertisers.
+func (r *PromotionRepository) ListActive(ctx context.Context, city, category string, limit int) ([]entity.Promotion, error) {
+	var promotions []entity.Promotion
+
+	queryBuilder := r.db.WithContext(ctx).
+		Where("status = ?", entity.PromotionStatusActive).
+		Where("starts_at <= now() AND ends_at > now()").
+		Where("spent_ton < budget_ton")
+
+	if city != "" {
+		queryBuilder = queryBuilder.Where("city_id IS NULL OR city_id IN (?)",
+			r.db.Table("cities").Select("id").Where("name ILIKE ?", "%"+strings.TrimSpace(city)+"%"))
+	}
+	if category != "" {
+		queryBuilder = queryBuilder.Where("category_id IS NULL OR category_id IN (?)",
+			r.db.Table("service_categories").Select("id").Where("name = ?", category))
+	}
+
+	if err := queryBuilder.
+		Order("spent_ton / budget_ton ASC").
+		Order("created_at ASC").
+		Limit(limit).
+		Find(&promotions).Error; err != nil {
+		return nil, err
+	}
+	return promotions, nil
+}
+
+func (r *PromotionRepository) RecordImpressions(ctx context.Context, ids []uuid.UUID, costTON float64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	// Counters change on every catalog page, they are not audited
+	return withoutAudit(r.db.WithContext(ctx)).Model(&entity.Promotion{}).
+		Where("id IN ?", ids).
+		Updates(map[string]interface{}{
+			"impressions": gorm.Expr("impressions + 1"),
+			"spent_ton":   gorm.Expr("LEAST(spent_ton + ?, budget_ton)", costTON),
+		}).Error
+}
+
+// RecordClick increments the click counter only when the click of the user
+// is new, so concurrent and repeated clicks of one user count once.
+func (r *PromotionRepository) RecordClick(ctx context.Context, id, userID uuid.UUID) error {
+	return withoutAudit(r.db.WithContext(ctx)).Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&entity.Promotion{}).Where("id = ?", id).Count(&count).Error; err != nil {
+			return err
+		}
+		if count == 0 {
+			return errors.ErrRecordNotFound
+		}
+
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).
+			Create(&entity.PromotionClick{PromotionID: id, UserID: userID})
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		return tx.Model(&entity.Promotion{}).
+			Where("id = ?", id).
+			Update("clicks", gorm.Expr("clicks + 1")).Error
+	})
+}
+
+// ExpireFinished marks active campaigns that ended or spent their budget, and
+// pending ones that ended unpaid, as expired and returns how many were
+// updated.
+func (r *PromotionRepository) ExpireFinished(ctx context.Context) (int64, error) {
+	result := r.db.WithContext(ctx).Model(&entity.Promotion{}).
+		Where("status IN ?", []entity.PromotionStatus{entity.PromotionStatusPending, entity.PromotionStatusActive}).
+		Where("ends_at <= now() OR spent_ton >= budget_ton").
+		Update("status", entity.PromotionStatusExpired)
+	return result.RowsAffected, result.Error
+}
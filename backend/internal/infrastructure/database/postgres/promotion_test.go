@@ -0,0 +1,67 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Vanv1k/BeautyTON/internal/domain/entity"
+	er "github.com/Vanv1k/BeautyTON/internal/domain/errors"
+)
+
+func TestRecordClickCountsEachUserOnce(t *testing.T) {
+	db := openTestDB(t)
+	truncate(t, db, "promotions", "promotion_clicks")
+	repo := &PromotionRepository{db: db.GetDB()}
+	ctx := context.Background()
+
+	promotion := &entity.Promotion{
+		ID:        uuid.New(),
+		MasterID:  uuid.New(),
+		StartsAt:  time.Now(),
+		EndsAt:    time.Now().Add(24 * time.Hour),
+		BudgetTON: 10,
+		Status:    entity.PromotionStatusActive,
+	}
+	if err := repo.Create(ctx, promotion); err != nil {
+		t.Fatalf("create promotion: %v", err)
+	}
+
+	// Concurrent clicks of one user count once
+	user := uuid.New()
+	var wg sync.WaitGroup
+	errs := make(chan error, 5)
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- repo.RecordClick(ctx, promotion.ID, user)
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("record click: %v", err)
+		}
+	}
+	if err := repo.RecordClick(ctx, promotion.ID, uuid.New()); err != nil {
+		t.Fatalf("record click of another user: %v", err)
+	}
+
+	stored, err := repo.GetByID(ctx, promotion.ID)
+	if err != nil {
+		t.Fatalf("get promotion: %v", err)
+	}
+	if stored.Clicks != 2 {
+		t.Fatalf("promotion has %d clicks, want 2", stored.Clicks)
+	}
+
+	if err := repo.RecordClick(ctx, uuid.New(), user); !errors.Is(err, er.ErrRecordNotFound) {
+		t.Fatalf("click on a missing promotion: got %v, want not found", err)
+	}
+}
@@ -0,0 +1,62 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/Vanv1k/BeautyTON/internal/domain/entity"
+	"github.com/Vanv1k/BeautyTON/internal/domain/repository"
+)
+
+// refilledTokens is the content of bucket b after refilling it for the time
+// since its last take.
+const refilledTokens = `LEAST(CAST(@capacity AS double precision),
+	b.tokens + CAST(EXTRACT(EPOCH FROM now() - b.updated_at) AS double precision) * CAST(@rate AS double precision))`
+
+type RateLimitRepository struct {
+	db *gorm.DB
+}
+
+func NewRateLimitRepository(postgres *Postgres) repository.RateLimitRepository {
+	return &RateLimitRepository{db: postgres.GetDB()}
+}
+
+// Take refills and takes from the bucket in a single upsert, so concurrent
+// requests from all instances are serialized on the bucket row. An empty
+// bucket is not updated and the upsert returns no row.
+func (r *RateLimitRepository) Take(ctx context.Context, key string, limit entity.RateLimit) (time.Duration, error) {
+	args := map[string]interface{}{
+		"key":      key,
+		"capacity": float64(limit.Requests),
+		"rate":     float64(limit.Requests) / limit.Period.Seconds(),
+	}
+	var taken []entity.RateLimitBucket
+	err := r.db.WithContext(ctx).Raw(`
+		INSERT INTO rate_limit_buckets AS b (key, tokens, updated_at)
+		VALUES (@key, CAST(@capacity AS double precision) - 1, now())
+		ON CONFLICT (key) DO UPDATE
+		SET tokens = `+refilledTokens+` - 1,
+			updated_at = now()
+		WHERE `+refilledTokens+` >= 1
+		RETURNING key, tokens, updated_at`, args).Scan(&taken).Error
+	if err != nil || len(taken) > 0 {
+		return 0, err
+	}
+
+	// Время ожидания считается отдельно: это подсказка клиенту, а не часть лимита
+	var wait float64
+	err = r.db.WithContext(ctx).Raw(`
+		SELECT GREATEST(0, 1 - `+refilledTokens+`) / CAST(@rate AS double precision)
+		FROM rate_limit_buckets AS b WHERE key = @key`, args).Scan(&wait).Error
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(wait * float64(time.Second)), nil
+}
+
+func (r *RateLimitRepository) DeleteIdle(ctx context.Context, before time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Where("updated_at < ?", before).Delete(&entity.RateLimitBucket{})
+	return result.RowsAffected, result.Error
+}
@@ -46,3 +46,24 @@ func (r *ReviewRepository) Delete(ctx context.Context, id uuid.UUID) error {
 		return tx.Delete(&entity.Review{}, id).Error
 	})
 }
+
+func (r *ReviewRepository) ListByModerationStatus(ctx context.Context, status entity.ModerationStatus, page, pageSize int) ([]entity.Review, int64, error) {
+	var reviews []entity.Review
+	var total int64
+
+	queryBuilder := r.db.WithContext(ctx).Model(&entity.Review{}).Where("moderation_status = ?", status)
+	if err := queryBuilder.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * pageSize
+	if err := queryBuilder.
+		Order("id ASC").
+		Offset(offset).
+		Limit(pageSize).
+		Find(&reviews).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return reviews, total, nil
+}
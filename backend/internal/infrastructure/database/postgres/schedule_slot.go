@@ -32,6 +32,9 @@ func (r *ScheduleSlotRepository) Get(ctx context.Context, id uuid.UUID) (*entity
 }
 
 func (r *ScheduleSlotRepository) Create(ctx context.Context, slot *entity.ScheduleSlot) error {
+	if slot.Version == 0 {
+		slot.Version = 1
+	}
 	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		return tx.Create(slot).Error
 	})
@@ -39,7 +42,7 @@ func (r *ScheduleSlotRepository) Create(ctx context.Context, slot *entity.Schedu
 
 func (r *ScheduleSlotRepository) Update(ctx context.Context, slot *entity.ScheduleSlot) error {
 	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		return tx.Save(slot).Error
+		return updateVersioned(tx, slot, &slot.Version)
 	})
 }
 
@@ -49,9 +52,13 @@ func (r *ScheduleSlotRepository) Delete(ctx context.Context, id uuid.UUID) error
 	})
 }
 
-func (r *ScheduleSlotRepository) List(ctx context.Context, masterID uuid.UUID) ([]entity.ScheduleSlot, error) {
+func (r *ScheduleSlotRepository) List(ctx context.Context, masterID uuid.UUID, forModels *bool) ([]entity.ScheduleSlot, error) {
 	var slots []entity.ScheduleSlot
-	if err := r.db.WithContext(ctx).Where("master_id = ?", masterID).Find(&slots).Error; err != nil {
+	queryBuilder := r.db.WithContext(ctx).Where("master_id = ?", masterID)
+	if forModels != nil {
+		queryBuilder = queryBuilder.Where("for_models = ?", *forModels)
+	}
+	if err := queryBuilder.Find(&slots).Error; err != nil {
 		return nil, err
 	}
 	return slots, nil
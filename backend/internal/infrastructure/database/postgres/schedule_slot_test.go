@@ -0,0 +1,49 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Vanv1k/BeautyTON/internal/domain/entity"
+)
+
+// The test database starts with schedule_slots as deployments created it, so
+// this fails if a column the entity maps is not migrated.
+func TestScheduleSlotsAreMigrated(t *testing.T) {
+	db := openTestDB(t)
+	truncate(t, db, "schedule_slots")
+	repo := &ScheduleSlotRepository{db: db.GetDB()}
+	ctx := context.Background()
+
+	master := uuid.New()
+	start := time.Date(2030, 1, 1, 10, 0, 0, 0, time.UTC)
+	slot := &entity.ScheduleSlot{
+		ID:        uuid.New(),
+		MasterID:  master,
+		Date:      start,
+		StartTime: start,
+		EndTime:   start.Add(time.Hour),
+		Status:    entity.ScheduleSlotStatusFree,
+		SlotType:  entity.SlotManual,
+		ForModels: true,
+	}
+	if err := repo.Create(ctx, slot); err != nil {
+		t.Fatalf("create slot: %v", err)
+	}
+	slot.EndTime = start.Add(2 * time.Hour)
+	if err := repo.Update(ctx, slot); err != nil {
+		t.Fatalf("update slot: %v", err)
+	}
+
+	forModels := true
+	slots, err := repo.List(ctx, master, &forModels)
+	if err != nil {
+		t.Fatalf("list model slots: %v", err)
+	}
+	if len(slots) != 1 || !slots[0].ForModels || slots[0].Version != 2 {
+		t.Fatalf("got %+v, want the model slot at version 2", slots)
+	}
+}
@@ -31,6 +31,9 @@ func (r *ServiceRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.
 }
 
 func (r *ServiceRepository) Create(ctx context.Context, service *entity.Service) error {
+	if service.Version == 0 {
+		service.Version = 1
+	}
 	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		return tx.Create(service).Error
 	})
@@ -38,7 +41,7 @@ func (r *ServiceRepository) Create(ctx context.Context, service *entity.Service)
 
 func (r *ServiceRepository) Update(ctx context.Context, service *entity.Service) error {
 	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		return tx.Save(service).Error
+		return updateVersioned(tx, service, &service.Version)
 	})
 }
 
@@ -0,0 +1,66 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/Vanv1k/BeautyTON/internal/domain/entity"
+	er "github.com/Vanv1k/BeautyTON/internal/domain/errors"
+)
+
+func TestConcurrentUpdatesOfOneVersionHaveOneWinner(t *testing.T) {
+	db := openTestDB(t)
+	truncate(t, db, "services")
+	repo := &ServiceRepository{db: db.GetDB()}
+	ctx := context.Background()
+
+	service := &entity.Service{ID: uuid.New(), Title: "Manicure", Price: 1000, Version: 1}
+	if err := db.GetDB().Create(service).Error; err != nil {
+		t.Fatalf("create service: %v", err)
+	}
+
+	// Both writers read version 1 and change the price
+	var wg sync.WaitGroup
+	errs := make([]error, 2)
+	updated := make([]*entity.Service, 2)
+	for i := range errs {
+		copied := *service
+		copied.Price = float64(2000 + i)
+		updated[i] = &copied
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = repo.Update(ctx, updated[i])
+		}(i)
+	}
+	wg.Wait()
+
+	winner := -1
+	for i, err := range errs {
+		switch {
+		case err == nil && winner == -1:
+			winner = i
+		case errors.Is(err, er.ErrVersionConflict):
+			if updated[i].Version != 1 {
+				t.Fatalf("losing writer has version %d, want it left at 1", updated[i].Version)
+			}
+		default:
+			t.Fatalf("updates returned %v, want one success and one version conflict", errs)
+		}
+	}
+	if winner == -1 {
+		t.Fatalf("updates returned %v, want one success", errs)
+	}
+
+	stored, err := repo.GetByID(ctx, service.ID)
+	if err != nil {
+		t.Fatalf("get service: %v", err)
+	}
+	if stored.Version != 2 || stored.Price != updated[winner].Price {
+		t.Fatalf("service is at version %d with price %v, want version 2 with the price of the winner", stored.Version, stored.Price)
+	}
+}
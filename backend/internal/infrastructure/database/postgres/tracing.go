@@ -0,0 +1,78 @@
+package postgres
+
+import (
+	"errors"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+const tracingSpanKey = "tracing:span"
+
+var tracer = otel.Tracer("github.com/Vanv1k/BeautyTON/internal/infrastructure/database/postgres")
+
+// registerTracingCallbacks wraps every GORM operation in a client span. The
+// span is put into the statement context, so queries made by other
+// callbacks, such as the audit log, become its children.
+func registerTracingCallbacks(db *gorm.DB) error {
+	callbacks := []error{
+		db.Callback().Create().Before("*").Register("tracing:before_create", startQuerySpan("create")),
+		db.Callback().Create().After("*").Register("tracing:after_create", endQuerySpan),
+		db.Callback().Query().Before("*").Register("tracing:before_query", startQuerySpan("query")),
+		db.Callback().Query().After("*").Register("tracing:after_query", endQuerySpan),
+		db.Callback().Update().Before("*").Register("tracing:before_update", startQuerySpan("update")),
+		db.Callback().Update().After("*").Register("tracing:after_update", endQuerySpan),
+		db.Callback().Delete().Before("*").Register("tracing:before_delete", startQuerySpan("delete")),
+		db.Callback().Delete().After("*").Register("tracing:after_delete", endQuerySpan),
+		db.Callback().Row().Before("*").Register("tracing:before_row", startQuerySpan("row")),
+		db.Callback().Row().After("*").Register("tracing:after_row", endQuerySpan),
+		db.Callback().Raw().Before("*").Register("tracing:before_raw", startQuerySpan("raw")),
+		db.Callback().Raw().After("*").Register("tracing:after_raw", endQuerySpan),
+	}
+	for _, err := range callbacks {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func startQuerySpan(operation string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		name := "db." + operation
+		if db.Statement.Table != "" {
+			name += " " + db.Statement.Table
+		}
+		ctx, span := tracer.Start(db.Statement.Context, name,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(
+				attribute.String("db.system", "postgresql"),
+				attribute.String("db.sql.table", db.Statement.Table),
+			),
+		)
+		db.Statement.Context = ctx
+		db.InstanceSet(tracingSpanKey, span)
+	}
+}
+
+func endQuerySpan(db *gorm.DB) {
+	value, ok := db.InstanceGet(tracingSpanKey)
+	if !ok {
+		return
+	}
+	span := value.(trace.Span)
+	defer span.End()
+
+	// The statement is recorded without its parameters
+	span.SetAttributes(
+		attribute.String("db.statement", db.Statement.SQL.String()),
+		attribute.Int64("db.rows_affected", db.Statement.RowsAffected),
+	)
+	if db.Error != nil && !errors.Is(db.Error, gorm.ErrRecordNotFound) {
+		span.RecordError(db.Error)
+		span.SetStatus(codes.Error, db.Error.Error())
+	}
+}
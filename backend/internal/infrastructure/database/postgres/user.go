@@ -32,7 +32,7 @@ func (r *UserRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Use
 
 func (r *UserRepository) GetByTelegramID(ctx context.Context, telegramID int64) (*entity.User, error) {
 	var user entity.User
-	if err := r.db.WithContext(ctx).First(&user, telegramID).Error; err != nil {
+	if err := r.db.WithContext(ctx).Where("tg_id = ?", telegramID).First(&user).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, errors.ErrRecordNotFound
 		}
@@ -42,6 +42,9 @@ func (r *UserRepository) GetByTelegramID(ctx context.Context, telegramID int64)
 }
 
 func (r *UserRepository) Create(ctx context.Context, user *entity.User) error {
+	if user.Version == 0 {
+		user.Version = 1
+	}
 	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		return tx.Create(user).Error
 	})
@@ -49,7 +52,7 @@ func (r *UserRepository) Create(ctx context.Context, user *entity.User) error {
 
 func (r *UserRepository) Update(ctx context.Context, user *entity.User) error {
 	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		return tx.Save(user).Error
+		return updateVersioned(tx, user, &user.Version)
 	})
 }
 
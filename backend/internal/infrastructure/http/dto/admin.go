@@ -0,0 +1,120 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Vanv1k/BeautyTON/internal/domain/entity"
+)
+
+type SetUserRoleRequest struct {
+	Role entity.UserRole `json:"role" validate:"required,oneof=master client admin"`
+}
+
+type BanUserRequest struct {
+	Reason string `json:"reason" validate:"required,max=500"`
+}
+
+type SuspendUserRequest struct {
+	Until  time.Time `json:"until" validate:"required"`
+	Reason string    `json:"reason" validate:"required,max=500"`
+}
+
+// ModerationRequest carries the reason of a decision, required to reject.
+type ModerationRequest struct {
+	Reason string `json:"reason" validate:"max=500"`
+}
+
+// AdminUserResponse is a user together with their blocking state.
+type AdminUserResponse struct {
+	UserResponse
+	BannedAt       *time.Time `json:"banned_at"`
+	SuspendedUntil *time.Time `json:"suspended_until"`
+	BlockReason    string     `json:"block_reason"`
+}
+
+func NewAdminUserResponse(user *entity.User) AdminUserResponse {
+	return AdminUserResponse{
+		UserResponse:   NewUserResponse(user, nil),
+		BannedAt:       user.BannedAt,
+		SuspendedUntil: user.SuspendedUntil,
+		BlockReason:    user.BlockReason,
+	}
+}
+
+type PhotoModerationResponse struct {
+	ID          uuid.UUID               `json:"id"`
+	FileID      string                  `json:"file_id"`
+	OwnerType   entity.PhotoOwnerType   `json:"owner_type"`
+	OwnerID     uuid.UUID               `json:"owner_id"`
+	Status      entity.ModerationStatus `json:"status"`
+	Reason      string                  `json:"reason"`
+	ModeratorID *uuid.UUID              `json:"moderator_id"`
+	CreatedAt   time.Time               `json:"created_at"`
+	ModeratedAt *time.Time              `json:"moderated_at"`
+}
+
+type PhotoModerationListResponse struct {
+	Results    []PhotoModerationResponse `json:"results"`
+	Page       int                       `json:"page"`
+	PageSize   int                       `json:"page_size"`
+	Total      int64                     `json:"total"`
+	TotalPages int                       `json:"total_pages"`
+}
+
+func NewPhotoModerationResponse(moderation *entity.PhotoModeration) PhotoModerationResponse {
+	return PhotoModerationResponse{
+		ID:          moderation.ID,
+		FileID:      moderation.FileID,
+		OwnerType:   moderation.OwnerType,
+		OwnerID:     moderation.OwnerID,
+		Status:      moderation.Status,
+		Reason:      moderation.Reason,
+		ModeratorID: moderation.ModeratorID,
+		CreatedAt:   moderation.CreatedAt,
+		ModeratedAt: moderation.ModeratedAt,
+	}
+}
+
+func NewPhotoModerationResponses(moderations []entity.PhotoModeration) []PhotoModerationResponse {
+	responses := make([]PhotoModerationResponse, len(moderations))
+	for i := range moderations {
+		responses[i] = NewPhotoModerationResponse(&moderations[i])
+	}
+	return responses
+}
+
+type AdminActionResponse struct {
+	ID         uuid.UUID `json:"id"`
+	AdminID    uuid.UUID `json:"admin_id"`
+	Action     string    `json:"action"`
+	EntityType string    `json:"entity_type"`
+	EntityID   string    `json:"entity_id"`
+	Details    string    `json:"details"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type AdminActionListResponse struct {
+	Results    []AdminActionResponse `json:"results"`
+	Page       int                   `json:"page"`
+	PageSize   int                   `json:"page_size"`
+	Total      int64                 `json:"total"`
+	TotalPages int                   `json:"total_pages"`
+}
+
+func NewAdminActionResponses(actions []entity.AdminAction) []AdminActionResponse {
+	responses := make([]AdminActionResponse, len(actions))
+	for i, action := range actions {
+		responses[i] = AdminActionResponse{
+			ID:         action.ID,
+			AdminID:    action.AdminID,
+			Action:     action.Action,
+			EntityType: action.EntityType,
+			EntityID:   action.EntityID,
+			Details:    action.Details,
+			CreatedAt:  action.CreatedAt,
+		}
+	}
+	return responses
+}
@@ -0,0 +1,56 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Vanv1k/BeautyTON/internal/domain/entity"
+)
+
+type AuditLogResponse struct {
+	ID         uuid.UUID          `json:"id"`
+	ActorID    *uuid.UUID         `json:"actor_id"`
+	Action     entity.AuditAction `json:"action"`
+	EntityType string             `json:"entity_type"`
+	EntityID   string             `json:"entity_id"`
+	Before     json.RawMessage    `json:"before" swaggertype:"object"`
+	After      json.RawMessage    `json:"after" swaggertype:"object"`
+	RequestID  string             `json:"request_id"`
+	CreatedAt  time.Time          `json:"created_at"`
+}
+
+type AuditLogListResponse struct {
+	Results    []AuditLogResponse `json:"results"`
+	Page       int                `json:"page"`
+	PageSize   int                `json:"page_size"`
+	Total      int64              `json:"total"`
+	TotalPages int                `json:"total_pages"`
+}
+
+func NewAuditLogResponses(logs []entity.AuditLog) []AuditLogResponse {
+	responses := make([]AuditLogResponse, len(logs))
+	for i, log := range logs {
+		responses[i] = AuditLogResponse{
+			ID:         log.ID,
+			ActorID:    log.ActorID,
+			Action:     log.Action,
+			EntityType: log.EntityType,
+			EntityID:   log.EntityID,
+			Before:     rawJSON(log.Before),
+			After:      rawJSON(log.After),
+			RequestID:  log.RequestID,
+			CreatedAt:  log.CreatedAt,
+		}
+	}
+	return responses
+}
+
+// rawJSON passes a stored JSON document through, a missing one becomes null.
+func rawJSON(value *string) json.RawMessage {
+	if value == nil {
+		return json.RawMessage("null")
+	}
+	return json.RawMessage(*value)
+}
@@ -0,0 +1,38 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Vanv1k/BeautyTON/internal/domain/entity"
+)
+
+type CreateSessionRequest struct {
+	InitData string `json:"init_data" validate:"required"`
+}
+
+type RefreshSessionRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+}
+
+// SessionResponse carries a new token pair. Expiry fields are in seconds.
+type SessionResponse struct {
+	SessionID        uuid.UUID `json:"session_id"`
+	TokenType        string    `json:"token_type" example:"Bearer"`
+	AccessToken      string    `json:"access_token"`
+	ExpiresIn        int64     `json:"expires_in"`
+	RefreshToken     string    `json:"refresh_token"`
+	RefreshExpiresIn int64     `json:"refresh_expires_in"`
+}
+
+func NewSessionResponse(tokens *entity.AuthTokens) SessionResponse {
+	return SessionResponse{
+		SessionID:        tokens.SessionID,
+		TokenType:        "Bearer",
+		AccessToken:      tokens.AccessToken,
+		ExpiresIn:        int64(time.Until(tokens.AccessExpiresAt).Seconds()),
+		RefreshToken:     tokens.RefreshToken,
+		RefreshExpiresIn: int64(time.Until(tokens.RefreshExpiresAt).Seconds()),
+	}
+}
@@ -0,0 +1,92 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Vanv1k/BeautyTON/internal/domain/entity"
+)
+
+// CreateBookingRequest creates a pending booking for the current user. A model
+// booking is made under the model offer of the service and requires portfolio
+// consent.
+type CreateBookingRequest struct {
+	MasterID         uuid.UUID `json:"master_id" validate:"required"`
+	ServiceID        uuid.UUID `json:"service_id" validate:"required"`
+	BookingTime      time.Time `json:"booking_time" validate:"required"`
+	IsModelBooking   bool      `json:"is_model_booking"`
+	PortfolioConsent bool      `json:"portfolio_consent"`
+}
+
+func (r *CreateBookingRequest) ToEntity(clientID uuid.UUID) *entity.Booking {
+	return &entity.Booking{
+		ClientID:         clientID,
+		MasterID:         r.MasterID,
+		ServiceID:        r.ServiceID,
+		BookingTime:      r.BookingTime,
+		Status:           entity.BookingStatusPending,
+		IsModelBooking:   r.IsModelBooking,
+		PortfolioConsent: r.PortfolioConsent,
+	}
+}
+
+// UpdateBookingRequest reschedules a booking. Participants and the kind of
+// booking are fixed at creation, the status has its own endpoint.
+type UpdateBookingRequest struct {
+	ServiceID        uuid.UUID `json:"service_id" validate:"required"`
+	BookingTime      time.Time `json:"booking_time" validate:"required"`
+	PortfolioConsent bool      `json:"portfolio_consent"`
+}
+
+// NewUpdateBookingRequest returns the editable fields of booking, the
+// document a merge patch is applied to.
+func NewUpdateBookingRequest(booking *entity.Booking) UpdateBookingRequest {
+	return UpdateBookingRequest{
+		ServiceID:        booking.ServiceID,
+		BookingTime:      booking.BookingTime,
+		PortfolioConsent: booking.PortfolioConsent,
+	}
+}
+
+func (r *UpdateBookingRequest) ApplyTo(booking *entity.Booking) {
+	booking.ServiceID = r.ServiceID
+	booking.BookingTime = r.BookingTime
+	booking.PortfolioConsent = r.PortfolioConsent
+}
+
+type UpdateBookingStatusRequest struct {
+	Status entity.BookingStatus `json:"status" validate:"required,oneof=pending confirmed completed canceled"`
+}
+
+type BookingResponse struct {
+	ID               uuid.UUID            `json:"id"`
+	ClientID         uuid.UUID            `json:"client_id"`
+	MasterID         uuid.UUID            `json:"master_id"`
+	ServiceID        uuid.UUID            `json:"service_id"`
+	BookingTime      time.Time            `json:"booking_time"`
+	Status           entity.BookingStatus `json:"status"`
+	IsModelBooking   bool                 `json:"is_model_booking"`
+	PortfolioConsent bool                 `json:"portfolio_consent"`
+	Price            float64              `json:"price"`
+	CreatedAt        time.Time            `json:"created_at"`
+	UpdatedAt        time.Time            `json:"updated_at"`
+	Version          int64                `json:"version"`
+}
+
+func NewBookingResponse(booking *entity.Booking) BookingResponse {
+	return BookingResponse{
+		ID:               booking.ID,
+		ClientID:         booking.ClientID,
+		MasterID:         booking.MasterID,
+		ServiceID:        booking.ServiceID,
+		BookingTime:      booking.BookingTime,
+		Status:           booking.Status,
+		IsModelBooking:   booking.IsModelBooking,
+		PortfolioConsent: booking.PortfolioConsent,
+		Price:            booking.Price,
+		CreatedAt:        booking.CreatedAt,
+		UpdatedAt:        booking.UpdatedAt,
+		Version:          booking.Version,
+	}
+}
@@ -0,0 +1,44 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+
+	"github.com/Vanv1k/BeautyTON/internal/domain/entity"
+)
+
+// CityRequest is used both to create and to update a city. Timezone is an
+// IANA time zone name.
+type CityRequest struct {
+	Name      string    `json:"name" validate:"required,max=128"`
+	CountryID uuid.UUID `json:"country_id" validate:"required"`
+	Timezone  string    `json:"timezone" validate:"omitempty,timezone"`
+}
+
+func (r *CityRequest) ToEntity() *entity.City {
+	return &entity.City{Name: r.Name, CountryID: r.CountryID, Timezone: r.Timezone}
+}
+
+func (r *CityRequest) ApplyTo(city *entity.City) {
+	city.Name = r.Name
+	city.CountryID = r.CountryID
+	city.Timezone = r.Timezone
+}
+
+type CityResponse struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	CountryID uuid.UUID `json:"country_id"`
+	Timezone  string    `json:"timezone"`
+}
+
+func NewCityResponse(city *entity.City) CityResponse {
+	return CityResponse{ID: city.ID, Name: city.Name, CountryID: city.CountryID, Timezone: city.Timezone}
+}
+
+func NewCityResponses(cities []entity.City) []CityResponse {
+	responses := make([]CityResponse, len(cities))
+	for i := range cities {
+		responses[i] = NewCityResponse(&cities[i])
+	}
+	return responses
+}
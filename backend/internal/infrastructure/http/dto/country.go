@@ -0,0 +1,33 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+
+	"github.com/Vanv1k/BeautyTON/internal/domain/entity"
+)
+
+// CountryRequest is used both to create and to update a country. Code is an
+// ISO 3166-1 alpha-2 code.
+type CountryRequest struct {
+	Name string `json:"name" validate:"required,max=128"`
+	Code string `json:"code" validate:"required,len=2,alpha"`
+}
+
+func (r *CountryRequest) ToEntity() *entity.Country {
+	return &entity.Country{Name: r.Name, Code: r.Code}
+}
+
+func (r *CountryRequest) ApplyTo(country *entity.Country) {
+	country.Name = r.Name
+	country.Code = r.Code
+}
+
+type CountryResponse struct {
+	ID   uuid.UUID `json:"id"`
+	Name string    `json:"name"`
+	Code string    `json:"code"`
+}
+
+func NewCountryResponse(country *entity.Country) CountryResponse {
+	return CountryResponse{ID: country.ID, Name: country.Name, Code: country.Code}
+}
@@ -0,0 +1,94 @@
+package dto
+
+import (
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Vanv1k/BeautyTON/internal/domain/entity"
+)
+
+// FileUploadResponse is returned by the upload endpoints. Variants are set
+// for photos.
+type FileUploadResponse struct {
+	FileID   string            `json:"file_id"`
+	Variants map[string]string `json:"variants,omitempty"`
+}
+
+// PresignUploadRequest declares a file the client uploads to the storage
+// directly. The storage rejects content that differs from it.
+type PresignUploadRequest struct {
+	Name        string `json:"name" validate:"max=255"`
+	Size        int64  `json:"size" validate:"required,gt=0"`
+	ContentType string `json:"content_type" validate:"required,max=255"`
+	// ChecksumSHA256 is the hex-encoded SHA-256 of the content
+	ChecksumSHA256 string `json:"checksum_sha256" validate:"required,len=64,hexadecimal"`
+}
+
+func (r *PresignUploadRequest) ToEntity(ownerID uuid.UUID) *entity.File {
+	return &entity.File{
+		ID:       uuid.New().String(),
+		Name:     r.Name,
+		OwnerID:  ownerID,
+		Size:     r.Size,
+		MimeType: r.ContentType,
+		Checksum: r.ChecksumSHA256,
+	}
+}
+
+// PresignUploadResponse is the request uploading the file to the storage.
+// The upload is completed with FileID once the storage has accepted it.
+type PresignUploadResponse struct {
+	FileID string `json:"file_id"`
+	Method string `json:"method"`
+	URL    string `json:"url"`
+	// Headers must be sent with the upload as given
+	Headers   map[string]string `json:"headers"`
+	ExpiresAt time.Time         `json:"expires_at"`
+}
+
+func NewPresignUploadResponse(fileID string, request *entity.PresignedRequest) PresignUploadResponse {
+	return PresignUploadResponse{
+		FileID:    fileID,
+		Method:    request.Method,
+		URL:       request.URL,
+		Headers:   request.Headers,
+		ExpiresAt: request.ExpiresAt,
+	}
+}
+
+// CompleteUploadRequest completes a presigned upload of a photo.
+type CompleteUploadRequest struct {
+	FileID string `json:"file_id" validate:"required"`
+}
+
+// FileURLResponse is a presigned URL downloading a file.
+type FileURLResponse struct {
+	URL       string    `json:"url"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// FileURL is where a variant of a file is downloaded through the API.
+func FileURL(id string, variant entity.ImageVariant) string {
+	return "/v1/files/" + url.PathEscape(id) + "?variant=" + string(variant)
+}
+
+// PhotoURLs maps every variant of a photo to its URL, nil without a photo.
+// Variants missing from urls, the storage URLs of the photo, are downloaded
+// through the API. Photos uploaded before variants existed are served whole
+// for any variant.
+func PhotoURLs(id string, urls map[entity.ImageVariant]string) map[string]string {
+	if id == "" {
+		return nil
+	}
+	photos := make(map[string]string, len(entity.ImageVariants))
+	for _, variant := range entity.ImageVariants {
+		if u, ok := urls[variant]; ok {
+			photos[string(variant)] = u
+		} else {
+			photos[string(variant)] = FileURL(id, variant)
+		}
+	}
+	return photos
+}
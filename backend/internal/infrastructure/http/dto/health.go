@@ -0,0 +1,8 @@
+package dto
+
+// HealthResponse reports the state of the server and, for readiness, of each
+// dependency it checked.
+type HealthResponse struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks,omitempty"`
+}
@@ -0,0 +1,149 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Vanv1k/BeautyTON/internal/domain/entity"
+)
+
+// CreateMasterProfileRequest creates a master profile of the current user.
+// The rating always starts at zero, only admins can set it.
+type CreateMasterProfileRequest struct {
+	QRCode    string   `json:"qr_code" validate:"required,max=255"`
+	Bio       string   `json:"bio" validate:"max=2000"`
+	Status    string   `json:"status" validate:"max=64"`
+	Address   string   `json:"address" validate:"max=255"`
+	Latitude  *float64 `json:"latitude" validate:"required_with=Longitude,omitempty,gte=-90,lte=90"`
+	Longitude *float64 `json:"longitude" validate:"required_with=Latitude,omitempty,gte=-180,lte=180"`
+}
+
+func (r *CreateMasterProfileRequest) ToEntity(userID uuid.UUID) *entity.MasterProfile {
+	return &entity.MasterProfile{
+		UserID:    &userID,
+		QRCode:    r.QRCode,
+		Bio:       r.Bio,
+		Status:    r.Status,
+		Address:   r.Address,
+		Latitude:  r.Latitude,
+		Longitude: r.Longitude,
+	}
+}
+
+type UpdateMasterProfileRequest struct {
+	QRCode    string   `json:"qr_code" validate:"required,max=255"`
+	Bio       string   `json:"bio" validate:"max=2000"`
+	Status    string   `json:"status" validate:"max=64"`
+	Address   string   `json:"address" validate:"max=255"`
+	Latitude  *float64 `json:"latitude" validate:"required_with=Longitude,omitempty,gte=-90,lte=90"`
+	Longitude *float64 `json:"longitude" validate:"required_with=Latitude,omitempty,gte=-180,lte=180"`
+}
+
+// NewUpdateMasterProfileRequest returns the editable fields of profile, the
+// document a merge patch is applied to.
+func NewUpdateMasterProfileRequest(profile *entity.MasterProfile) UpdateMasterProfileRequest {
+	return UpdateMasterProfileRequest{
+		QRCode:    profile.QRCode,
+		Bio:       profile.Bio,
+		Status:    profile.Status,
+		Address:   profile.Address,
+		Latitude:  profile.Latitude,
+		Longitude: profile.Longitude,
+	}
+}
+
+func (r *UpdateMasterProfileRequest) ApplyTo(profile *entity.MasterProfile) {
+	profile.QRCode = r.QRCode
+	profile.Bio = r.Bio
+	profile.Status = r.Status
+	profile.Address = r.Address
+	profile.Latitude = r.Latitude
+	profile.Longitude = r.Longitude
+}
+
+type UpdateMasterProfileRatingRequest struct {
+	Rating float64 `json:"rating" validate:"gte=0,lte=5"`
+}
+
+type MasterProfileResponse struct {
+	ID        uuid.UUID  `json:"id"`
+	UserID    *uuid.UUID `json:"user_id"`
+	QRCode    string     `json:"qr_code"`
+	Bio       string     `json:"bio"`
+	Status    string     `json:"status"`
+	Rating    float64    `json:"rating"`
+	Address   string     `json:"address"`
+	Latitude  *float64   `json:"latitude"`
+	Longitude *float64   `json:"longitude"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+	Version   int64      `json:"version"`
+}
+
+func NewMasterProfileResponse(profile *entity.MasterProfile) MasterProfileResponse {
+	return MasterProfileResponse{
+		ID:        profile.ID,
+		UserID:    profile.UserID,
+		QRCode:    profile.QRCode,
+		Bio:       profile.Bio,
+		Status:    profile.Status,
+		Rating:    profile.Rating,
+		Address:   profile.Address,
+		Latitude:  profile.Latitude,
+		Longitude: profile.Longitude,
+		CreatedAt: profile.CreatedAt,
+		UpdatedAt: profile.UpdatedAt,
+		Version:   profile.Version,
+	}
+}
+
+// CatalogMasterProfileResponse is a catalog entry; promoted entries carry
+// the ID of the campaign to report clicks to.
+type CatalogMasterProfileResponse struct {
+	MasterProfileResponse
+	IsPromoted  bool       `json:"is_promoted"`
+	PromotionID *uuid.UUID `json:"promotion_id,omitempty"`
+}
+
+type MasterProfileListResponse struct {
+	Results    []CatalogMasterProfileResponse `json:"results"`
+	Page       int                            `json:"page"`
+	PageSize   int                            `json:"page_size"`
+	Total      int64                          `json:"total"`
+	TotalPages int                            `json:"total_pages"`
+	NextCursor string                         `json:"next_cursor"`
+	HasMore    bool                           `json:"has_more"`
+}
+
+func NewCatalogMasterProfileResponses(profiles []entity.CatalogMasterProfile) []CatalogMasterProfileResponse {
+	responses := make([]CatalogMasterProfileResponse, len(profiles))
+	for i := range profiles {
+		responses[i] = CatalogMasterProfileResponse{
+			MasterProfileResponse: NewMasterProfileResponse(&profiles[i].MasterProfile),
+			IsPromoted:            profiles[i].IsPromoted,
+			PromotionID:           profiles[i].PromotionID,
+		}
+	}
+	return responses
+}
+
+type NearbyMasterProfileResponse struct {
+	MasterProfileResponse
+	DistanceKm float64 `json:"distance_km"`
+}
+
+type NearbyMasterProfileListResponse struct {
+	Results []NearbyMasterProfileResponse `json:"results"`
+}
+
+func NewNearbyMasterProfileResponses(profiles []entity.NearbyMasterProfile) []NearbyMasterProfileResponse {
+	responses := make([]NearbyMasterProfileResponse, len(profiles))
+	for i := range profiles {
+		responses[i] = NearbyMasterProfileResponse{
+			MasterProfileResponse: NewMasterProfileResponse(&profiles[i].MasterProfile),
+			DistanceKm:            profiles[i].DistanceKm,
+		}
+	}
+	return responses
+}
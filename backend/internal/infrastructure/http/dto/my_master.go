@@ -0,0 +1,40 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Vanv1k/BeautyTON/internal/domain/entity"
+)
+
+// MyMasterRequest is used both to create and to update a "my master" link.
+type MyMasterRequest struct {
+	ClientID uuid.UUID `json:"client_id" validate:"required"`
+	MasterID uuid.UUID `json:"master_id" validate:"required"`
+}
+
+func (r *MyMasterRequest) ToEntity() *entity.MyMaster {
+	return &entity.MyMaster{ClientID: r.ClientID, MasterID: r.MasterID}
+}
+
+func (r *MyMasterRequest) ApplyTo(myMaster *entity.MyMaster) {
+	myMaster.ClientID = r.ClientID
+	myMaster.MasterID = r.MasterID
+}
+
+type MyMasterResponse struct {
+	ID        uuid.UUID `json:"id"`
+	ClientID  uuid.UUID `json:"client_id"`
+	MasterID  uuid.UUID `json:"master_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func NewMyMasterResponse(myMaster *entity.MyMaster) MyMasterResponse {
+	return MyMasterResponse{
+		ID:        myMaster.ID,
+		ClientID:  myMaster.ClientID,
+		MasterID:  myMaster.MasterID,
+		CreatedAt: myMaster.CreatedAt,
+	}
+}
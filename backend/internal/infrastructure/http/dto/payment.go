@@ -0,0 +1,89 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Vanv1k/BeautyTON/internal/domain/entity"
+)
+
+// CreatePaymentRequest creates a pending payment. The status has its own
+// endpoint. A payment for a booking takes its participants from the
+// booking and is charged the booking price, a payment for a promotion is
+// charged its budget in TON, so the amount may be omitted. Completing a
+// promotion payment starts the campaign.
+type CreatePaymentRequest struct {
+	ClientID         *uuid.UUID         `json:"client_id"`
+	MasterID         *uuid.UUID         `json:"master_id"`
+	BookingID        *uuid.UUID         `json:"booking_id"`
+	PromotionID      *uuid.UUID         `json:"promotion_id"`
+	Amount           float64            `json:"amount" validate:"omitempty,gt=0"`
+	Currency         string             `json:"currency" validate:"required,max=10"`
+	Type             entity.PaymentType `json:"type" validate:"required,oneof=payment tip"`
+	TonTransactionID string             `json:"ton_transaction_id" validate:"max=255"`
+}
+
+func (r *CreatePaymentRequest) ToEntity() *entity.Payment {
+	return &entity.Payment{
+		ClientID:         r.ClientID,
+		MasterID:         r.MasterID,
+		BookingID:        r.BookingID,
+		PromotionID:      r.PromotionID,
+		Amount:           r.Amount,
+		Currency:         r.Currency,
+		Type:             r.Type,
+		TonTransactionID: r.TonTransactionID,
+		Status:           entity.PaymentStatusPending,
+	}
+}
+
+// UpdatePaymentRequest replaces the editable fields of a payment. Payer and
+// payee are fixed at creation.
+type UpdatePaymentRequest struct {
+	Amount           float64            `json:"amount" validate:"gt=0"`
+	Currency         string             `json:"currency" validate:"required,max=10"`
+	Type             entity.PaymentType `json:"type" validate:"required,oneof=payment tip"`
+	TonTransactionID string             `json:"ton_transaction_id" validate:"max=255"`
+}
+
+func (r *UpdatePaymentRequest) ApplyTo(payment *entity.Payment) {
+	payment.Amount = r.Amount
+	payment.Currency = r.Currency
+	payment.Type = r.Type
+	payment.TonTransactionID = r.TonTransactionID
+}
+
+type UpdatePaymentStatusRequest struct {
+	Status entity.PaymentStatus `json:"status" validate:"required,oneof=pending completed failed"`
+}
+
+type PaymentResponse struct {
+	ID               uuid.UUID            `json:"id"`
+	ClientID         *uuid.UUID           `json:"client_id"`
+	MasterID         *uuid.UUID           `json:"master_id"`
+	BookingID        *uuid.UUID           `json:"booking_id"`
+	PromotionID      *uuid.UUID           `json:"promotion_id"`
+	Amount           float64              `json:"amount"`
+	Currency         string               `json:"currency"`
+	Type             entity.PaymentType   `json:"type"`
+	TonTransactionID string               `json:"ton_transaction_id"`
+	Status           entity.PaymentStatus `json:"status"`
+	CreatedAt        time.Time            `json:"created_at"`
+}
+
+func NewPaymentResponse(payment *entity.Payment) PaymentResponse {
+	return PaymentResponse{
+		ID:               payment.ID,
+		ClientID:         payment.ClientID,
+		MasterID:         payment.MasterID,
+		BookingID:        payment.BookingID,
+		PromotionID:      payment.PromotionID,
+		Amount:           payment.Amount,
+		Currency:         payment.Currency,
+		Type:             payment.Type,
+		TonTransactionID: payment.TonTransactionID,
+		Status:           payment.Status,
+		CreatedAt:        payment.CreatedAt,
+	}
+}
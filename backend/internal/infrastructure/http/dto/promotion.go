@@ -0,0 +1,66 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Vanv1k/BeautyTON/internal/domain/entity"
+)
+
+// CreatePromotionRequest creates a campaign for the master of the current
+// user; master_id may be omitted and must be theirs when set. Counters,
+// spending and status are managed by the server.
+type CreatePromotionRequest struct {
+	MasterID   uuid.UUID  `json:"master_id"`
+	CityID     *uuid.UUID `json:"city_id"`
+	CategoryID *uuid.UUID `json:"category_id"`
+	StartsAt   time.Time  `json:"starts_at" validate:"required"`
+	EndsAt     time.Time  `json:"ends_at" validate:"required,gtfield=StartsAt"`
+	BudgetTON  float64    `json:"budget_ton" validate:"gt=0"`
+}
+
+func (r *CreatePromotionRequest) ToEntity() *entity.Promotion {
+	return &entity.Promotion{
+		MasterID:   r.MasterID,
+		CityID:     r.CityID,
+		CategoryID: r.CategoryID,
+		StartsAt:   r.StartsAt,
+		EndsAt:     r.EndsAt,
+		BudgetTON:  r.BudgetTON,
+	}
+}
+
+type PromotionResponse struct {
+	ID          uuid.UUID              `json:"id"`
+	MasterID    uuid.UUID              `json:"master_id"`
+	CityID      *uuid.UUID             `json:"city_id"`
+	CategoryID  *uuid.UUID             `json:"category_id"`
+	StartsAt    time.Time              `json:"starts_at"`
+	EndsAt      time.Time              `json:"ends_at"`
+	BudgetTON   float64                `json:"budget_ton"`
+	SpentTON    float64                `json:"spent_ton"`
+	Impressions int64                  `json:"impressions"`
+	Clicks      int64                  `json:"clicks"`
+	Status      entity.PromotionStatus `json:"status"`
+	CreatedAt   time.Time              `json:"created_at"`
+	UpdatedAt   time.Time              `json:"updated_at"`
+}
+
+func NewPromotionResponse(promotion *entity.Promotion) PromotionResponse {
+	return PromotionResponse{
+		ID:          promotion.ID,
+		MasterID:    promotion.MasterID,
+		CityID:      promotion.CityID,
+		CategoryID:  promotion.CategoryID,
+		StartsAt:    promotion.StartsAt,
+		EndsAt:      promotion.EndsAt,
+		BudgetTON:   promotion.BudgetTON,
+		SpentTON:    promotion.SpentTON,
+		Impressions: promotion.Impressions,
+		Clicks:      promotion.Clicks,
+		Status:      promotion.Status,
+		CreatedAt:   promotion.CreatedAt,
+		UpdatedAt:   promotion.UpdatedAt,
+	}
+}
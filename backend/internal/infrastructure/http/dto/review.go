@@ -0,0 +1,65 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+
+	"github.com/Vanv1k/BeautyTON/internal/domain/entity"
+)
+
+type CreateReviewRequest struct {
+	BookingID uuid.UUID `json:"booking_id" validate:"required"`
+	Rating    int       `json:"rating" validate:"required,gte=1,lte=5"`
+	Comment   string    `json:"comment" validate:"max=2000"`
+}
+
+func (r *CreateReviewRequest) ToEntity() *entity.Review {
+	return &entity.Review{BookingID: r.BookingID, Rating: r.Rating, Comment: r.Comment}
+}
+
+type UpdateReviewRequest struct {
+	Rating  int    `json:"rating" validate:"required,gte=1,lte=5"`
+	Comment string `json:"comment" validate:"max=2000"`
+}
+
+func (r *UpdateReviewRequest) ApplyTo(review *entity.Review) {
+	review.Rating = r.Rating
+	review.Comment = r.Comment
+}
+
+type ReviewResponse struct {
+	ID        uuid.UUID `json:"id"`
+	BookingID uuid.UUID `json:"booking_id"`
+	Rating    int       `json:"rating"`
+	Comment   string    `json:"comment"`
+
+	ModerationStatus entity.ModerationStatus `json:"moderation_status"`
+	ModerationReason string                  `json:"moderation_reason,omitempty"`
+}
+
+func NewReviewResponse(review *entity.Review) ReviewResponse {
+	return ReviewResponse{
+		ID:        review.ID,
+		BookingID: review.BookingID,
+		Rating:    review.Rating,
+		Comment:   review.Comment,
+
+		ModerationStatus: review.ModerationStatus,
+		ModerationReason: review.ModerationReason,
+	}
+}
+
+type ReviewListResponse struct {
+	Results    []ReviewResponse `json:"results"`
+	Page       int              `json:"page"`
+	PageSize   int              `json:"page_size"`
+	Total      int64            `json:"total"`
+	TotalPages int              `json:"total_pages"`
+}
+
+func NewReviewResponses(reviews []entity.Review) []ReviewResponse {
+	responses := make([]ReviewResponse, len(reviews))
+	for i := range reviews {
+		responses[i] = NewReviewResponse(&reviews[i])
+	}
+	return responses
+}
@@ -0,0 +1,95 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Vanv1k/BeautyTON/internal/domain/entity"
+)
+
+// ScheduleSlotRequest is used both to create and to update a slot. The
+// master is always the authenticated user.
+type ScheduleSlotRequest struct {
+	BookingID *uuid.UUID                `json:"booking_id"`
+	Date      time.Time                 `json:"date" validate:"required"`
+	StartTime time.Time                 `json:"start_time" validate:"required"`
+	EndTime   time.Time                 `json:"end_time" validate:"required,gtfield=StartTime"`
+	Status    entity.ScheduleSlotStatus `json:"status" validate:"required,oneof=booked free busy reserved"`
+	SlotType  entity.SlotType           `json:"slot_type" validate:"required,oneof=manual auto"`
+	ForModels bool                      `json:"for_models"`
+}
+
+func (r *ScheduleSlotRequest) ToEntity(masterID uuid.UUID) *entity.ScheduleSlot {
+	slot := &entity.ScheduleSlot{MasterID: masterID}
+	r.ApplyTo(slot)
+	return slot
+}
+
+// NewScheduleSlotRequest returns the editable fields of slot, the document a
+// merge patch is applied to.
+func NewScheduleSlotRequest(slot *entity.ScheduleSlot) ScheduleSlotRequest {
+	return ScheduleSlotRequest{
+		BookingID: slot.BookingID,
+		Date:      slot.Date,
+		StartTime: slot.StartTime,
+		EndTime:   slot.EndTime,
+		Status:    slot.Status,
+		SlotType:  slot.SlotType,
+		ForModels: slot.ForModels,
+	}
+}
+
+func (r *ScheduleSlotRequest) ApplyTo(slot *entity.ScheduleSlot) {
+	slot.BookingID = r.BookingID
+	slot.Date = r.Date
+	slot.StartTime = r.StartTime
+	slot.EndTime = r.EndTime
+	slot.Status = r.Status
+	slot.SlotType = r.SlotType
+	slot.ForModels = r.ForModels
+}
+
+type ScheduleSlotResponse struct {
+	ID        uuid.UUID                 `json:"id"`
+	MasterID  uuid.UUID                 `json:"master_id"`
+	BookingID *uuid.UUID                `json:"booking_id"`
+	Date      time.Time                 `json:"date"`
+	StartTime time.Time                 `json:"start_time"`
+	EndTime   time.Time                 `json:"end_time"`
+	Status    entity.ScheduleSlotStatus `json:"status"`
+	SlotType  entity.SlotType           `json:"slot_type"`
+	ForModels bool                      `json:"for_models"`
+	CreatedAt time.Time                 `json:"created_at"`
+	UpdatedAt time.Time                 `json:"updated_at"`
+	Version   int64                     `json:"version"`
+}
+
+type ScheduleSlotListResponse struct {
+	Results []ScheduleSlotResponse `json:"results"`
+}
+
+func NewScheduleSlotResponse(slot *entity.ScheduleSlot) ScheduleSlotResponse {
+	return ScheduleSlotResponse{
+		ID:        slot.ID,
+		MasterID:  slot.MasterID,
+		BookingID: slot.BookingID,
+		Date:      slot.Date,
+		StartTime: slot.StartTime,
+		EndTime:   slot.EndTime,
+		Status:    slot.Status,
+		SlotType:  slot.SlotType,
+		ForModels: slot.ForModels,
+		CreatedAt: slot.CreatedAt,
+		UpdatedAt: slot.UpdatedAt,
+		Version:   slot.Version,
+	}
+}
+
+func NewScheduleSlotResponses(slots []entity.ScheduleSlot) []ScheduleSlotResponse {
+	responses := make([]ScheduleSlotResponse, len(slots))
+	for i := range slots {
+		responses[i] = NewScheduleSlotResponse(&slots[i])
+	}
+	return responses
+}
@@ -0,0 +1,115 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Vanv1k/BeautyTON/internal/domain/entity"
+)
+
+type CreateServiceRequest struct {
+	UserID             *uuid.UUID `json:"user_id"`
+	CategoryID         *uuid.UUID `json:"category_id"`
+	Title              string     `json:"title" validate:"required,max=255"`
+	Description        string     `json:"description" validate:"max=2000"`
+	Price              float64    `json:"price" validate:"gte=0"`
+	Duration           string     `json:"duration" validate:"max=64"`
+	ModelOffer         bool       `json:"model_offer"`
+	ModelPrice         *float64   `json:"model_price" validate:"omitempty,gte=0"`
+	ModelBookingsLimit int        `json:"model_bookings_limit" validate:"gte=0"`
+}
+
+func (r *CreateServiceRequest) ToEntity() *entity.Service {
+	return &entity.Service{
+		UserID:             r.UserID,
+		CategoryID:         r.CategoryID,
+		Title:              r.Title,
+		Description:        r.Description,
+		Price:              r.Price,
+		Duration:           r.Duration,
+		ModelOffer:         r.ModelOffer,
+		ModelPrice:         r.ModelPrice,
+		ModelBookingsLimit: r.ModelBookingsLimit,
+	}
+}
+
+// UpdateServiceRequest replaces the editable fields of a service. The owner
+// is fixed at creation, the photo is set by the upload endpoint.
+type UpdateServiceRequest struct {
+	CategoryID         *uuid.UUID `json:"category_id"`
+	Title              string     `json:"title" validate:"required,max=255"`
+	Description        string     `json:"description" validate:"max=2000"`
+	Price              float64    `json:"price" validate:"gte=0"`
+	Duration           string     `json:"duration" validate:"max=64"`
+	ModelOffer         bool       `json:"model_offer"`
+	ModelPrice         *float64   `json:"model_price" validate:"omitempty,gte=0"`
+	ModelBookingsLimit int        `json:"model_bookings_limit" validate:"gte=0"`
+}
+
+// NewUpdateServiceRequest returns the editable fields of service, the
+// document a merge patch is applied to.
+func NewUpdateServiceRequest(service *entity.Service) UpdateServiceRequest {
+	return UpdateServiceRequest{
+		CategoryID:         service.CategoryID,
+		Title:              service.Title,
+		Description:        service.Description,
+		Price:              service.Price,
+		Duration:           service.Duration,
+		ModelOffer:         service.ModelOffer,
+		ModelPrice:         service.ModelPrice,
+		ModelBookingsLimit: service.ModelBookingsLimit,
+	}
+}
+
+func (r *UpdateServiceRequest) ApplyTo(service *entity.Service) {
+	service.CategoryID = r.CategoryID
+	service.Title = r.Title
+	service.Description = r.Description
+	service.Price = r.Price
+	service.Duration = r.Duration
+	service.ModelOffer = r.ModelOffer
+	service.ModelPrice = r.ModelPrice
+	service.ModelBookingsLimit = r.ModelBookingsLimit
+}
+
+type ServiceResponse struct {
+	ID          uuid.UUID  `json:"id"`
+	UserID      *uuid.UUID `json:"user_id"`
+	CategoryID  *uuid.UUID `json:"category_id"`
+	Title       string     `json:"title"`
+	Description string     `json:"description"`
+	// PhotoURL is the URL of the full-size photo, PhotoVariants maps each
+	// size to its URL
+	PhotoURL           string            `json:"photo_url"`
+	PhotoVariants      map[string]string `json:"photo_variants,omitempty"`
+	Price              float64           `json:"price"`
+	Duration           string            `json:"duration"`
+	ModelOffer         bool              `json:"model_offer"`
+	ModelPrice         *float64          `json:"model_price"`
+	ModelBookingsLimit int               `json:"model_bookings_limit"`
+	CreatedAt          time.Time         `json:"created_at"`
+	Version            int64             `json:"version"`
+}
+
+// NewServiceResponse links the photo to urls, its storage URLs by variant, see
+// PhotoURLs.
+func NewServiceResponse(service *entity.Service, urls map[entity.ImageVariant]string) ServiceResponse {
+	photos := PhotoURLs(service.PhotoURL, urls)
+	return ServiceResponse{
+		ID:                 service.ID,
+		UserID:             service.UserID,
+		CategoryID:         service.CategoryID,
+		Title:              service.Title,
+		Description:        service.Description,
+		PhotoURL:           photos[string(entity.ImageVariantFull)],
+		PhotoVariants:      photos,
+		Price:              service.Price,
+		Duration:           service.Duration,
+		ModelOffer:         service.ModelOffer,
+		ModelPrice:         service.ModelPrice,
+		ModelBookingsLimit: service.ModelBookingsLimit,
+		CreatedAt:          service.CreatedAt,
+		Version:            service.Version,
+	}
+}
@@ -0,0 +1,29 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+
+	"github.com/Vanv1k/BeautyTON/internal/domain/entity"
+)
+
+// ServiceCategoryRequest is used both to create and to update a category.
+type ServiceCategoryRequest struct {
+	Name string `json:"name" validate:"required,max=128"`
+}
+
+func (r *ServiceCategoryRequest) ToEntity() *entity.ServiceCategory {
+	return &entity.ServiceCategory{Name: r.Name}
+}
+
+func (r *ServiceCategoryRequest) ApplyTo(category *entity.ServiceCategory) {
+	category.Name = r.Name
+}
+
+type ServiceCategoryResponse struct {
+	ID   uuid.UUID `json:"id"`
+	Name string    `json:"name"`
+}
+
+func NewServiceCategoryResponse(category *entity.ServiceCategory) ServiceCategoryResponse {
+	return ServiceCategoryResponse{ID: category.ID, Name: category.Name}
+}
@@ -0,0 +1,40 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Vanv1k/BeautyTON/internal/domain/entity"
+)
+
+// SubscriptionRequest is used both to create and to update a subscription.
+type SubscriptionRequest struct {
+	ClientID uuid.UUID `json:"client_id" validate:"required"`
+	MasterID uuid.UUID `json:"master_id" validate:"required"`
+}
+
+func (r *SubscriptionRequest) ToEntity() *entity.Subscription {
+	return &entity.Subscription{ClientID: r.ClientID, MasterID: r.MasterID}
+}
+
+func (r *SubscriptionRequest) ApplyTo(subscription *entity.Subscription) {
+	subscription.ClientID = r.ClientID
+	subscription.MasterID = r.MasterID
+}
+
+type SubscriptionResponse struct {
+	ID        uuid.UUID `json:"id"`
+	ClientID  uuid.UUID `json:"client_id"`
+	MasterID  uuid.UUID `json:"master_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func NewSubscriptionResponse(subscription *entity.Subscription) SubscriptionResponse {
+	return SubscriptionResponse{
+		ID:        subscription.ID,
+		ClientID:  subscription.ClientID,
+		MasterID:  subscription.MasterID,
+		CreatedAt: subscription.CreatedAt,
+	}
+}
@@ -0,0 +1,86 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Vanv1k/BeautyTON/internal/domain/entity"
+)
+
+type CreateUserRequest struct {
+	TgID      int64           `json:"tg_id" validate:"required"`
+	Username  string          `json:"username" validate:"required,max=64"`
+	Role      entity.UserRole `json:"role" validate:"required,oneof=master client"`
+	CityID    uuid.UUID       `json:"city_id" validate:"required"`
+	TonWallet string          `json:"ton_wallet" validate:"max=128"`
+}
+
+func (r *CreateUserRequest) ToEntity() *entity.User {
+	return &entity.User{
+		TgID:      r.TgID,
+		Username:  r.Username,
+		Role:      r.Role,
+		CityID:    r.CityID,
+		TonWallet: r.TonWallet,
+	}
+}
+
+// UpdateUserRequest replaces the editable fields of a user. The role and
+// Telegram ID are fixed at creation, the photo is set by the upload endpoint.
+type UpdateUserRequest struct {
+	Username  string    `json:"username" validate:"required,max=64"`
+	CityID    uuid.UUID `json:"city_id" validate:"required"`
+	TonWallet string    `json:"ton_wallet" validate:"max=128"`
+}
+
+// NewUpdateUserRequest returns the editable fields of user, the document a
+// merge patch is applied to.
+func NewUpdateUserRequest(user *entity.User) UpdateUserRequest {
+	return UpdateUserRequest{
+		Username:  user.Username,
+		CityID:    user.CityID,
+		TonWallet: user.TonWallet,
+	}
+}
+
+func (r *UpdateUserRequest) ApplyTo(user *entity.User) {
+	user.Username = r.Username
+	user.CityID = r.CityID
+	user.TonWallet = r.TonWallet
+}
+
+type UserResponse struct {
+	ID       uuid.UUID       `json:"id"`
+	TgID     int64           `json:"tg_id"`
+	Username string          `json:"username"`
+	Role     entity.UserRole `json:"role"`
+	// PhotoURL is the URL of the full-size photo, PhotoVariants maps each
+	// size to its URL
+	PhotoURL      string            `json:"photo_url"`
+	PhotoVariants map[string]string `json:"photo_variants,omitempty"`
+	CityID        uuid.UUID         `json:"city_id"`
+	TonWallet     string            `json:"ton_wallet"`
+	CreatedAt     time.Time         `json:"created_at"`
+	UpdatedAt     time.Time         `json:"updated_at"`
+	Version       int64             `json:"version"`
+}
+
+// NewUserResponse links the photo to urls, its storage URLs by variant, see
+// PhotoURLs.
+func NewUserResponse(user *entity.User, urls map[entity.ImageVariant]string) UserResponse {
+	photos := PhotoURLs(user.PhotoURL, urls)
+	return UserResponse{
+		ID:            user.ID,
+		TgID:          user.TgID,
+		Username:      user.Username,
+		Role:          user.Role,
+		PhotoURL:      photos[string(entity.ImageVariantFull)],
+		PhotoVariants: photos,
+		CityID:        user.CityID,
+		TonWallet:     user.TonWallet,
+		CreatedAt:     user.CreatedAt,
+		UpdatedAt:     user.UpdatedAt,
+		Version:       user.Version,
+	}
+}
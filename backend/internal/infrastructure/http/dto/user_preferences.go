@@ -0,0 +1,53 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+
+	"github.com/Vanv1k/BeautyTON/internal/domain/entity"
+)
+
+type CreateUserPreferencesRequest struct {
+	UserID              uuid.UUID `json:"user_id" validate:"required"`
+	PreferredCategoryID uuid.UUID `json:"preferred_category_id"`
+	MaxPrice            float64   `json:"max_price" validate:"gte=0"`
+	MaxDistanceKm       int       `json:"max_distance_km" validate:"gte=0"`
+}
+
+func (r *CreateUserPreferencesRequest) ToEntity() *entity.UserPreferences {
+	return &entity.UserPreferences{
+		UserID:              r.UserID,
+		PreferredCategoryID: r.PreferredCategoryID,
+		MaxPrice:            r.MaxPrice,
+		MaxDistanceKm:       r.MaxDistanceKm,
+	}
+}
+
+type UpdateUserPreferencesRequest struct {
+	PreferredCategoryID uuid.UUID `json:"preferred_category_id"`
+	MaxPrice            float64   `json:"max_price" validate:"gte=0"`
+	MaxDistanceKm       int       `json:"max_distance_km" validate:"gte=0"`
+}
+
+func (r *UpdateUserPreferencesRequest) ApplyTo(preferences *entity.UserPreferences) {
+	preferences.PreferredCategoryID = r.PreferredCategoryID
+	preferences.MaxPrice = r.MaxPrice
+	preferences.MaxDistanceKm = r.MaxDistanceKm
+}
+
+type UserPreferencesResponse struct {
+	ID                  uuid.UUID `json:"id"`
+	UserID              uuid.UUID `json:"user_id"`
+	PreferredCategoryID uuid.UUID `json:"preferred_category_id"`
+	MaxPrice            float64   `json:"max_price"`
+	MaxDistanceKm       int       `json:"max_distance_km"`
+}
+
+func NewUserPreferencesResponse(preferences *entity.UserPreferences) UserPreferencesResponse {
+	return UserPreferencesResponse{
+		ID:                  preferences.ID,
+		UserID:              preferences.UserID,
+		PreferredCategoryID: preferences.PreferredCategoryID,
+		MaxPrice:            preferences.MaxPrice,
+		MaxDistanceKm:       preferences.MaxDistanceKm,
+	}
+}
@@ -0,0 +1,449 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+
+	"github.com/Vanv1k/BeautyTON/internal/domain/entity"
+	er "github.com/Vanv1k/BeautyTON/internal/domain/errors"
+	"github.com/Vanv1k/BeautyTON/internal/infrastructure/http/dto"
+	"github.com/Vanv1k/BeautyTON/internal/infrastructure/http/response"
+	"github.com/Vanv1k/BeautyTON/internal/usecase"
+)
+
+type AdminHandler struct {
+	usecase *usecase.AdminUsecase
+}
+
+func NewAdminHandler(usecase *usecase.AdminUsecase) *AdminHandler {
+	return &AdminHandler{usecase: usecase}
+}
+
+// RegisterRoutes registers the admin routes.
+func (h *AdminHandler) RegisterRoutes(routes *Routes) {
+	// Users
+	routes.Admin.HandleFunc("/users/{id}/role", h.SetUserRole).Methods("PUT", "OPTIONS")
+	routes.Admin.HandleFunc("/users/{id}/ban", h.BanUser).Methods("POST", "OPTIONS")
+	routes.Admin.HandleFunc("/users/{id}/suspend", h.SuspendUser).Methods("POST", "OPTIONS")
+	routes.Admin.HandleFunc("/users/{id}/unblock", h.UnblockUser).Methods("POST", "OPTIONS")
+
+	// Moderation
+	routes.Admin.HandleFunc("/moderation/reviews", h.ListReviewQueue).Methods("GET", "OPTIONS")
+	routes.Admin.HandleFunc("/moderation/reviews/{id}/approve", h.ApproveReview).Methods("POST", "OPTIONS")
+	routes.Admin.HandleFunc("/moderation/reviews/{id}/reject", h.RejectReview).Methods("POST", "OPTIONS")
+	routes.Admin.HandleFunc("/moderation/photos", h.ListPhotoQueue).Methods("GET", "OPTIONS")
+	routes.Admin.HandleFunc("/moderation/photos/{id}/approve", h.ApprovePhoto).Methods("POST", "OPTIONS")
+	routes.Admin.HandleFunc("/moderation/photos/{id}/reject", h.RejectPhoto).Methods("POST", "OPTIONS")
+
+	routes.Admin.HandleFunc("/actions", h.ListActions).Methods("GET", "OPTIONS")
+}
+
+// SetUserRole godoc
+// @Summary Change a user's role
+// @Description Change the role of a user. The user's sessions are revoked so new tokens carry the new role
+// @Tags admin
+// @Accept  json
+// @Produce  json
+// @Param id path string true "User ID"
+// @Param role body dto.SetUserRoleRequest true "New role"
+// @Success 200 {object} dto.AdminUserResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Router /admin/users/{id}/role [put]
+func (h *AdminHandler) SetUserRole(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseID(w, r)
+	if !ok {
+		return
+	}
+	var req dto.SetUserRoleRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+	user, err := h.usecase.SetUserRole(r.Context(), adminID(r), id, req.Role)
+	writeAdminUser(w, r, user, err)
+}
+
+// BanUser godoc
+// @Summary Ban a user
+// @Description Block a user until unbanned and revoke their sessions
+// @Tags admin
+// @Accept  json
+// @Produce  json
+// @Param id path string true "User ID"
+// @Param ban body dto.BanUserRequest true "Ban reason"
+// @Success 200 {object} dto.AdminUserResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Router /admin/users/{id}/ban [post]
+func (h *AdminHandler) BanUser(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseID(w, r)
+	if !ok {
+		return
+	}
+	var req dto.BanUserRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+	user, err := h.usecase.BanUser(r.Context(), adminID(r), id, req.Reason)
+	writeAdminUser(w, r, user, err)
+}
+
+// SuspendUser godoc
+// @Summary Suspend a user
+// @Description Block a user until the given time and revoke their sessions
+// @Tags admin
+// @Accept  json
+// @Produce  json
+// @Param id path string true "User ID"
+// @Param suspension body dto.SuspendUserRequest true "Suspension end and reason"
+// @Success 200 {object} dto.AdminUserResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Router /admin/users/{id}/suspend [post]
+func (h *AdminHandler) SuspendUser(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseID(w, r)
+	if !ok {
+		return
+	}
+	var req dto.SuspendUserRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+	user, err := h.usecase.SuspendUser(r.Context(), adminID(r), id, req.Until, req.Reason)
+	writeAdminUser(w, r, user, err)
+}
+
+// UnblockUser godoc
+// @Summary Unblock a user
+// @Description Lift a ban or a suspension
+// @Tags admin
+// @Produce  json
+// @Param id path string true "User ID"
+// @Success 200 {object} dto.AdminUserResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Router /admin/users/{id}/unblock [post]
+func (h *AdminHandler) UnblockUser(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseID(w, r)
+	if !ok {
+		return
+	}
+	user, err := h.usecase.UnblockUser(r.Context(), id)
+	writeAdminUser(w, r, user, err)
+}
+
+// ListReviewQueue godoc
+// @Summary List reviews for moderation
+// @Description List reviews by moderation status, oldest first
+// @Tags admin
+// @Produce  json
+// @Param status query string false "Moderation status: pending (default), approved, rejected"
+// @Param page query int false "Page number (default: 1)"
+// @Param page_size query int false "Number of reviews per page (default: 20, max: 100)"
+// @Success 200 {object} dto.ReviewListResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Router /admin/moderation/reviews [get]
+func (h *AdminHandler) ListReviewQueue(w http.ResponseWriter, r *http.Request) {
+	status, ok := parseModerationStatus(w, r)
+	if !ok {
+		return
+	}
+	page, pageSize, ok := parsePage(w, r)
+	if !ok {
+		return
+	}
+	reviews, total, err := h.usecase.ListReviews(r.Context(), status, page, pageSize)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(dto.ReviewListResponse{
+		Results:    dto.NewReviewResponses(reviews),
+		Page:       page,
+		PageSize:   pageSize,
+		Total:      total,
+		TotalPages: totalPages(total, pageSize),
+	})
+}
+
+// ApproveReview godoc
+// @Summary Approve a review
+// @Description Publish a review
+// @Tags admin
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Review ID"
+// @Success 200 {object} dto.ReviewResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Router /admin/moderation/reviews/{id}/approve [post]
+func (h *AdminHandler) ApproveReview(w http.ResponseWriter, r *http.Request) {
+	h.moderateReview(w, r, entity.ModerationStatusApproved)
+}
+
+// RejectReview godoc
+// @Summary Reject a review
+// @Description Hide a review from the public
+// @Tags admin
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Review ID"
+// @Param decision body dto.ModerationRequest true "Rejection reason"
+// @Success 200 {object} dto.ReviewResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Router /admin/moderation/reviews/{id}/reject [post]
+func (h *AdminHandler) RejectReview(w http.ResponseWriter, r *http.Request) {
+	h.moderateReview(w, r, entity.ModerationStatusRejected)
+}
+
+func (h *AdminHandler) moderateReview(w http.ResponseWriter, r *http.Request, status entity.ModerationStatus) {
+	id, ok := parseID(w, r)
+	if !ok {
+		return
+	}
+	req, ok := decodeModeration(w, r)
+	if !ok {
+		return
+	}
+	review, err := h.usecase.ModerateReview(r.Context(), id, status, req.Reason)
+	if err != nil {
+		if errors.Is(err, er.ErrRecordNotFound) {
+			err = er.NotFound("review not found")
+		}
+		response.Error(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(dto.NewReviewResponse(review))
+}
+
+// ListPhotoQueue godoc
+// @Summary List photos for moderation
+// @Description List uploaded user and service photos by moderation status, oldest first
+// @Tags admin
+// @Produce  json
+// @Param status query string false "Moderation status: pending (default), approved, rejected"
+// @Param page query int false "Page number (default: 1)"
+// @Param page_size query int false "Number of photos per page (default: 20, max: 100)"
+// @Success 200 {object} dto.PhotoModerationListResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Router /admin/moderation/photos [get]
+func (h *AdminHandler) ListPhotoQueue(w http.ResponseWriter, r *http.Request) {
+	status, ok := parseModerationStatus(w, r)
+	if !ok {
+		return
+	}
+	page, pageSize, ok := parsePage(w, r)
+	if !ok {
+		return
+	}
+	photos, total, err := h.usecase.ListPhotos(r.Context(), status, page, pageSize)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(dto.PhotoModerationListResponse{
+		Results:    dto.NewPhotoModerationResponses(photos),
+		Page:       page,
+		PageSize:   pageSize,
+		Total:      total,
+		TotalPages: totalPages(total, pageSize),
+	})
+}
+
+// ApprovePhoto godoc
+// @Summary Approve a photo
+// @Description Accept an uploaded photo
+// @Tags admin
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Photo moderation ID"
+// @Success 200 {object} dto.PhotoModerationResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Router /admin/moderation/photos/{id}/approve [post]
+func (h *AdminHandler) ApprovePhoto(w http.ResponseWriter, r *http.Request) {
+	h.moderatePhoto(w, r, entity.ModerationStatusApproved)
+}
+
+// RejectPhoto godoc
+// @Summary Reject a photo
+// @Description Reject an uploaded photo and remove it from its user or service
+// @Tags admin
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Photo moderation ID"
+// @Param decision body dto.ModerationRequest true "Rejection reason"
+// @Success 200 {object} dto.PhotoModerationResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Router /admin/moderation/photos/{id}/reject [post]
+func (h *AdminHandler) RejectPhoto(w http.ResponseWriter, r *http.Request) {
+	h.moderatePhoto(w, r, entity.ModerationStatusRejected)
+}
+
+func (h *AdminHandler) moderatePhoto(w http.ResponseWriter, r *http.Request, status entity.ModerationStatus) {
+	id, ok := parseID(w, r)
+	if !ok {
+		return
+	}
+	req, ok := decodeModeration(w, r)
+	if !ok {
+		return
+	}
+	photo, err := h.usecase.ModeratePhoto(r.Context(), adminID(r), id, status, req.Reason)
+	if err != nil {
+		if errors.Is(err, er.ErrRecordNotFound) {
+			err = er.NotFound("photo not found")
+		}
+		response.Error(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(dto.NewPhotoModerationResponse(photo))
+}
+
+// ListActions godoc
+// @Summary List admin actions
+// @Description Audit trail of changes made through the admin API, newest first
+// @Tags admin
+// @Produce  json
+// @Param admin_id query string false "Admin user ID"
+// @Param action query string false "Action, e.g. POST /admin/users/{id}/ban"
+// @Param entity_type query string false "Entity type, e.g. users, cities, reviews"
+// @Param entity_id query string false "Entity ID"
+// @Param page query int false "Page number (default: 1)"
+// @Param page_size query int false "Number of actions per page (default: 20, max: 100)"
+// @Success 200 {object} dto.AdminActionListResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Router /admin/actions [get]
+func (h *AdminHandler) ListActions(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	filter := entity.AdminActionFilter{
+		Action:     query.Get("action"),
+		EntityType: query.Get("entity_type"),
+		EntityID:   query.Get("entity_id"),
+	}
+	if adminIDStr := query.Get("admin_id"); adminIDStr != "" {
+		id, err := uuid.Parse(adminIDStr)
+		if err != nil {
+			response.Error(w, r, er.BadRequest("invalid admin_id"))
+			return
+		}
+		filter.AdminID = &id
+	}
+	var ok bool
+	if filter.Page, filter.PageSize, ok = parsePage(w, r); !ok {
+		return
+	}
+	actions, total, err := h.usecase.ListActions(r.Context(), filter)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(dto.AdminActionListResponse{
+		Results:    dto.NewAdminActionResponses(actions),
+		Page:       filter.Page,
+		PageSize:   filter.PageSize,
+		Total:      total,
+		TotalPages: totalPages(total, filter.PageSize),
+	})
+}
+
+// adminID returns the authenticated admin, set by TelegramAuthMiddleware.
+func adminID(r *http.Request) uuid.UUID {
+	id, _ := r.Context().Value("user_id").(uuid.UUID)
+	return id
+}
+
+func parseID(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		response.Error(w, r, er.BadRequest("invalid ID"))
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+func writeAdminUser(w http.ResponseWriter, r *http.Request, user *entity.User, err error) {
+	if err != nil {
+		if errors.Is(err, er.ErrRecordNotFound) {
+			err = er.NotFound("user not found")
+		}
+		response.Error(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(dto.NewAdminUserResponse(user))
+}
+
+// decodeModeration decodes an optional moderation body; approving needs no
+// reason, so an empty body is allowed.
+func decodeModeration(w http.ResponseWriter, r *http.Request) (dto.ModerationRequest, bool) {
+	var req dto.ModerationRequest
+	if r.ContentLength == 0 {
+		return req, true
+	}
+	return req, decodeRequest(w, r, &req)
+}
+
+func parseModerationStatus(w http.ResponseWriter, r *http.Request) (entity.ModerationStatus, bool) {
+	status := entity.ModerationStatus(r.URL.Query().Get("status"))
+	switch status {
+	case "":
+		return entity.ModerationStatusPending, true
+	case entity.ModerationStatusPending, entity.ModerationStatusApproved, entity.ModerationStatusRejected:
+		return status, true
+	}
+	response.Error(w, r, er.BadRequest("invalid status"))
+	return "", false
+}
+
+func parsePage(w http.ResponseWriter, r *http.Request) (int, int, bool) {
+	page, pageSize := 1, 20
+	maxPageSize := 100
+	if pageStr := r.URL.Query().Get("page"); pageStr != "" {
+		var err error
+		page, err = strconv.Atoi(pageStr)
+		if err != nil || page < 1 {
+			response.Error(w, r, er.BadRequest("invalid page number"))
+			return 0, 0, false
+		}
+	}
+	if pageSizeStr := r.URL.Query().Get("page_size"); pageSizeStr != "" {
+		var err error
+		pageSize, err = strconv.Atoi(pageSizeStr)
+		if err != nil || pageSize < 1 {
+			response.Error(w, r, er.BadRequest("invalid page size"))
+			return 0, 0, false
+		}
+		if pageSize > maxPageSize {
+			pageSize = maxPageSize
+		}
+	}
+	return page, pageSize, true
+}
+
+func totalPages(total int64, pageSize int) int {
+	return int((total + int64(pageSize) - 1) / int64(pageSize))
+}
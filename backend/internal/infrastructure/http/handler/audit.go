@@ -0,0 +1,103 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Vanv1k/BeautyTON/internal/domain/entity"
+	er "github.com/Vanv1k/BeautyTON/internal/domain/errors"
+	"github.com/Vanv1k/BeautyTON/internal/infrastructure/http/dto"
+	"github.com/Vanv1k/BeautyTON/internal/infrastructure/http/response"
+	"github.com/Vanv1k/BeautyTON/internal/usecase"
+)
+
+type AuditHandler struct {
+	usecase *usecase.AuditUsecase
+}
+
+func NewAuditHandler(usecase *usecase.AuditUsecase) *AuditHandler {
+	return &AuditHandler{usecase: usecase}
+}
+
+// RegisterRoutes registers the audit routes.
+func (h *AuditHandler) RegisterRoutes(routes *Routes) {
+	routes.Admin.HandleFunc("/audit_logs", h.ListAuditLogs).Methods("GET", "OPTIONS")
+}
+
+// ListAuditLogs godoc
+// @Summary List audit log entries
+// @Description Created, updated and deleted rows with the changed values, newest first. Updates hold only the changed columns
+// @Tags admin
+// @Produce  json
+// @Param actor_id query string false "ID of the user who made the change"
+// @Param action query string false "Action (create, update, delete)"
+// @Param entity_type query string false "Entity type (table name), e.g. bookings, services"
+// @Param entity_id query string false "Entity ID"
+// @Param request_id query string false "Request ID"
+// @Param from query string false "Changes made at or after this time (RFC 3339)"
+// @Param to query string false "Changes made before this time (RFC 3339)"
+// @Param page query int false "Page number (default: 1)"
+// @Param page_size query int false "Number of entries per page (default: 20, max: 100)"
+// @Success 200 {object} dto.AuditLogListResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Router /admin/audit_logs [get]
+func (h *AuditHandler) ListAuditLogs(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	filter := entity.AuditLogFilter{
+		Action:     entity.AuditAction(query.Get("action")),
+		EntityType: query.Get("entity_type"),
+		EntityID:   query.Get("entity_id"),
+		RequestID:  query.Get("request_id"),
+	}
+	switch filter.Action {
+	case "", entity.AuditActionCreate, entity.AuditActionUpdate, entity.AuditActionDelete:
+	default:
+		response.Error(w, r, er.BadRequest("invalid action"))
+		return
+	}
+	if actorIDStr := query.Get("actor_id"); actorIDStr != "" {
+		id, err := uuid.Parse(actorIDStr)
+		if err != nil {
+			response.Error(w, r, er.BadRequest("invalid actor_id"))
+			return
+		}
+		filter.ActorID = &id
+	}
+	if fromStr := query.Get("from"); fromStr != "" {
+		from, err := time.Parse(time.RFC3339, fromStr)
+		if err != nil {
+			response.Error(w, r, er.BadRequest("invalid from"))
+			return
+		}
+		filter.From = &from
+	}
+	if toStr := query.Get("to"); toStr != "" {
+		to, err := time.Parse(time.RFC3339, toStr)
+		if err != nil {
+			response.Error(w, r, er.BadRequest("invalid to"))
+			return
+		}
+		filter.To = &to
+	}
+	var ok bool
+	if filter.Page, filter.PageSize, ok = parsePage(w, r); !ok {
+		return
+	}
+	logs, total, err := h.usecase.ListLogs(r.Context(), filter)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(dto.AuditLogListResponse{
+		Results:    dto.NewAuditLogResponses(logs),
+		Page:       filter.Page,
+		PageSize:   filter.PageSize,
+		Total:      total,
+		TotalPages: totalPages(total, filter.PageSize),
+	})
+}
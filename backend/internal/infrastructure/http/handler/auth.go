@@ -0,0 +1,110 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/google/uuid"
+
+	"github.com/Vanv1k/BeautyTON/internal/domain/entity"
+	er "github.com/Vanv1k/BeautyTON/internal/domain/errors"
+	"github.com/Vanv1k/BeautyTON/internal/infrastructure/http/dto"
+	"github.com/Vanv1k/BeautyTON/internal/infrastructure/http/response"
+	"github.com/Vanv1k/BeautyTON/internal/usecase"
+)
+
+type AuthHandler struct {
+	usecase *usecase.AuthUsecase
+}
+
+func NewAuthHandler(usecase *usecase.AuthUsecase) *AuthHandler {
+	return &AuthHandler{usecase: usecase}
+}
+
+// RegisterRoutes registers the auth routes.
+func (h *AuthHandler) RegisterRoutes(routes *Routes) {
+	// Session routes exchange credentials for tokens, so they skip
+	// authentication
+	limitAuth := routes.RateLimit(entity.RateLimitGroupAuth)
+	routes.Public.Handle("/auth/session", limitAuth(http.HandlerFunc(h.CreateSession))).Methods("POST", "OPTIONS")
+	routes.Public.Handle("/auth/refresh", limitAuth(http.HandlerFunc(h.RefreshSession))).Methods("POST", "OPTIONS")
+
+	routes.API.HandleFunc("/auth/session", h.DeleteSession).Methods("DELETE", "OPTIONS")
+}
+
+// CreateSession godoc
+// @Summary Create a session
+// @Description Exchange Telegram Mini App initData for a short-lived access token and a refresh token. Send the access token as "Authorization: Bearer <token>"
+// @Tags auth
+// @Accept  json
+// @Produce  json
+// @Param session body dto.CreateSessionRequest true "Telegram initData"
+// @Success 201 {object} dto.SessionResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 429 {object} response.ErrorResponse
+// @Router /auth/session [post]
+func (h *AuthHandler) CreateSession(w http.ResponseWriter, r *http.Request) {
+	var req dto.CreateSessionRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+	tokens, err := h.usecase.CreateSession(r.Context(), req.InitData)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(dto.NewSessionResponse(tokens))
+}
+
+// RefreshSession godoc
+// @Summary Refresh a session
+// @Description Exchange a refresh token for a new token pair. Each refresh token works once; reusing one revokes the session
+// @Tags auth
+// @Accept  json
+// @Produce  json
+// @Param session body dto.RefreshSessionRequest true "Refresh token"
+// @Success 200 {object} dto.SessionResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 429 {object} response.ErrorResponse
+// @Router /auth/refresh [post]
+func (h *AuthHandler) RefreshSession(w http.ResponseWriter, r *http.Request) {
+	var req dto.RefreshSessionRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+	tokens, err := h.usecase.RefreshSession(r.Context(), req.RefreshToken)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(dto.NewSessionResponse(tokens))
+}
+
+// DeleteSession godoc
+// @Summary Log out
+// @Description Revoke the session of the bearer access token
+// @Tags auth
+// @Produce  json
+// @Success 204
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Router /auth/session [delete]
+func (h *AuthHandler) DeleteSession(w http.ResponseWriter, r *http.Request) {
+	sessionID, ok := r.Context().Value("session_id").(uuid.UUID)
+	if !ok {
+		response.Error(w, r, er.BadRequest("request is not authenticated with a session"))
+		return
+	}
+	if err := h.usecase.RevokeSession(r.Context(), sessionID); err != nil {
+		response.Error(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -5,6 +5,8 @@ import (
 	"errors"
 	"github.com/Vanv1k/BeautyTON/internal/domain/entity"
 	er "github.com/Vanv1k/BeautyTON/internal/domain/errors"
+	"github.com/Vanv1k/BeautyTON/internal/infrastructure/http/dto"
+	"github.com/Vanv1k/BeautyTON/internal/infrastructure/http/response"
 	"github.com/Vanv1k/BeautyTON/internal/usecase"
 	"github.com/google/uuid"
 	"github.com/gorilla/mux"
@@ -19,6 +21,16 @@ func NewBookingHandler(usecase *usecase.BookingUsecase) *BookingHandler {
 	return &BookingHandler{usecase: usecase}
 }
 
+// RegisterRoutes registers the booking routes.
+func (h *BookingHandler) RegisterRoutes(routes *Routes) {
+	routes.API.HandleFunc("/bookings/{id}", h.GetBooking).Methods("GET", "OPTIONS")
+	routes.API.Handle("/bookings", routes.RateLimit(entity.RateLimitGroupBookings)(http.HandlerFunc(h.CreateBooking))).Methods("POST", "OPTIONS")
+	routes.API.HandleFunc("/bookings/{id}", h.UpdateBooking).Methods("PUT", "OPTIONS")
+	routes.API.HandleFunc("/bookings/{id}", h.PatchBooking).Methods("PATCH", "OPTIONS")
+	routes.API.HandleFunc("/bookings/{id}", h.DeleteBooking).Methods("DELETE", "OPTIONS")
+	routes.API.HandleFunc("/bookings/{id}/status", h.UpdateBookingStatus).Methods("PUT", "OPTIONS")
+}
+
 // GetBooking godoc
 // @Summary Get a booking by ID
 // @Description Get booking details by booking ID
@@ -26,54 +38,60 @@ func NewBookingHandler(usecase *usecase.BookingUsecase) *BookingHandler {
 // @Accept  json
 // @Produce  json
 // @Param id path string true "Booking ID"
-// @Success 200 {object} entity.Booking
-// @Failure 400 {object} map[string]string
-// @Failure 404 {object} map[string]string
-// @Failure 500 {object} map[string]string
+// @Success 200 {object} dto.BookingResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
 // @Router /bookings/{id} [get]
 func (h *BookingHandler) GetBooking(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	id, err := uuid.Parse(vars["id"])
 	if err != nil {
-		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		response.Error(w, r, er.BadRequest("invalid ID"))
 		return
 	}
 	booking, err := h.usecase.GetBooking(r.Context(), id)
 	if err != nil {
 		if errors.Is(err, er.ErrRecordNotFound) {
-			http.Error(w, "Booking not found", http.StatusNotFound)
-		} else {
-			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			err = er.NotFound("booking not found")
 		}
+		response.Error(w, r, err)
 		return
 	}
+	setETag(w, booking.Version)
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(booking)
+	json.NewEncoder(w).Encode(dto.NewBookingResponse(booking))
 }
 
 // CreateBooking godoc
 // @Summary Create a new booking
-// @Description Create a new booking with the input payload
+// @Description Create a new booking of the current user with the master and service in the payload
 // @Tags bookings
 // @Accept  json
 // @Produce  json
-// @Param booking body entity.Booking true "Create booking"
-// @Success 201 {object} entity.Booking
-// @Failure 400 {object} map[string]string
+// @Param booking body dto.CreateBookingRequest true "Create booking"
+// @Param Idempotency-Key header string false "Unique key making retries of this request safe"
+// @Success 201 {object} dto.BookingResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 409 {object} response.ErrorResponse
+// @Failure 422 {object} response.ErrorResponse
+// @Failure 429 {object} response.ErrorResponse
 // @Router /bookings [post]
 func (h *BookingHandler) CreateBooking(w http.ResponseWriter, r *http.Request) {
-	var booking entity.Booking
-	if err := json.NewDecoder(r.Body).Decode(&booking); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+	var req dto.CreateBookingRequest
+	if !decodeRequest(w, r, &req) {
 		return
 	}
-	if err := h.usecase.CreateBooking(r.Context(), &booking); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+	clientID, _ := currentUser(r)
+	booking := req.ToEntity(clientID)
+	if err := h.usecase.CreateBooking(r.Context(), booking); err != nil {
+		response.Error(w, r, err)
 		return
 	}
+	setETag(w, booking.Version)
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
-	json.NewEncoder(w).Encode(booking)
+	json.NewEncoder(w).Encode(dto.NewBookingResponse(booking))
 }
 
 // UpdateBooking godoc
@@ -83,29 +101,91 @@ func (h *BookingHandler) CreateBooking(w http.ResponseWriter, r *http.Request) {
 // @Accept  json
 // @Produce  json
 // @Param id path string true "Booking ID"
-// @Param booking body entity.Booking true "Update booking"
-// @Success 200 {object} entity.Booking
-// @Failure 400 {object} map[string]string
+// @Param booking body dto.UpdateBookingRequest true "Update booking"
+// @Param If-Match header string true "ETag of the version being updated"
+// @Success 200 {object} dto.BookingResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 412 {object} response.ErrorResponse
+// @Failure 428 {object} response.ErrorResponse
 // @Router /bookings/{id} [put]
 func (h *BookingHandler) UpdateBooking(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	id, err := uuid.Parse(vars["id"])
 	if err != nil {
-		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		response.Error(w, r, er.BadRequest("invalid ID"))
+		return
+	}
+	var req dto.UpdateBookingRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+	booking, err := h.usecase.GetBooking(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, er.ErrRecordNotFound) {
+			err = er.NotFound("booking not found")
+		}
+		response.Error(w, r, err)
+		return
+	}
+	if !checkIfMatch(w, r, booking.Version) {
+		return
+	}
+	req.ApplyTo(booking)
+	if err := h.usecase.UpdateBooking(r.Context(), booking); err != nil {
+		response.Error(w, r, err)
+		return
+	}
+	setETag(w, booking.Version)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(dto.NewBookingResponse(booking))
+}
+
+// PatchBooking godoc
+// @Summary Partially update a booking
+// @Description Apply a JSON Merge Patch (RFC 7386) to a booking by booking ID. Members set to null are cleared
+// @Tags bookings
+// @Accept  json
+// @Accept  application/merge-patch+json
+// @Produce  json
+// @Param id path string true "Booking ID"
+// @Param booking body dto.UpdateBookingRequest true "Fields to change"
+// @Param If-Match header string true "ETag of the version being updated"
+// @Success 200 {object} dto.BookingResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 412 {object} response.ErrorResponse
+// @Failure 428 {object} response.ErrorResponse
+// @Router /bookings/{id} [patch]
+func (h *BookingHandler) PatchBooking(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := uuid.Parse(vars["id"])
+	if err != nil {
+		response.Error(w, r, er.BadRequest("invalid ID"))
+		return
+	}
+	booking, err := h.usecase.GetBooking(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, er.ErrRecordNotFound) {
+			err = er.NotFound("booking not found")
+		}
+		response.Error(w, r, err)
 		return
 	}
-	var booking entity.Booking
-	if err := json.NewDecoder(r.Body).Decode(&booking); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+	if !checkIfMatch(w, r, booking.Version) {
 		return
 	}
-	booking.ID = id
-	if err := h.usecase.UpdateBooking(r.Context(), &booking); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+	req := dto.NewUpdateBookingRequest(booking)
+	if !decodePatch(w, r, &req) {
 		return
 	}
+	req.ApplyTo(booking)
+	if err := h.usecase.UpdateBooking(r.Context(), booking); err != nil {
+		response.Error(w, r, err)
+		return
+	}
+	setETag(w, booking.Version)
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(booking)
+	json.NewEncoder(w).Encode(dto.NewBookingResponse(booking))
 }
 
 // UpdateBookingStatus godoc
@@ -115,31 +195,43 @@ func (h *BookingHandler) UpdateBooking(w http.ResponseWriter, r *http.Request) {
 // @Accept  json
 // @Produce  json
 // @Param id path string true "Booking ID"
-// @Param status body object{status=entity.BookingStatus} true "Booking status"
-// @Success 200 {object} entity.Booking
-// @Failure 400 {object} map[string]string
+// @Param status body dto.UpdateBookingStatusRequest true "Booking status"
+// @Param If-Match header string true "ETag of the version being updated"
+// @Success 200 {object} dto.BookingResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 412 {object} response.ErrorResponse
+// @Failure 428 {object} response.ErrorResponse
 // @Router /bookings/{id}/status [put]
 func (h *BookingHandler) UpdateBookingStatus(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	id, err := uuid.Parse(vars["id"])
 	if err != nil {
-		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		response.Error(w, r, er.BadRequest("invalid ID"))
+		return
+	}
+	booking, err := h.usecase.GetBooking(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, er.ErrRecordNotFound) {
+			err = er.NotFound("booking not found")
+		}
+		response.Error(w, r, err)
 		return
 	}
-	var input struct {
-		Status entity.BookingStatus `json:"status"`
+	if !checkIfMatch(w, r, booking.Version) {
+		return
 	}
-	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+	var req dto.UpdateBookingStatusRequest
+	if !decodeRequest(w, r, &req) {
 		return
 	}
-	booking, err := h.usecase.UpdateBookingStatus(r.Context(), id, input.Status)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+	if err := h.usecase.UpdateBookingStatus(r.Context(), booking, req.Status); err != nil {
+		response.Error(w, r, err)
 		return
 	}
+	setETag(w, booking.Version)
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(booking)
+	json.NewEncoder(w).Encode(dto.NewBookingResponse(booking))
 }
 
 // DeleteBooking godoc
@@ -150,23 +242,22 @@ func (h *BookingHandler) UpdateBookingStatus(w http.ResponseWriter, r *http.Requ
 // @Produce  json
 // @Param id path string true "Booking ID"
 // @Success 204
-// @Failure 400 {object} map[string]string
-// @Failure 404 {object} map[string]string
-// @Failure 500 {object} map[string]string
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
 // @Router /bookings/{id} [delete]
 func (h *BookingHandler) DeleteBooking(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	id, err := uuid.Parse(vars["id"])
 	if err != nil {
-		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		response.Error(w, r, er.BadRequest("invalid ID"))
 		return
 	}
 	if err := h.usecase.DeleteBooking(r.Context(), id); err != nil {
 		if errors.Is(err, er.ErrRecordNotFound) {
-			http.Error(w, "Booking not found", http.StatusNotFound)
-		} else {
-			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			err = er.NotFound("booking not found")
 		}
+		response.Error(w, r, err)
 		return
 	}
 	w.WriteHeader(http.StatusNoContent)
@@ -0,0 +1,206 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+
+	"github.com/Vanv1k/BeautyTON/internal/domain/entity"
+	er "github.com/Vanv1k/BeautyTON/internal/domain/errors"
+	"github.com/Vanv1k/BeautyTON/internal/domain/repository"
+	"github.com/Vanv1k/BeautyTON/internal/usecase"
+)
+
+// The fakes below keep records in maps. Their embedded interfaces panic on
+// calls a test does not expect.
+
+type fakeUserRepo struct {
+	repository.UserRepository
+	users map[uuid.UUID]*entity.User
+}
+
+func (r *fakeUserRepo) GetByID(ctx context.Context, id uuid.UUID) (*entity.User, error) {
+	if user, ok := r.users[id]; ok {
+		return user, nil
+	}
+	return nil, er.ErrRecordNotFound
+}
+
+type fakeServiceRepo struct {
+	repository.ServiceRepository
+	services map[uuid.UUID]*entity.Service
+}
+
+func (r *fakeServiceRepo) GetByID(ctx context.Context, id uuid.UUID) (*entity.Service, error) {
+	if service, ok := r.services[id]; ok {
+		return service, nil
+	}
+	return nil, er.ErrRecordNotFound
+}
+
+type fakeBookingRepo struct {
+	repository.BookingRepository
+	bookings map[uuid.UUID]*entity.Booking
+}
+
+func (r *fakeBookingRepo) GetByID(ctx context.Context, id uuid.UUID) (*entity.Booking, error) {
+	if booking, ok := r.bookings[id]; ok {
+		copied := *booking
+		return &copied, nil
+	}
+	return nil, er.ErrRecordNotFound
+}
+
+func (r *fakeBookingRepo) Create(ctx context.Context, booking *entity.Booking) error {
+	r.bookings[booking.ID] = booking
+	return nil
+}
+
+// Update checks the version like the Postgres repository does.
+func (r *fakeBookingRepo) Update(ctx context.Context, booking *entity.Booking) error {
+	stored, ok := r.bookings[booking.ID]
+	if !ok {
+		return er.ErrRecordNotFound
+	}
+	if stored.Version != booking.Version {
+		return er.ErrVersionConflict
+	}
+	booking.Version++
+	copied := *booking
+	r.bookings[booking.ID] = &copied
+	return nil
+}
+
+// bookingFixture serves the booking routes for a client, a master and a
+// service of the master.
+type bookingFixture struct {
+	bookings *fakeBookingRepo
+	handler  *BookingHandler
+	client   uuid.UUID
+	master   uuid.UUID
+	service  uuid.UUID
+}
+
+func newBookingFixture() *bookingFixture {
+	f := &bookingFixture{
+		bookings: &fakeBookingRepo{bookings: map[uuid.UUID]*entity.Booking{}},
+		client:   uuid.New(),
+		master:   uuid.New(),
+		service:  uuid.New(),
+	}
+	users := &fakeUserRepo{users: map[uuid.UUID]*entity.User{
+		f.client: {ID: f.client, Role: entity.UserRoleClient},
+		f.master: {ID: f.master, Role: entity.UserRoleMaster},
+	}}
+	services := &fakeServiceRepo{services: map[uuid.UUID]*entity.Service{
+		f.service: {ID: f.service, UserID: &f.master, Price: 1000},
+	}}
+	f.handler = NewBookingHandler(usecase.NewBookingUsecase(f.bookings, users, services))
+	return f
+}
+
+// authenticatedRequest builds a request of userID as set by the auth
+// middleware.
+func authenticatedRequest(method, target, body string, userID uuid.UUID, role entity.UserRole) *http.Request {
+	r := httptest.NewRequest(method, target, strings.NewReader(body))
+	ctx := context.WithValue(r.Context(), "user_id", userID)
+	ctx = context.WithValue(ctx, "user_role", string(role))
+	return r.WithContext(ctx)
+}
+
+func TestCreateBookingIsMadeForCaller(t *testing.T) {
+	f := newBookingFixture()
+	body := `{"master_id":"` + f.master.String() + `","service_id":"` + f.service.String() + `","booking_time":"2030-01-01T10:00:00Z"`
+
+	w := httptest.NewRecorder()
+	f.handler.CreateBooking(w, authenticatedRequest("POST", "/bookings", body+"}", f.client, entity.UserRoleClient))
+	if w.Code != http.StatusCreated {
+		t.Fatalf("create booking: got %d %s", w.Code, w.Body)
+	}
+	if len(f.bookings.bookings) != 1 {
+		t.Fatalf("%d bookings stored, want 1", len(f.bookings.bookings))
+	}
+	for _, booking := range f.bookings.bookings {
+		if booking.ClientID != f.client {
+			t.Fatalf("booking client is %s, want the caller %s", booking.ClientID, f.client)
+		}
+	}
+
+	// The client can no longer be chosen by the caller
+	w = httptest.NewRecorder()
+	withClient := body + `,"client_id":"` + uuid.NewString() + `"}`
+	f.handler.CreateBooking(w, authenticatedRequest("POST", "/bookings", withClient, f.client, entity.UserRoleClient))
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("create booking with client_id: got %d, want 400", w.Code)
+	}
+	if len(f.bookings.bookings) != 1 {
+		t.Fatalf("%d bookings stored, want 1", len(f.bookings.bookings))
+	}
+}
+
+func TestUpdateBookingRequiresCurrentIfMatch(t *testing.T) {
+	f := newBookingFixture()
+	booking := &entity.Booking{
+		ID:          uuid.New(),
+		ClientID:    f.client,
+		MasterID:    f.master,
+		ServiceID:   f.service,
+		BookingTime: time.Date(2030, 1, 1, 10, 0, 0, 0, time.UTC),
+		Status:      entity.BookingStatusPending,
+		Version:     2,
+	}
+	f.bookings.bookings[booking.ID] = booking
+	body := `{"service_id":"` + f.service.String() + `","booking_time":"2030-01-02T10:00:00Z"}`
+	status := `{"status":"confirmed"}`
+
+	tests := []struct {
+		name    string
+		method  string
+		update  http.HandlerFunc
+		body    string
+		ifMatch string
+		want    int
+	}{
+		{name: "put without If-Match", method: "PUT", update: f.handler.UpdateBooking, want: http.StatusPreconditionRequired},
+		{name: "patch without If-Match", method: "PATCH", update: f.handler.PatchBooking, want: http.StatusPreconditionRequired},
+		{name: "put with a stale version", method: "PUT", update: f.handler.UpdateBooking, ifMatch: `"1"`, want: http.StatusPreconditionFailed},
+		{name: "patch with a stale version", method: "PATCH", update: f.handler.PatchBooking, ifMatch: `"1"`, want: http.StatusPreconditionFailed},
+		{name: "put with the current version", method: "PUT", update: f.handler.UpdateBooking, ifMatch: `"2"`, want: http.StatusOK},
+		// The put above moved the booking to version 3
+		{name: "patch with the current version", method: "PATCH", update: f.handler.PatchBooking, ifMatch: `"0", "3"`, want: http.StatusOK},
+		{name: "status without If-Match", method: "PUT", update: f.handler.UpdateBookingStatus, body: status, want: http.StatusPreconditionRequired},
+		{name: "status with a stale version", method: "PUT", update: f.handler.UpdateBookingStatus, body: status, ifMatch: `"3"`, want: http.StatusPreconditionFailed},
+		{name: "status with the current version", method: "PUT", update: f.handler.UpdateBookingStatus, body: status, ifMatch: `"4"`, want: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.body == "" {
+				tt.body = body
+			}
+			r := authenticatedRequest(tt.method, "/bookings/"+booking.ID.String(), tt.body, f.client, entity.UserRoleClient)
+			r = mux.SetURLVars(r, map[string]string{"id": booking.ID.String()})
+			if tt.ifMatch != "" {
+				r.Header.Set("If-Match", tt.ifMatch)
+			}
+			w := httptest.NewRecorder()
+			tt.update(w, r)
+			if w.Code != tt.want {
+				t.Fatalf("got %d %s, want %d", w.Code, w.Body, tt.want)
+			}
+			if w.Code == http.StatusOK && w.Header().Get("ETag") != strconv.Quote(strconv.FormatInt(f.bookings.bookings[booking.ID].Version, 10)) {
+				t.Fatalf("ETag is %q, want the stored version", w.Header().Get("ETag"))
+			}
+		})
+	}
+	stored := f.bookings.bookings[booking.ID]
+	if stored.Version != 5 || stored.Status != entity.BookingStatusConfirmed {
+		t.Fatalf("booking is %s at version %d, want confirmed at 5 after the three accepted updates", stored.Status, stored.Version)
+	}
+}
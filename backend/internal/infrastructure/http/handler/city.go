@@ -9,8 +9,9 @@ import (
 	"github.com/google/uuid"
 	"github.com/gorilla/mux"
 
-	"github.com/Vanv1k/BeautyTON/internal/domain/entity"
 	er "github.com/Vanv1k/BeautyTON/internal/domain/errors"
+	"github.com/Vanv1k/BeautyTON/internal/infrastructure/http/dto"
+	"github.com/Vanv1k/BeautyTON/internal/infrastructure/http/response"
 	"github.com/Vanv1k/BeautyTON/internal/usecase"
 )
 
@@ -22,6 +23,16 @@ func NewCityHandler(usecase *usecase.CityUsecase) *CityHandler {
 	return &CityHandler{usecase: usecase}
 }
 
+// RegisterRoutes registers the city routes.
+func (h *CityHandler) RegisterRoutes(routes *Routes) {
+	routes.API.HandleFunc("/cities/{id}", h.GetCity).Methods("GET", "OPTIONS")
+	routes.API.HandleFunc("/cities", h.ListCities).Methods("GET", "OPTIONS")
+
+	routes.Admin.HandleFunc("/cities", h.CreateCity).Methods("POST", "OPTIONS")
+	routes.Admin.HandleFunc("/cities/{id}", h.UpdateCity).Methods("PUT", "OPTIONS")
+	routes.Admin.HandleFunc("/cities/{id}", h.DeleteCity).Methods("DELETE", "OPTIONS")
+}
+
 // GetCity godoc
 // @Summary Get a city by ID
 // @Description Get city details by city ID
@@ -29,29 +40,28 @@ func NewCityHandler(usecase *usecase.CityUsecase) *CityHandler {
 // @Accept  json
 // @Produce  json
 // @Param id path string true "City ID"
-// @Success 200 {object} entity.City
-// @Failure 400 {object} map[string]string
-// @Failure 404 {object} map[string]string
-// @Failure 500 {object} map[string]string
+// @Success 200 {object} dto.CityResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
 // @Router /cities/{id} [get]
 func (h *CityHandler) GetCity(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	id, err := uuid.Parse(vars["id"])
 	if err != nil {
-		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		response.Error(w, r, er.BadRequest("invalid ID"))
 		return
 	}
 	city, err := h.usecase.GetCity(r.Context(), id)
 	if err != nil {
 		if errors.Is(err, er.ErrRecordNotFound) {
-			http.Error(w, "City not found", http.StatusNotFound)
-		} else {
-			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			err = er.NotFound("city not found")
 		}
+		response.Error(w, r, err)
 		return
 	}
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(city)
+	json.NewEncoder(w).Encode(dto.NewCityResponse(city))
 }
 
 // CreateCity godoc
@@ -60,23 +70,24 @@ func (h *CityHandler) GetCity(w http.ResponseWriter, r *http.Request) {
 // @Tags cities
 // @Accept  json
 // @Produce  json
-// @Param city body entity.City true "Create city"
-// @Success 201 {object} entity.City
-// @Failure 400 {object} map[string]string
-// @Router /cities [post]
+// @Param city body dto.CityRequest true "Create city"
+// @Success 201 {object} dto.CityResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Router /admin/cities [post]
 func (h *CityHandler) CreateCity(w http.ResponseWriter, r *http.Request) {
-	var city entity.City
-	if err := json.NewDecoder(r.Body).Decode(&city); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+	var req dto.CityRequest
+	if !decodeRequest(w, r, &req) {
 		return
 	}
-	if err := h.usecase.CreateCity(r.Context(), &city); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+	city := req.ToEntity()
+	if err := h.usecase.CreateCity(r.Context(), city); err != nil {
+		response.Error(w, r, err)
 		return
 	}
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
-	json.NewEncoder(w).Encode(city)
+	json.NewEncoder(w).Encode(dto.NewCityResponse(city))
 }
 
 // UpdateCity godoc
@@ -86,29 +97,37 @@ func (h *CityHandler) CreateCity(w http.ResponseWriter, r *http.Request) {
 // @Accept  json
 // @Produce  json
 // @Param id path string true "City ID"
-// @Param city body entity.City true "Update city"
-// @Success 200 {object} entity.City
-// @Failure 400 {object} map[string]string
-// @Router /cities/{id} [put]
+// @Param city body dto.CityRequest true "Update city"
+// @Success 200 {object} dto.CityResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Router /admin/cities/{id} [put]
 func (h *CityHandler) UpdateCity(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	id, err := uuid.Parse(vars["id"])
 	if err != nil {
-		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		response.Error(w, r, er.BadRequest("invalid ID"))
+		return
+	}
+	var req dto.CityRequest
+	if !decodeRequest(w, r, &req) {
 		return
 	}
-	var city entity.City
-	if err := json.NewDecoder(r.Body).Decode(&city); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+	city, err := h.usecase.GetCity(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, er.ErrRecordNotFound) {
+			err = er.NotFound("city not found")
+		}
+		response.Error(w, r, err)
 		return
 	}
-	city.ID = id
-	if err := h.usecase.UpdateCity(r.Context(), &city); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+	req.ApplyTo(city)
+	if err := h.usecase.UpdateCity(r.Context(), city); err != nil {
+		response.Error(w, r, err)
 		return
 	}
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(city)
+	json.NewEncoder(w).Encode(dto.NewCityResponse(city))
 }
 
 // DeleteCity godoc
@@ -119,23 +138,23 @@ func (h *CityHandler) UpdateCity(w http.ResponseWriter, r *http.Request) {
 // @Produce  json
 // @Param id path string true "City ID"
 // @Success 204
-// @Failure 400 {object} map[string]string
-// @Failure 404 {object} map[string]string
-// @Failure 500 {object} map[string]string
-// @Router /cities/{id} [delete]
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Router /admin/cities/{id} [delete]
 func (h *CityHandler) DeleteCity(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	id, err := uuid.Parse(vars["id"])
 	if err != nil {
-		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		response.Error(w, r, er.BadRequest("invalid ID"))
 		return
 	}
 	if err := h.usecase.DeleteCity(r.Context(), id); err != nil {
 		if errors.Is(err, er.ErrRecordNotFound) {
-			http.Error(w, "City not found", http.StatusNotFound)
-		} else {
-			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			err = er.NotFound("city not found")
 		}
+		response.Error(w, r, err)
 		return
 	}
 	w.WriteHeader(http.StatusNoContent)
@@ -151,8 +170,8 @@ func (h *CityHandler) DeleteCity(w http.ResponseWriter, r *http.Request) {
 // @Param page query int false "Page number (default: 1)"
 // @Param page_size query int false "Number of cities per page (default: 10, max: 100)"
 // @Success 200 {object} map[string]interface{} "Paginated list of cities with metadata under 'results' key"
-// @Failure 400 {object} map[string]string
-// @Failure 500 {object} map[string]string
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
 // @Router /cities [get]
 func (h *CityHandler) ListCities(w http.ResponseWriter, r *http.Request) {
 	// Parse query parameters
@@ -170,7 +189,7 @@ func (h *CityHandler) ListCities(w http.ResponseWriter, r *http.Request) {
 		var err error
 		page, err = strconv.Atoi(pageStr)
 		if err != nil || page < 1 {
-			http.Error(w, "Invalid page number", http.StatusBadRequest)
+			response.Error(w, r, er.BadRequest("invalid page number"))
 			return
 		}
 	}
@@ -180,7 +199,7 @@ func (h *CityHandler) ListCities(w http.ResponseWriter, r *http.Request) {
 		var err error
 		pageSize, err = strconv.Atoi(pageSizeStr)
 		if err != nil || pageSize < 1 {
-			http.Error(w, "Invalid page size", http.StatusBadRequest)
+			response.Error(w, r, er.BadRequest("invalid page size"))
 			return
 		}
 		if pageSize > maxPageSize {
@@ -191,7 +210,7 @@ func (h *CityHandler) ListCities(w http.ResponseWriter, r *http.Request) {
 	// Fetch paginated cities
 	cities, total, err := h.usecase.ListCities(r.Context(), query, page, pageSize)
 	if err != nil {
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		response.Error(w, r, err)
 		return
 	}
 
@@ -199,8 +218,8 @@ func (h *CityHandler) ListCities(w http.ResponseWriter, r *http.Request) {
 	totalPages := int((total + int64(pageSize) - 1) / int64(pageSize))
 
 	// Prepare response
-	response := map[string]interface{}{
-		"results":     cities,
+	result := map[string]interface{}{
+		"results":     dto.NewCityResponses(cities),
 		"page":        page,
 		"page_size":   pageSize,
 		"total":       total,
@@ -209,5 +228,5 @@ func (h *CityHandler) ListCities(w http.ResponseWriter, r *http.Request) {
 
 	// Send response
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(response)
+	json.NewEncoder(w).Encode(result)
 }
@@ -8,8 +8,9 @@ import (
 	"github.com/google/uuid"
 	"github.com/gorilla/mux"
 
-	"github.com/Vanv1k/BeautyTON/internal/domain/entity"
 	er "github.com/Vanv1k/BeautyTON/internal/domain/errors"
+	"github.com/Vanv1k/BeautyTON/internal/infrastructure/http/dto"
+	"github.com/Vanv1k/BeautyTON/internal/infrastructure/http/response"
 	"github.com/Vanv1k/BeautyTON/internal/usecase"
 )
 
@@ -21,6 +22,15 @@ func NewCountryHandler(usecase *usecase.CountryUsecase) *CountryHandler {
 	return &CountryHandler{usecase: usecase}
 }
 
+// RegisterRoutes registers the country routes.
+func (h *CountryHandler) RegisterRoutes(routes *Routes) {
+	routes.API.HandleFunc("/countries/{id}", h.GetCountry).Methods("GET", "OPTIONS")
+
+	routes.Admin.HandleFunc("/countries", h.CreateCountry).Methods("POST", "OPTIONS")
+	routes.Admin.HandleFunc("/countries/{id}", h.UpdateCountry).Methods("PUT", "OPTIONS")
+	routes.Admin.HandleFunc("/countries/{id}", h.DeleteCountry).Methods("DELETE", "OPTIONS")
+}
+
 // GetCountry godoc
 // @Summary Get a country by ID
 // @Description Get country details by country ID
@@ -28,29 +38,28 @@ func NewCountryHandler(usecase *usecase.CountryUsecase) *CountryHandler {
 // @Accept  json
 // @Produce  json
 // @Param id path string true "Country ID"
-// @Success 200 {object} entity.Country
-// @Failure 400 {object} map[string]string
-// @Failure 404 {object} map[string]string
-// @Failure 500 {object} map[string]string
+// @Success 200 {object} dto.CountryResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
 // @Router /countries/{id} [get]
 func (h *CountryHandler) GetCountry(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	id, err := uuid.Parse(vars["id"])
 	if err != nil {
-		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		response.Error(w, r, er.BadRequest("invalid ID"))
 		return
 	}
 	country, err := h.usecase.GetCountry(r.Context(), id)
 	if err != nil {
 		if errors.Is(err, er.ErrRecordNotFound) {
-			http.Error(w, "Country not found", http.StatusNotFound)
-		} else {
-			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			err = er.NotFound("country not found")
 		}
+		response.Error(w, r, err)
 		return
 	}
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(country)
+	json.NewEncoder(w).Encode(dto.NewCountryResponse(country))
 }
 
 // CreateCountry godoc
@@ -59,23 +68,24 @@ func (h *CountryHandler) GetCountry(w http.ResponseWriter, r *http.Request) {
 // @Tags countries
 // @Accept  json
 // @Produce  json
-// @Param country body entity.Country true "Create country"
-// @Success 201 {object} entity.Country
-// @Failure 400 {object} map[string]string
-// @Router /countries [post]
+// @Param country body dto.CountryRequest true "Create country"
+// @Success 201 {object} dto.CountryResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Router /admin/countries [post]
 func (h *CountryHandler) CreateCountry(w http.ResponseWriter, r *http.Request) {
-	var country entity.Country
-	if err := json.NewDecoder(r.Body).Decode(&country); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+	var req dto.CountryRequest
+	if !decodeRequest(w, r, &req) {
 		return
 	}
-	if err := h.usecase.CreateCountry(r.Context(), &country); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+	country := req.ToEntity()
+	if err := h.usecase.CreateCountry(r.Context(), country); err != nil {
+		response.Error(w, r, err)
 		return
 	}
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
-	json.NewEncoder(w).Encode(country)
+	json.NewEncoder(w).Encode(dto.NewCountryResponse(country))
 }
 
 // UpdateCountry godoc
@@ -85,29 +95,37 @@ func (h *CountryHandler) CreateCountry(w http.ResponseWriter, r *http.Request) {
 // @Accept  json
 // @Produce  json
 // @Param id path string true "Country ID"
-// @Param country body entity.Country true "Update country"
-// @Success 200 {object} entity.Country
-// @Failure 400 {object} map[string]string
-// @Router /countries/{id} [put]
+// @Param country body dto.CountryRequest true "Update country"
+// @Success 200 {object} dto.CountryResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Router /admin/countries/{id} [put]
 func (h *CountryHandler) UpdateCountry(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	id, err := uuid.Parse(vars["id"])
 	if err != nil {
-		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		response.Error(w, r, er.BadRequest("invalid ID"))
+		return
+	}
+	var req dto.CountryRequest
+	if !decodeRequest(w, r, &req) {
 		return
 	}
-	var country entity.Country
-	if err := json.NewDecoder(r.Body).Decode(&country); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+	country, err := h.usecase.GetCountry(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, er.ErrRecordNotFound) {
+			err = er.NotFound("country not found")
+		}
+		response.Error(w, r, err)
 		return
 	}
-	country.ID = id
-	if err := h.usecase.UpdateCountry(r.Context(), &country); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+	req.ApplyTo(country)
+	if err := h.usecase.UpdateCountry(r.Context(), country); err != nil {
+		response.Error(w, r, err)
 		return
 	}
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(country)
+	json.NewEncoder(w).Encode(dto.NewCountryResponse(country))
 }
 
 // DeleteCountry godoc
@@ -118,23 +136,23 @@ func (h *CountryHandler) UpdateCountry(w http.ResponseWriter, r *http.Request) {
 // @Produce  json
 // @Param id path string true "Country ID"
 // @Success 204
-// @Failure 400 {object} map[string]string
-// @Failure 404 {object} map[string]string
-// @Failure 500 {object} map[string]string
-// @Router /countries/{id} [delete]
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Router /admin/countries/{id} [delete]
 func (h *CountryHandler) DeleteCountry(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	id, err := uuid.Parse(vars["id"])
 	if err != nil {
-		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		response.Error(w, r, er.BadRequest("invalid ID"))
 		return
 	}
 	if err := h.usecase.DeleteCountry(r.Context(), id); err != nil {
 		if errors.Is(err, er.ErrRecordNotFound) {
-			http.Error(w, "Country not found", http.StatusNotFound)
-		} else {
-			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			err = er.NotFound("country not found")
 		}
+		response.Error(w, r, err)
 		return
 	}
 	w.WriteHeader(http.StatusNoContent)
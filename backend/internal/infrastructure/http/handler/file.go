@@ -3,14 +3,22 @@ package handler
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/gorilla/mux"
 
 	"github.com/Vanv1k/BeautyTON/internal/domain/entity"
 	er "github.com/Vanv1k/BeautyTON/internal/domain/errors"
+	"github.com/Vanv1k/BeautyTON/internal/infrastructure/http/dto"
+	"github.com/Vanv1k/BeautyTON/internal/infrastructure/http/response"
 	"github.com/Vanv1k/BeautyTON/internal/usecase"
 )
 
@@ -22,75 +30,332 @@ func NewFileHandler(usecase *usecase.FileUsecase) *FileHandler {
 	return &FileHandler{usecase: usecase}
 }
 
+// publicFileMaxAge is how long, in seconds, shared caches may keep a photo.
+// A rejected photo disappears from caches within it.
+const publicFileMaxAge = 24 * 60 * 60
+
+// RegisterRoutes registers the file routes.
+func (h *FileHandler) RegisterRoutes(routes *Routes) {
+	routes.API.HandleFunc("/files/{id}", h.GetFile).Methods("GET", "OPTIONS")
+	routes.API.HandleFunc("/files/{id}/url", h.GetFileURL).Methods("GET", "OPTIONS")
+	routes.API.Handle("/files", routes.RateLimit(entity.RateLimitGroupUploads)(http.HandlerFunc(h.UploadFile))).Methods("POST", "OPTIONS")
+	routes.API.Handle("/files/presign", routes.RateLimit(entity.RateLimitGroupUploads)(http.HandlerFunc(h.PresignUpload))).Methods("POST", "OPTIONS")
+	routes.API.HandleFunc("/files/{id}/complete", h.CompleteUpload).Methods("POST", "OPTIONS")
+}
+
 // GetFile godoc
 // @Summary Get a file by ID
-// @Description Download file by file ID
+// @Description Download file by file ID. Photos are public, attachments are readable by their owner and admins only. A single byte range may be requested with Range, If-None-Match and If-Modified-Since make the request conditional
 // @Tags files
 // @Accept  json
 // @Produce  application/octet-stream
 // @Param id path string true "File ID"
+// @Param variant query string false "Photo size, the whole photo by default" Enums(thumb, card, full)
+// @Param Range header string false "Byte range, e.g. bytes=0-1023"
+// @Param If-None-Match header string false "ETag of a cached copy"
+// @Param If-Modified-Since header string false "Last-Modified of a cached copy"
 // @Success 200 {file} binary
-// @Failure 400 {object} map[string]string
-// @Failure 404 {object} map[string]string
-// @Failure 500 {object} map[string]string
+// @Success 206 {file} binary
+// @Success 304 "Not modified"
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 416 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
 // @Router /files/{id} [get]
 func (h *FileHandler) GetFile(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	id := vars["id"]
 	if id == "" {
-		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		response.Error(w, r, er.BadRequest("invalid ID"))
 		return
 	}
-	file, content, err := h.usecase.GetFile(r.Context(), id)
+	variant, ok := parseVariant(w, r)
+	if !ok {
+		return
+	}
+	// Условия проверяются до чтения: 304 не скачивает файл, а If-None-Match
+	// важнее недопустимого Range
+	userID, role := currentUser(r)
+	file, err := h.usecase.StatFile(r.Context(), userID, role, id, variant)
 	if err != nil {
 		if errors.Is(err, er.ErrRecordNotFound) {
-			http.Error(w, "File not found", http.StatusNotFound)
-		} else {
-			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			err = er.NotFound("file not found")
 		}
+		response.Error(w, r, err)
 		return
 	}
-	w.Header().Set("Content-Type", file.MimeType)
-	w.Header().Set("Content-Length", string(file.Size))
-	_, err = io.Copy(w, content)
+
+	header := w.Header()
+	setFileValidators(header, file)
+	// Фото публичны и кешируются общими кешами, вложения только клиентом
+	// с проверкой версии
+	if file.Purpose.Public() {
+		header.Set("Cache-Control", "public, max-age="+strconv.Itoa(publicFileMaxAge))
+	} else {
+		header.Set("Cache-Control", "private, no-cache")
+	}
+	if notModified(r, file) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	rng := parseRange(r)
+	if rng != nil {
+		if _, ok := rng.Resolve(file.Size); !ok {
+			rangeNotSatisfiable(w, r, file.Size)
+			return
+		}
+	}
+	stored, content, err := h.usecase.ReadFile(r.Context(), file, variant, rng)
 	if err != nil {
-		http.Error(w, "Failed to stream file", http.StatusInternalServerError)
+		switch {
+		case errors.Is(err, er.ErrRangeNotSatisfiable):
+			// Файл заменили меньшим после Stat
+			rangeNotSatisfiable(w, r, file.Size)
+			return
+		case errors.Is(err, er.ErrRecordNotFound):
+			err = er.NotFound("file not found")
+		}
+		response.Error(w, r, err)
 		return
 	}
+	defer content.Close()
+
+	setFileValidators(header, stored)
+	header.Set("Content-Type", stored.MimeType)
+	header.Set("Accept-Ranges", "bytes")
+	status, length := http.StatusOK, stored.Size
+	if stored.Range != nil {
+		header.Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", stored.Range.Start, stored.Range.End, stored.Size))
+		status, length = http.StatusPartialContent, stored.Range.Length()
+	}
+	header.Set("Content-Length", strconv.FormatInt(length, 10))
+	w.WriteHeader(status)
+	// Статус уже отправлен, обрыв виден клиенту по недостающим байтам
+	if _, err := io.Copy(w, content); err != nil {
+		slog.WarnContext(r.Context(), "failed to stream file", "file_id", stored.ID, "error", err)
+	}
+}
+
+// GetFileURL godoc
+// @Summary Get a download URL of a file
+// @Description Get a presigned URL downloading the file from the storage directly, with the same access rules as downloading it through the API
+// @Tags files
+// @Produce  json
+// @Param id path string true "File ID"
+// @Param variant query string false "Photo size, the whole photo by default" Enums(thumb, card, full)
+// @Success 200 {object} dto.FileURLResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 501 {object} response.ErrorResponse
+// @Router /files/{id}/url [get]
+func (h *FileHandler) GetFileURL(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	variant, ok := parseVariant(w, r)
+	if !ok {
+		return
+	}
+	userID, role := currentUser(r)
+	request, err := h.usecase.GetFileURL(r.Context(), userID, role, vars["id"], variant)
+	if err != nil {
+		if errors.Is(err, er.ErrRecordNotFound) {
+			err = er.NotFound("file not found")
+		}
+		response.Error(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(dto.FileURLResponse{URL: request.URL, ExpiresAt: request.ExpiresAt})
 }
 
 // UploadFile godoc
 // @Summary Upload a file
-// @Description Upload a file to the server
+// @Description Upload a private attachment, readable by its owner and admins only
 // @Tags files
 // @Accept  multipart/form-data
 // @Produce  json
 // @Param file formData file true "File to upload"
-// @Success 200 {object} map[string]string
-// @Failure 400 {object} map[string]string
+// @Success 200 {object} dto.FileUploadResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 429 {object} response.ErrorResponse
 // @Router /files [post]
 func (h *FileHandler) UploadFile(w http.ResponseWriter, r *http.Request) {
 	if err := r.ParseMultipartForm(10 << 20); err != nil { // 10 MB limit
-		http.Error(w, "File too large or invalid form", http.StatusBadRequest)
+		response.Error(w, r, er.BadRequest("file too large or invalid form"))
 		return
 	}
 	file, header, err := r.FormFile("file")
 	if err != nil {
-		http.Error(w, "Invalid file", http.StatusBadRequest)
+		response.Error(w, r, er.BadRequest("invalid file"))
 		return
 	}
 	defer file.Close()
+	mimeType, err := sniffContentType(file)
+	if err != nil {
+		response.Error(w, r, er.BadRequest("invalid file"))
+		return
+	}
+	ownerID, _ := currentUser(r)
 	fileEntity := &entity.File{
 		ID:       uuid.New().String(),
 		Name:     header.Filename,
+		OwnerID:  ownerID,
 		Size:     header.Size,
-		MimeType: header.Header.Get("Content-Type"),
+		MimeType: mimeType,
 	}
 	if err := h.usecase.UploadFile(r.Context(), fileEntity, file); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		response.Error(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(dto.FileUploadResponse{FileID: fileEntity.ID})
+}
+
+// PresignUpload godoc
+// @Summary Presign a file upload
+// @Description Register a private attachment and get a presigned request uploading it to the storage directly. The storage accepts only the declared size, type and checksum. The file is kept once the upload is completed
+// @Tags files
+// @Accept  json
+// @Produce  json
+// @Param file body dto.PresignUploadRequest true "File to upload"
+// @Success 200 {object} dto.PresignUploadResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 429 {object} response.ErrorResponse
+// @Failure 501 {object} response.ErrorResponse
+// @Router /files/presign [post]
+func (h *FileHandler) PresignUpload(w http.ResponseWriter, r *http.Request) {
+	var req dto.PresignUploadRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+	ownerID, _ := currentUser(r)
+	file := req.ToEntity(ownerID)
+	request, err := h.usecase.PresignUpload(r.Context(), file)
+	if err != nil {
+		response.Error(w, r, err)
 		return
 	}
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(map[string]string{"file_id": fileEntity.ID})
+	json.NewEncoder(w).Encode(dto.NewPresignUploadResponse(file.ID, request))
+}
+
+// CompleteUpload godoc
+// @Summary Complete a file upload
+// @Description Verify a presigned upload against its declaration and keep the file
+// @Tags files
+// @Produce  json
+// @Param id path string true "File ID"
+// @Success 200 {object} dto.FileUploadResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 409 {object} response.ErrorResponse
+// @Failure 422 {object} response.ErrorResponse
+// @Router /files/{id}/complete [post]
+func (h *FileHandler) CompleteUpload(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	ownerID, _ := currentUser(r)
+	file, err := h.usecase.CompleteUpload(r.Context(), ownerID, vars["id"])
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(dto.FileUploadResponse{FileID: file.ID})
+}
+
+// parseRange reads a single byte range from the Range header. Other ranges,
+// including several at once, are ignored and the whole file is served, as
+// HTTP allows. So is a range made conditional with If-Range, which would
+// need the version of the file before it is read.
+func parseRange(r *http.Request) *entity.ByteRange {
+	header := r.Header.Get("Range")
+	if header == "" || r.Header.Get("If-Range") != "" {
+		return nil
+	}
+	spec, ok := strings.CutPrefix(header, "bytes=")
+	if !ok || strings.Contains(spec, ",") {
+		return nil
+	}
+	first, last, ok := strings.Cut(strings.TrimSpace(spec), "-")
+	if !ok {
+		return nil
+	}
+	if first == "" {
+		// Последние n байт
+		n, err := strconv.ParseInt(last, 10, 64)
+		if err != nil || n <= 0 {
+			return nil
+		}
+		return &entity.ByteRange{Start: -n, End: -1}
+	}
+	start, err := strconv.ParseInt(first, 10, 64)
+	if err != nil || start < 0 {
+		return nil
+	}
+	end := int64(-1)
+	if last != "" {
+		end, err = strconv.ParseInt(last, 10, 64)
+		if err != nil || end < start {
+			return nil
+		}
+	}
+	return &entity.ByteRange{Start: start, End: end}
+}
+
+// setFileValidators sets the headers a cached copy of the file is
+// validated with.
+func setFileValidators(header http.Header, file *entity.File) {
+	if file.ETag != "" {
+		header.Set("ETag", file.ETag)
+	}
+	if !file.ModifiedAt.IsZero() {
+		header.Set("Last-Modified", file.ModifiedAt.UTC().Format(http.TimeFormat))
+	}
+}
+
+// rangeNotSatisfiable writes a 416 response telling the size of the file,
+// as RFC 9110 requires.
+func rangeNotSatisfiable(w http.ResponseWriter, r *http.Request, size int64) {
+	w.Header().Set("Content-Range", "bytes */"+strconv.FormatInt(size, 10))
+	response.Error(w, r, er.ErrRangeNotSatisfiable)
+}
+
+// notModified evaluates If-None-Match, or If-Modified-Since without it,
+// against the version of the file.
+func notModified(r *http.Request, file *entity.File) bool {
+	if header := r.Header.Get("If-None-Match"); header != "" {
+		if file.ETag == "" {
+			return false
+		}
+		// Слабое сравнение, как требует RFC 9110 для If-None-Match
+		etag := strings.TrimPrefix(file.ETag, "W/")
+		for _, tag := range strings.Split(header, ",") {
+			tag = strings.TrimSpace(tag)
+			if tag == "*" || strings.TrimPrefix(tag, "W/") == etag {
+				return true
+			}
+		}
+		return false
+	}
+	since, err := http.ParseTime(r.Header.Get("If-Modified-Since"))
+	if err != nil || file.ModifiedAt.IsZero() {
+		return false
+	}
+	return !file.ModifiedAt.Truncate(time.Second).After(since)
+}
+
+// parseVariant reads the requested size of a photo, the full one by
+// default.
+func parseVariant(w http.ResponseWriter, r *http.Request) (entity.ImageVariant, bool) {
+	variant := entity.ImageVariant(r.URL.Query().Get("variant"))
+	if variant == "" {
+		variant = entity.ImageVariantFull
+	}
+	if !slices.Contains(entity.ImageVariants, variant) {
+		response.Error(w, r, er.BadRequest("invalid variant"))
+		return "", false
+	}
+	return variant, true
 }
@@ -0,0 +1,105 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+
+	"github.com/Vanv1k/BeautyTON/internal/domain/entity"
+	er "github.com/Vanv1k/BeautyTON/internal/domain/errors"
+	"github.com/Vanv1k/BeautyTON/internal/domain/repository"
+	"github.com/Vanv1k/BeautyTON/internal/infrastructure/storage/memory"
+	"github.com/Vanv1k/BeautyTON/internal/usecase"
+)
+
+type fakeFileMetadataRepo struct {
+	repository.FileMetadataRepository
+	files map[string]*entity.File
+}
+
+func (r *fakeFileMetadataRepo) GetByID(ctx context.Context, id string) (*entity.File, error) {
+	if file, ok := r.files[id]; ok {
+		copied := *file
+		return &copied, nil
+	}
+	return nil, er.ErrRecordNotFound
+}
+
+// countingFileRepo counts the reads of file contents.
+type countingFileRepo struct {
+	repository.FileRepository
+	gets int
+}
+
+func (r *countingFileRepo) Get(ctx context.Context, id string, rng *entity.ByteRange) (*entity.File, io.ReadCloser, error) {
+	r.gets++
+	return r.FileRepository.Get(ctx, id, rng)
+}
+
+func TestGetFileConditionsAndRanges(t *testing.T) {
+	storage := &countingFileRepo{FileRepository: memory.NewFileRepository()}
+	file := &entity.File{ID: uuid.NewString(), OwnerID: uuid.New(), MimeType: "text/plain", Purpose: entity.FilePurposeAttachment}
+	if err := storage.Upload(context.Background(), file, bytes.NewReader([]byte("0123456789"))); err != nil {
+		t.Fatalf("upload: %v", err)
+	}
+	stat, err := storage.Stat(context.Background(), file.ID)
+	if err != nil {
+		t.Fatalf("stat: %v", err)
+	}
+	metadata := &fakeFileMetadataRepo{files: map[string]*entity.File{file.ID: file}}
+	files := usecase.NewFileStore(storage, metadata, nil, "", time.Minute, time.Minute)
+	h := NewFileHandler(usecase.NewFileUsecase(files, time.Hour))
+
+	tests := []struct {
+		name         string
+		headers      map[string]string
+		want         int
+		body         string
+		contentRange string
+		reads        int
+	}{
+		{name: "whole file", want: http.StatusOK, body: "0123456789", reads: 1},
+		{name: "range", headers: map[string]string{"Range": "bytes=2-4"}, want: http.StatusPartialContent, body: "234", contentRange: "bytes 2-4/10", reads: 1},
+		{name: "suffix range", headers: map[string]string{"Range": "bytes=-3"}, want: http.StatusPartialContent, body: "789", contentRange: "bytes 7-9/10", reads: 1},
+		{name: "unsatisfiable range", headers: map[string]string{"Range": "bytes=10-"}, want: http.StatusRequestedRangeNotSatisfiable, contentRange: "bytes */10"},
+		{name: "matching If-None-Match", headers: map[string]string{"If-None-Match": stat.ETag}, want: http.StatusNotModified},
+		{name: "If-None-Match before an unsatisfiable range", headers: map[string]string{"If-None-Match": stat.ETag, "Range": "bytes=10-"}, want: http.StatusNotModified},
+		{name: "stale If-None-Match", headers: map[string]string{"If-None-Match": `"other"`}, want: http.StatusOK, body: "0123456789", reads: 1},
+		{name: "If-Modified-Since", headers: map[string]string{"If-Modified-Since": stat.ModifiedAt.Add(time.Second).UTC().Format(http.TimeFormat)}, want: http.StatusNotModified},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storage.gets = 0
+			r := authenticatedRequest("GET", "/files/"+file.ID, "", file.OwnerID, entity.UserRoleClient)
+			r = mux.SetURLVars(r, map[string]string{"id": file.ID})
+			for name, value := range tt.headers {
+				r.Header.Set(name, value)
+			}
+			w := httptest.NewRecorder()
+			h.GetFile(w, r)
+
+			if w.Code != tt.want {
+				t.Fatalf("got %d %s, want %d", w.Code, w.Body, tt.want)
+			}
+			if tt.body != "" && w.Body.String() != tt.body {
+				t.Fatalf("body is %q, want %q", w.Body, tt.body)
+			}
+			if got := w.Header().Get("Content-Range"); got != tt.contentRange {
+				t.Fatalf("Content-Range is %q, want %q", got, tt.contentRange)
+			}
+			if w.Header().Get("ETag") != stat.ETag {
+				t.Fatalf("ETag is %q, want %q", w.Header().Get("ETag"), stat.ETag)
+			}
+			if storage.gets != tt.reads {
+				t.Fatalf("content read %d times, want %d", storage.gets, tt.reads)
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Vanv1k/BeautyTON/internal/infrastructure/http/dto"
+)
+
+// readinessCheckTimeout bounds each dependency check, so that a hanging
+// dependency fails the probe instead of timing it out.
+const readinessCheckTimeout = 2 * time.Second
+
+// HealthCheck checks that a dependency needed to serve requests is reachable.
+type HealthCheck struct {
+	Name  string
+	Check func(ctx context.Context) error
+}
+
+type HealthHandler struct {
+	checks   []HealthCheck
+	draining atomic.Bool
+}
+
+func NewHealthHandler(checks ...HealthCheck) *HealthHandler {
+	return &HealthHandler{checks: checks}
+}
+
+// SetDraining makes readiness fail, so that the load balancer stops sending
+// traffic before the server shuts down.
+func (h *HealthHandler) SetDraining() {
+	h.draining.Store(true)
+}
+
+// Healthz godoc
+// @Summary Liveness probe
+// @Description Reports that the process is running. Dependencies are not checked
+// @Tags health
+// @Produce  json
+// @Success 200 {object} dto.HealthResponse
+// @Router /healthz [get]
+func (h *HealthHandler) Healthz(w http.ResponseWriter, r *http.Request) {
+	writeHealth(w, http.StatusOK, dto.HealthResponse{Status: "ok"})
+}
+
+// Readyz godoc
+// @Summary Readiness probe
+// @Description Reports whether the server can handle requests: Postgres and the file storage are reachable and the server is not shutting down
+// @Tags health
+// @Produce  json
+// @Success 200 {object} dto.HealthResponse
+// @Failure 503 {object} dto.HealthResponse
+// @Router /readyz [get]
+func (h *HealthHandler) Readyz(w http.ResponseWriter, r *http.Request) {
+	if h.draining.Load() {
+		writeHealth(w, http.StatusServiceUnavailable, dto.HealthResponse{Status: "draining"})
+		return
+	}
+
+	results := make([]error, len(h.checks))
+	var wg sync.WaitGroup
+	for i, check := range h.checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(r.Context(), readinessCheckTimeout)
+			defer cancel()
+			results[i] = check.Check(ctx)
+		}()
+	}
+	wg.Wait()
+
+	status, resp := http.StatusOK, dto.HealthResponse{Status: "ok", Checks: map[string]string{}}
+	for i, check := range h.checks {
+		if err := results[i]; err != nil {
+			// Подробности ошибки только в логах, проба доступна без авторизации
+			slog.WarnContext(r.Context(), "readiness check failed", "check", check.Name, "error", err)
+			status, resp.Status = http.StatusServiceUnavailable, "unavailable"
+			resp.Checks[check.Name] = "failed"
+			continue
+		}
+		resp.Checks[check.Name] = "ok"
+	}
+	writeHealth(w, status, resp)
+}
+
+func writeHealth(w http.ResponseWriter, status int, resp dto.HealthResponse) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(resp)
+}
@@ -11,6 +11,8 @@ import (
 
 	"github.com/Vanv1k/BeautyTON/internal/domain/entity"
 	er "github.com/Vanv1k/BeautyTON/internal/domain/errors"
+	"github.com/Vanv1k/BeautyTON/internal/infrastructure/http/dto"
+	"github.com/Vanv1k/BeautyTON/internal/infrastructure/http/response"
 	"github.com/Vanv1k/BeautyTON/internal/usecase"
 )
 
@@ -22,6 +24,18 @@ func NewMasterProfileHandler(usecase *usecase.MasterProfileUsecase) *MasterProfi
 	return &MasterProfileHandler{usecase: usecase}
 }
 
+// RegisterRoutes registers the master profile routes.
+func (h *MasterProfileHandler) RegisterRoutes(routes *Routes) {
+	routes.API.HandleFunc("/master_profiles/nearby", h.ListNearbyProfiles).Methods("GET", "OPTIONS")
+	routes.API.HandleFunc("/master_profiles/{id}", h.GetMasterProfile).Methods("GET", "OPTIONS")
+	routes.API.HandleFunc("/master_profiles", h.ListProfiles).Methods("GET", "OPTIONS")
+	routes.API.HandleFunc("/master_profiles", h.CreateMasterProfile).Methods("POST", "OPTIONS")
+	routes.API.HandleFunc("/master_profiles/{id}", h.UpdateMasterProfile).Methods("PUT", "OPTIONS")
+	routes.API.HandleFunc("/master_profiles/{id}", h.PatchMasterProfile).Methods("PATCH", "OPTIONS")
+	routes.API.HandleFunc("/master_profiles/{id}", h.DeleteMasterProfile).Methods("DELETE", "OPTIONS")
+	routes.Admin.HandleFunc("/master_profiles/{id}/rating", h.UpdateMasterProfileRating).Methods("PUT", "OPTIONS")
+}
+
 // GetMasterProfile godoc
 // @Summary Get a master profile by ID
 // @Description Get master profile details by master profile ID
@@ -29,57 +43,56 @@ func NewMasterProfileHandler(usecase *usecase.MasterProfileUsecase) *MasterProfi
 // @Accept  json
 // @Produce  json
 // @Param id path string true "Master Profile ID"
-// @Success 200 {object} entity.MasterProfile
-// @Failure 400 {object} map[string]string
-// @Failure 404 {object} map[string]string
-// @Failure 500 {object} map[string]string
+// @Success 200 {object} dto.MasterProfileResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
 // @Router /master_profiles/{id} [get]
 func (h *MasterProfileHandler) GetMasterProfile(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	id, err := uuid.Parse(vars["id"])
 	if err != nil {
-		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		response.Error(w, r, er.BadRequest("invalid ID"))
 		return
 	}
 	profile, err := h.usecase.GetMasterProfile(r.Context(), id)
 	if err != nil {
 		if errors.Is(err, er.ErrRecordNotFound) {
-			http.Error(w, "Master profile not found", http.StatusNotFound)
-		} else {
-			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			err = er.NotFound("master profile not found")
 		}
+		response.Error(w, r, err)
 		return
 	}
+	setETag(w, profile.Version)
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(profile)
+	json.NewEncoder(w).Encode(dto.NewMasterProfileResponse(profile))
 }
 
 // CreateMasterProfile godoc
 // @Summary Create a new master profile
-// @Description Create a new master profile with the input payload
+// @Description Create a master profile of the current user, who must be a master
 // @Tags master-profiles
 // @Accept  json
 // @Produce  json
-// @Param profile body entity.MasterProfile true "Create master profile"
-// @Success 201 {object} entity.MasterProfile
-// @Failure 400 {object} map[string]string
+// @Param profile body dto.CreateMasterProfileRequest true "Create master profile"
+// @Success 201 {object} dto.MasterProfileResponse
+// @Failure 400 {object} response.ErrorResponse
 // @Router /master_profiles [post]
 func (h *MasterProfileHandler) CreateMasterProfile(w http.ResponseWriter, r *http.Request) {
-	var profile entity.MasterProfile
-	if err := json.NewDecoder(r.Body).Decode(&profile); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+	var req dto.CreateMasterProfileRequest
+	if !decodeRequest(w, r, &req) {
 		return
 	}
-	if err := h.usecase.CreateMasterProfile(r.Context(), &profile); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+	userID, _ := currentUser(r)
+	profile := req.ToEntity(userID)
+	if err := h.usecase.CreateMasterProfile(r.Context(), profile); err != nil {
+		response.Error(w, r, err)
 		return
 	}
+	setETag(w, profile.Version)
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
-	response := map[string]interface{}{
-		"results": profile,
-	}
-	json.NewEncoder(w).Encode(response)
+	json.NewEncoder(w).Encode(dto.NewMasterProfileResponse(profile))
 }
 
 // UpdateMasterProfile godoc
@@ -89,63 +102,123 @@ func (h *MasterProfileHandler) CreateMasterProfile(w http.ResponseWriter, r *htt
 // @Accept  json
 // @Produce  json
 // @Param id path string true "Master Profile ID"
-// @Param profile body entity.MasterProfile true "Update master profile"
-// @Success 200 {object} entity.MasterProfile
-// @Failure 400 {object} map[string]string
+// @Param profile body dto.UpdateMasterProfileRequest true "Update master profile"
+// @Param If-Match header string true "ETag of the version being updated"
+// @Success 200 {object} dto.MasterProfileResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 412 {object} response.ErrorResponse
+// @Failure 428 {object} response.ErrorResponse
 // @Router /master_profiles/{id} [put]
 func (h *MasterProfileHandler) UpdateMasterProfile(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	id, err := uuid.Parse(vars["id"])
 	if err != nil {
-		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		response.Error(w, r, er.BadRequest("invalid ID"))
+		return
+	}
+	var req dto.UpdateMasterProfileRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+	profile, err := h.usecase.GetMasterProfile(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, er.ErrRecordNotFound) {
+			err = er.NotFound("master profile not found")
+		}
+		response.Error(w, r, err)
 		return
 	}
-	var profile entity.MasterProfile
-	if err := json.NewDecoder(r.Body).Decode(&profile); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+	if !checkIfMatch(w, r, profile.Version) {
 		return
 	}
-	profile.ID = id
-	if err := h.usecase.UpdateMasterProfile(r.Context(), &profile); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+	req.ApplyTo(profile)
+	if err := h.usecase.UpdateMasterProfile(r.Context(), profile); err != nil {
+		response.Error(w, r, err)
 		return
 	}
+	setETag(w, profile.Version)
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(profile)
+	json.NewEncoder(w).Encode(dto.NewMasterProfileResponse(profile))
+}
+
+// PatchMasterProfile godoc
+// @Summary Partially update a master profile
+// @Description Apply a JSON Merge Patch (RFC 7386) to a master profile by master profile ID. Members set to null are cleared
+// @Tags master-profiles
+// @Accept  json
+// @Accept  application/merge-patch+json
+// @Produce  json
+// @Param id path string true "Master Profile ID"
+// @Param profile body dto.UpdateMasterProfileRequest true "Fields to change"
+// @Param If-Match header string true "ETag of the version being updated"
+// @Success 200 {object} dto.MasterProfileResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 412 {object} response.ErrorResponse
+// @Failure 428 {object} response.ErrorResponse
+// @Router /master_profiles/{id} [patch]
+func (h *MasterProfileHandler) PatchMasterProfile(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := uuid.Parse(vars["id"])
+	if err != nil {
+		response.Error(w, r, er.BadRequest("invalid ID"))
+		return
+	}
+	profile, err := h.usecase.GetMasterProfile(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, er.ErrRecordNotFound) {
+			err = er.NotFound("master profile not found")
+		}
+		response.Error(w, r, err)
+		return
+	}
+	if !checkIfMatch(w, r, profile.Version) {
+		return
+	}
+	req := dto.NewUpdateMasterProfileRequest(profile)
+	if !decodePatch(w, r, &req) {
+		return
+	}
+	req.ApplyTo(profile)
+	if err := h.usecase.UpdateMasterProfile(r.Context(), profile); err != nil {
+		response.Error(w, r, err)
+		return
+	}
+	setETag(w, profile.Version)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(dto.NewMasterProfileResponse(profile))
 }
 
 // UpdateMasterProfileRating godoc
 // @Summary Update master profile rating
-// @Description Update master profile rating by master profile ID
+// @Description Set the rating of a master profile by master profile ID. Admin only
 // @Tags master-profiles
 // @Accept  json
 // @Produce  json
 // @Param id path string true "Master Profile ID"
-// @Param rating body object{rating=number} true "Rating value"
-// @Success 200 {object} entity.MasterProfile
-// @Failure 400 {object} map[string]string
-// @Router /master_profiles/{id}/rating [put]
+// @Param rating body dto.UpdateMasterProfileRatingRequest true "Rating value"
+// @Success 200 {object} dto.MasterProfileResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Router /admin/master_profiles/{id}/rating [put]
 func (h *MasterProfileHandler) UpdateMasterProfileRating(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	id, err := uuid.Parse(vars["id"])
 	if err != nil {
-		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		response.Error(w, r, er.BadRequest("invalid ID"))
 		return
 	}
-	var input struct {
-		Rating float64 `json:"rating"`
-	}
-	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+	var req dto.UpdateMasterProfileRatingRequest
+	if !decodeRequest(w, r, &req) {
 		return
 	}
-	profile, err := h.usecase.UpdateMasterProfileRating(r.Context(), id, input.Rating)
+	profile, err := h.usecase.UpdateMasterProfileRating(r.Context(), id, req.Rating)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		response.Error(w, r, err)
 		return
 	}
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(profile)
+	json.NewEncoder(w).Encode(dto.NewMasterProfileResponse(profile))
 }
 
 // DeleteMasterProfile godoc
@@ -156,23 +229,22 @@ func (h *MasterProfileHandler) UpdateMasterProfileRating(w http.ResponseWriter,
 // @Produce  json
 // @Param id path string true "Master Profile ID"
 // @Success 204
-// @Failure 400 {object} map[string]string
-// @Failure 404 {object} map[string]string
-// @Failure 500 {object} map[string]string
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
 // @Router /master_profiles/{id} [delete]
 func (h *MasterProfileHandler) DeleteMasterProfile(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	id, err := uuid.Parse(vars["id"])
 	if err != nil {
-		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		response.Error(w, r, er.BadRequest("invalid ID"))
 		return
 	}
 	if err := h.usecase.DeleteMasterProfile(r.Context(), id); err != nil {
 		if errors.Is(err, er.ErrRecordNotFound) {
-			http.Error(w, "Master profile not found", http.StatusNotFound)
-		} else {
-			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			err = er.NotFound("master profile not found")
 		}
+		response.Error(w, r, err)
 		return
 	}
 	w.WriteHeader(http.StatusNoContent)
@@ -180,30 +252,42 @@ func (h *MasterProfileHandler) DeleteMasterProfile(w http.ResponseWriter, r *htt
 
 // ListProfiles godoc
 // @Summary List profiles with pagination and filters
-// @Description Retrieve a paginated list of master profiles with optional filters
+// @Description Retrieve a list of master profiles with optional filters. Pages are addressed either by page number or by the opaque cursor returned in next_cursor, which stays stable when new profiles are added. Promoted masters take slots within the first page and are left out of the pages that follow it by cursor
 // @Tags master-profiles
 // @Accept  json
 // @Produce  json
-// @Param query query string false "Search by profile name (partial match)"
+// @Param query query string false "Full-text search over username, bio, services and categories (typo tolerant)"
 // @Param category query string false "Filter by category (e.g., hairdresser, nail_technician)"
 // @Param city query string false "Filter by city name (partial match)"
 // @Param priceFrom query int false "Minimum service price"
 // @Param priceTo query int false "Maximum service price"
 // @Param rating query float64 false "Minimum profile rating (0 to 5)"
+// @Param lookingForModels query bool false "Only masters looking for models (open model offer or free model slot)"
+// @Param sort query string false "Sort order: relevance (default with query), rating (default), reviews, price_asc, price_desc, distance, newest, availability"
+// @Param lat query number false "Latitude of the client, required for distance sort"
+// @Param lon query number false "Longitude of the client, required for distance sort"
+// @Param cursor query string false "Cursor from next_cursor of the previous page, valid only with the same query, filters, sort and location; page is ignored when set"
 // @Param page query int false "Page number (default: 1)"
 // @Param page_size query int false "Number of profiles per page (default: 10, max: 100)"
-// @Success 200 {object} map[string]interface{} "Paginated list of profiles with metadata"
-// @Failure 400 {object} map[string]string
-// @Failure 500 {object} map[string]string
-// @Router /master-profiles [get]
+// @Success 200 {object} dto.MasterProfileListResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /master_profiles [get]
 func (h *MasterProfileHandler) ListProfiles(w http.ResponseWriter, r *http.Request) {
 	// Parse query parameters
-	query := r.URL.Query().Get("query")
-	category := r.URL.Query().Get("category")
-	city := r.URL.Query().Get("city")
+	filter := entity.MasterProfileFilter{
+		Query:    r.URL.Query().Get("query"),
+		Category: r.URL.Query().Get("category"),
+		City:     r.URL.Query().Get("city"),
+		Sort:     entity.MasterProfileSort(r.URL.Query().Get("sort")),
+	}
+	cursor := r.URL.Query().Get("cursor")
 	priceFromStr := r.URL.Query().Get("priceFrom")
 	priceToStr := r.URL.Query().Get("priceTo")
 	ratingStr := r.URL.Query().Get("rating")
+	lookingForModelsStr := r.URL.Query().Get("lookingForModels")
+	latStr := r.URL.Query().Get("lat")
+	lonStr := r.URL.Query().Get("lon")
 	pageStr := r.URL.Query().Get("page")
 	pageSizeStr := r.URL.Query().Get("page_size")
 
@@ -211,16 +295,13 @@ func (h *MasterProfileHandler) ListProfiles(w http.ResponseWriter, r *http.Reque
 	page := 1
 	pageSize := 10
 	maxPageSize := 100
-	priceFrom := 0
-	priceTo := 0
-	rating := 0.0
 
 	// Parse and validate page
 	if pageStr != "" {
 		var err error
 		page, err = strconv.Atoi(pageStr)
 		if err != nil || page < 1 {
-			http.Error(w, "Invalid page number", http.StatusBadRequest)
+			response.Error(w, r, er.BadRequest("invalid page number"))
 			return
 		}
 	}
@@ -230,7 +311,7 @@ func (h *MasterProfileHandler) ListProfiles(w http.ResponseWriter, r *http.Reque
 		var err error
 		pageSize, err = strconv.Atoi(pageSizeStr)
 		if err != nil || pageSize < 1 {
-			http.Error(w, "Invalid page size", http.StatusBadRequest)
+			response.Error(w, r, er.BadRequest("invalid page size"))
 			return
 		}
 		if pageSize > maxPageSize {
@@ -241,9 +322,9 @@ func (h *MasterProfileHandler) ListProfiles(w http.ResponseWriter, r *http.Reque
 	// Parse and validate priceFrom
 	if priceFromStr != "" {
 		var err error
-		priceFrom, err = strconv.Atoi(priceFromStr)
-		if err != nil || priceFrom < 0 {
-			http.Error(w, "Invalid priceFrom", http.StatusBadRequest)
+		filter.PriceFrom, err = strconv.Atoi(priceFromStr)
+		if err != nil || filter.PriceFrom < 0 {
+			response.Error(w, r, er.BadRequest("invalid priceFrom"))
 			return
 		}
 	}
@@ -251,9 +332,9 @@ func (h *MasterProfileHandler) ListProfiles(w http.ResponseWriter, r *http.Reque
 	// Parse and validate priceTo
 	if priceToStr != "" {
 		var err error
-		priceTo, err = strconv.Atoi(priceToStr)
-		if err != nil || priceTo < 0 {
-			http.Error(w, "Invalid priceTo", http.StatusBadRequest)
+		filter.PriceTo, err = strconv.Atoi(priceToStr)
+		if err != nil || filter.PriceTo < 0 {
+			response.Error(w, r, er.BadRequest("invalid priceTo"))
 			return
 		}
 	}
@@ -261,17 +342,45 @@ func (h *MasterProfileHandler) ListProfiles(w http.ResponseWriter, r *http.Reque
 	// Parse and validate rating
 	if ratingStr != "" {
 		var err error
-		rating, err = strconv.ParseFloat(ratingStr, 64)
-		if err != nil || rating < 0 || rating > 5 {
-			http.Error(w, "Invalid rating (must be between 0 and 5)", http.StatusBadRequest)
+		filter.Rating, err = strconv.ParseFloat(ratingStr, 64)
+		if err != nil || filter.Rating < 0 || filter.Rating > 5 {
+			response.Error(w, r, er.BadRequest("invalid rating (must be between 0 and 5)"))
 			return
 		}
 	}
 
+	// Parse and validate lookingForModels
+	if lookingForModelsStr != "" {
+		var err error
+		filter.LookingForModels, err = strconv.ParseBool(lookingForModelsStr)
+		if err != nil {
+			response.Error(w, r, er.BadRequest("invalid lookingForModels"))
+			return
+		}
+	}
+
+	// Parse client location
+	if latStr != "" {
+		lat, err := strconv.ParseFloat(latStr, 64)
+		if err != nil {
+			response.Error(w, r, er.BadRequest("invalid lat"))
+			return
+		}
+		filter.Lat = &lat
+	}
+	if lonStr != "" {
+		lon, err := strconv.ParseFloat(lonStr, 64)
+		if err != nil {
+			response.Error(w, r, er.BadRequest("invalid lon"))
+			return
+		}
+		filter.Lon = &lon
+	}
+
 	// Fetch paginated profiles
-	profiles, total, err := h.usecase.List(r.Context(), query, category, city, priceFrom, priceTo, rating, page, pageSize)
+	profiles, total, nextCursor, err := h.usecase.List(r.Context(), filter, cursor, page, pageSize)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		response.Error(w, r, err)
 		return
 	}
 
@@ -279,15 +388,87 @@ func (h *MasterProfileHandler) ListProfiles(w http.ResponseWriter, r *http.Reque
 	totalPages := int((total + int64(pageSize) - 1) / int64(pageSize))
 
 	// Prepare response
-	response := map[string]interface{}{
-		"results":     profiles,
-		"page":        page,
-		"page_size":   pageSize,
-		"total":       total,
-		"total_pages": totalPages,
+	result := dto.MasterProfileListResponse{
+		Results:    dto.NewCatalogMasterProfileResponses(profiles),
+		Page:       page,
+		PageSize:   pageSize,
+		Total:      total,
+		TotalPages: totalPages,
+		NextCursor: nextCursor,
+		HasMore:    nextCursor != "",
 	}
 
 	// Send response
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(response)
+	json.NewEncoder(w).Encode(result)
+}
+
+// ListNearbyProfiles godoc
+// @Summary List master profiles near a location
+// @Description Retrieve master profiles within the given radius sorted by distance
+// @Tags master-profiles
+// @Accept  json
+// @Produce  json
+// @Param lat query number true "Latitude of the search point"
+// @Param lon query number true "Longitude of the search point"
+// @Param radius_km query number false "Search radius in kilometers (default: 10, max: 100)"
+// @Param exclude_id query string false "Master profile ID to exclude from results"
+// @Param limit query int false "Maximum number of profiles (default: 10, max: 100)"
+// @Success 200 {object} dto.NearbyMasterProfileListResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /master_profiles/nearby [get]
+func (h *MasterProfileHandler) ListNearbyProfiles(w http.ResponseWriter, r *http.Request) {
+	// Parse query parameters
+	lat, err := strconv.ParseFloat(r.URL.Query().Get("lat"), 64)
+	if err != nil {
+		response.Error(w, r, er.BadRequest("invalid lat"))
+		return
+	}
+	lon, err := strconv.ParseFloat(r.URL.Query().Get("lon"), 64)
+	if err != nil {
+		response.Error(w, r, er.BadRequest("invalid lon"))
+		return
+	}
+
+	radiusKm := 0.0
+	if radiusStr := r.URL.Query().Get("radius_km"); radiusStr != "" {
+		radiusKm, err = strconv.ParseFloat(radiusStr, 64)
+		if err != nil || radiusKm < 0 {
+			response.Error(w, r, er.BadRequest("invalid radius_km"))
+			return
+		}
+	}
+
+	var excludeID *uuid.UUID
+	if excludeStr := r.URL.Query().Get("exclude_id"); excludeStr != "" {
+		id, err := uuid.Parse(excludeStr)
+		if err != nil {
+			response.Error(w, r, er.BadRequest("invalid exclude_id"))
+			return
+		}
+		excludeID = &id
+	}
+
+	limit := 10
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		limit, err = strconv.Atoi(limitStr)
+		if err != nil || limit < 1 {
+			response.Error(w, r, er.BadRequest("invalid limit"))
+			return
+		}
+	}
+
+	profiles, err := h.usecase.ListNearby(r.Context(), lat, lon, radiusKm, excludeID, limit)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	result := dto.NearbyMasterProfileListResponse{
+		Results: dto.NewNearbyMasterProfileResponses(profiles),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
 }
@@ -0,0 +1,70 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+
+	"github.com/Vanv1k/BeautyTON/internal/domain/entity"
+	"github.com/Vanv1k/BeautyTON/internal/domain/repository"
+	"github.com/Vanv1k/BeautyTON/internal/usecase"
+)
+
+func TestMasterProfileRatingIsSetByAdmins(t *testing.T) {
+	routes := &Routes{Public: mux.NewRouter(), API: mux.NewRouter(), Admin: mux.NewRouter()}
+	NewMasterProfileHandler(nil).RegisterRoutes(routes)
+
+	r := httptest.NewRequest("PUT", "/master_profiles/"+uuid.NewString()+"/rating", nil)
+	var match mux.RouteMatch
+	if routes.API.Match(r, &match) {
+		t.Fatal("rating route is open to every authenticated user")
+	}
+	if !routes.Admin.Match(r, &match) {
+		t.Fatal("rating route is not served to admins")
+	}
+}
+
+type fakeMasterProfileRepo struct {
+	repository.MasterProfileRepository
+	profiles map[uuid.UUID]*entity.MasterProfile
+}
+
+func (r *fakeMasterProfileRepo) Create(ctx context.Context, profile *entity.MasterProfile) error {
+	r.profiles[profile.ID] = profile
+	return nil
+}
+
+func TestCreateMasterProfileIsMadeForCaller(t *testing.T) {
+	master := uuid.New()
+	profiles := &fakeMasterProfileRepo{profiles: map[uuid.UUID]*entity.MasterProfile{}}
+	users := &fakeUserRepo{users: map[uuid.UUID]*entity.User{
+		master: {ID: master, Role: entity.UserRoleMaster},
+	}}
+	h := NewMasterProfileHandler(usecase.NewMasterProfileUsecase(profiles, users))
+
+	w := httptest.NewRecorder()
+	h.CreateMasterProfile(w, authenticatedRequest("POST", "/master_profiles", `{"qr_code":"qr"}`, master, entity.UserRoleMaster))
+	if w.Code != http.StatusCreated {
+		t.Fatalf("create master profile: got %d %s", w.Code, w.Body)
+	}
+	for _, profile := range profiles.profiles {
+		if profile.UserID == nil || *profile.UserID != master {
+			t.Fatalf("profile user is %v, want the caller %s", profile.UserID, master)
+		}
+	}
+
+	// The user can no longer be chosen by the caller
+	w = httptest.NewRecorder()
+	body := `{"qr_code":"qr","user_id":"` + uuid.NewString() + `"}`
+	h.CreateMasterProfile(w, authenticatedRequest("POST", "/master_profiles", body, master, entity.UserRoleMaster))
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("create master profile with user_id: got %d, want 400", w.Code)
+	}
+	if len(profiles.profiles) != 1 {
+		t.Fatalf("%d profiles stored, want 1", len(profiles.profiles))
+	}
+}
@@ -8,8 +8,9 @@ import (
 	"github.com/google/uuid"
 	"github.com/gorilla/mux"
 
-	"github.com/Vanv1k/BeautyTON/internal/domain/entity"
 	er "github.com/Vanv1k/BeautyTON/internal/domain/errors"
+	"github.com/Vanv1k/BeautyTON/internal/infrastructure/http/dto"
+	"github.com/Vanv1k/BeautyTON/internal/infrastructure/http/response"
 	"github.com/Vanv1k/BeautyTON/internal/usecase"
 )
 
@@ -21,6 +22,14 @@ func NewMyMasterHandler(usecase *usecase.MyMasterUsecase) *MyMasterHandler {
 	return &MyMasterHandler{usecase: usecase}
 }
 
+// RegisterRoutes registers the my master routes.
+func (h *MyMasterHandler) RegisterRoutes(routes *Routes) {
+	routes.API.HandleFunc("/my_masters/{id}", h.GetMyMaster).Methods("GET", "OPTIONS")
+	routes.API.HandleFunc("/my_masters", h.CreateMyMaster).Methods("POST", "OPTIONS")
+	routes.API.HandleFunc("/my_masters/{id}", h.UpdateMyMaster).Methods("PUT", "OPTIONS")
+	routes.API.HandleFunc("/my_masters/{id}", h.DeleteMyMaster).Methods("DELETE", "OPTIONS")
+}
+
 // GetMyMaster godoc
 // @Summary Get a my master by ID
 // @Description Get my master details by my master ID
@@ -28,29 +37,28 @@ func NewMyMasterHandler(usecase *usecase.MyMasterUsecase) *MyMasterHandler {
 // @Accept  json
 // @Produce  json
 // @Param id path string true "My Master ID"
-// @Success 200 {object} entity.MyMaster
-// @Failure 400 {object} map[string]string
-// @Failure 404 {object} map[string]string
-// @Failure 500 {object} map[string]string
+// @Success 200 {object} dto.MyMasterResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
 // @Router /my_masters/{id} [get]
 func (h *MyMasterHandler) GetMyMaster(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	id, err := uuid.Parse(vars["id"])
 	if err != nil {
-		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		response.Error(w, r, er.BadRequest("invalid ID"))
 		return
 	}
 	myMaster, err := h.usecase.GetMyMaster(r.Context(), id)
 	if err != nil {
 		if errors.Is(err, er.ErrRecordNotFound) {
-			http.Error(w, "MyMaster not found", http.StatusNotFound)
-		} else {
-			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			err = er.NotFound("my master not found")
 		}
+		response.Error(w, r, err)
 		return
 	}
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(myMaster)
+	json.NewEncoder(w).Encode(dto.NewMyMasterResponse(myMaster))
 }
 
 // CreateMyMaster godoc
@@ -59,23 +67,23 @@ func (h *MyMasterHandler) GetMyMaster(w http.ResponseWriter, r *http.Request) {
 // @Tags my-masters
 // @Accept  json
 // @Produce  json
-// @Param mymaster body entity.MyMaster true "Create my master"
-// @Success 201 {object} entity.MyMaster
-// @Failure 400 {object} map[string]string
+// @Param mymaster body dto.MyMasterRequest true "Create my master"
+// @Success 201 {object} dto.MyMasterResponse
+// @Failure 400 {object} response.ErrorResponse
 // @Router /my_masters [post]
 func (h *MyMasterHandler) CreateMyMaster(w http.ResponseWriter, r *http.Request) {
-	var myMaster entity.MyMaster
-	if err := json.NewDecoder(r.Body).Decode(&myMaster); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+	var req dto.MyMasterRequest
+	if !decodeRequest(w, r, &req) {
 		return
 	}
-	if err := h.usecase.CreateMyMaster(r.Context(), &myMaster); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+	myMaster := req.ToEntity()
+	if err := h.usecase.CreateMyMaster(r.Context(), myMaster); err != nil {
+		response.Error(w, r, err)
 		return
 	}
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
-	json.NewEncoder(w).Encode(myMaster)
+	json.NewEncoder(w).Encode(dto.NewMyMasterResponse(myMaster))
 }
 
 // UpdateMyMaster godoc
@@ -85,29 +93,36 @@ func (h *MyMasterHandler) CreateMyMaster(w http.ResponseWriter, r *http.Request)
 // @Accept  json
 // @Produce  json
 // @Param id path string true "My Master ID"
-// @Param mymaster body entity.MyMaster true "Update my master"
-// @Success 200 {object} entity.MyMaster
-// @Failure 400 {object} map[string]string
+// @Param mymaster body dto.MyMasterRequest true "Update my master"
+// @Success 200 {object} dto.MyMasterResponse
+// @Failure 400 {object} response.ErrorResponse
 // @Router /my_masters/{id} [put]
 func (h *MyMasterHandler) UpdateMyMaster(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	id, err := uuid.Parse(vars["id"])
 	if err != nil {
-		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		response.Error(w, r, er.BadRequest("invalid ID"))
+		return
+	}
+	var req dto.MyMasterRequest
+	if !decodeRequest(w, r, &req) {
 		return
 	}
-	var myMaster entity.MyMaster
-	if err := json.NewDecoder(r.Body).Decode(&myMaster); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+	myMaster, err := h.usecase.GetMyMaster(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, er.ErrRecordNotFound) {
+			err = er.NotFound("my master not found")
+		}
+		response.Error(w, r, err)
 		return
 	}
-	myMaster.ID = id
-	if err := h.usecase.UpdateMyMaster(r.Context(), &myMaster); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+	req.ApplyTo(myMaster)
+	if err := h.usecase.UpdateMyMaster(r.Context(), myMaster); err != nil {
+		response.Error(w, r, err)
 		return
 	}
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(myMaster)
+	json.NewEncoder(w).Encode(dto.NewMyMasterResponse(myMaster))
 }
 
 // DeleteMyMaster godoc
@@ -118,23 +133,22 @@ func (h *MyMasterHandler) UpdateMyMaster(w http.ResponseWriter, r *http.Request)
 // @Produce  json
 // @Param id path string true "My Master ID"
 // @Success 204
-// @Failure 400 {object} map[string]string
-// @Failure 404 {object} map[string]string
-// @Failure 500 {object} map[string]string
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
 // @Router /my_masters/{id} [delete]
 func (h *MyMasterHandler) DeleteMyMaster(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	id, err := uuid.Parse(vars["id"])
 	if err != nil {
-		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		response.Error(w, r, er.BadRequest("invalid ID"))
 		return
 	}
 	if err := h.usecase.DeleteMyMaster(r.Context(), id); err != nil {
 		if errors.Is(err, er.ErrRecordNotFound) {
-			http.Error(w, "MyMaster not found", http.StatusNotFound)
-		} else {
-			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			err = er.NotFound("my master not found")
 		}
+		response.Error(w, r, err)
 		return
 	}
 	w.WriteHeader(http.StatusNoContent)
@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	er "github.com/Vanv1k/BeautyTON/internal/domain/errors"
+	"github.com/Vanv1k/BeautyTON/internal/infrastructure/http/dto"
+	"github.com/Vanv1k/BeautyTON/internal/infrastructure/http/response"
+)
+
+// decodePatch applies the JSON Merge Patch (RFC 7386) in the body to req,
+// which must hold the current state of the resource, and validates the
+// result. Fields set to null are reset to their zero value. On failure it
+// writes a 400 response and returns false.
+func decodePatch(w http.ResponseWriter, r *http.Request, req interface{}) bool {
+	var patch interface{}
+	if err := json.NewDecoder(r.Body).Decode(&patch); err != nil {
+		response.Error(w, r, er.BadRequest("invalid request body"))
+		return false
+	}
+	if _, ok := patch.(map[string]interface{}); !ok {
+		response.Error(w, r, er.BadRequest("invalid request body"))
+		return false
+	}
+
+	current, err := json.Marshal(req)
+	if err != nil {
+		response.Error(w, r, err)
+		return false
+	}
+	var document interface{}
+	if err := json.Unmarshal(current, &document); err != nil {
+		response.Error(w, r, err)
+		return false
+	}
+	merged, err := json.Marshal(mergePatch(document, patch))
+	if err != nil {
+		response.Error(w, r, err)
+		return false
+	}
+
+	// Decode into a zero value so removed members do not keep their old value
+	v := reflect.ValueOf(req).Elem()
+	v.Set(reflect.Zero(v.Type()))
+	decoder := json.NewDecoder(bytes.NewReader(merged))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(req); err != nil {
+		response.Error(w, r, er.BadRequest("invalid request body"))
+		return false
+	}
+	if fields := dto.Validate(req); fields != nil {
+		response.Error(w, r, er.Validation("validation failed").WithDetails(fields))
+		return false
+	}
+	return true
+}
+
+// mergePatch returns target with patch applied as described in RFC 7386.
+func mergePatch(target, patch interface{}) interface{} {
+	patchObject, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+	targetObject, ok := target.(map[string]interface{})
+	if !ok {
+		targetObject = map[string]interface{}{}
+	}
+	for name, value := range patchObject {
+		if value == nil {
+			delete(targetObject, name)
+			continue
+		}
+		targetObject[name] = mergePatch(targetObject[name], value)
+	}
+	return targetObject
+}
+
+// setETag sets the entity tag of a versioned resource.
+func setETag(w http.ResponseWriter, version int64) {
+	w.Header().Set("ETag", strconv.Quote(strconv.FormatInt(version, 10)))
+}
+
+// checkIfMatch compares the If-Match header with the current version of the
+// resource. The header is required, so clients cannot overwrite changes they
+// have not seen. Without it it writes a 428 response, on mismatch a 412
+// response, and returns false.
+func checkIfMatch(w http.ResponseWriter, r *http.Request, version int64) bool {
+	header := r.Header.Get("If-Match")
+	if header == "" {
+		response.Error(w, r, er.ErrIfMatchRequired)
+		return false
+	}
+	etag := strconv.Quote(strconv.FormatInt(version, 10))
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" || tag == etag {
+			return true
+		}
+	}
+	response.Error(w, r, er.ErrVersionConflict)
+	return false
+}
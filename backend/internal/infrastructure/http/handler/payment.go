@@ -3,8 +3,9 @@ package handler
 import (
 	"encoding/json"
 	"errors"
-	"github.com/Vanv1k/BeautyTON/internal/domain/entity"
 	er "github.com/Vanv1k/BeautyTON/internal/domain/errors"
+	"github.com/Vanv1k/BeautyTON/internal/infrastructure/http/dto"
+	"github.com/Vanv1k/BeautyTON/internal/infrastructure/http/response"
 	"github.com/Vanv1k/BeautyTON/internal/usecase"
 	"github.com/google/uuid"
 	"github.com/gorilla/mux"
@@ -19,6 +20,15 @@ func NewPaymentHandler(usecase *usecase.PaymentUsecase) *PaymentHandler {
 	return &PaymentHandler{usecase: usecase}
 }
 
+// RegisterRoutes registers the payment routes.
+func (h *PaymentHandler) RegisterRoutes(routes *Routes) {
+	routes.API.HandleFunc("/payments/{id}", h.GetPayment).Methods("GET", "OPTIONS")
+	routes.API.HandleFunc("/payments", h.CreatePayment).Methods("POST", "OPTIONS")
+	routes.API.HandleFunc("/payments/{id}", h.UpdatePayment).Methods("PUT", "OPTIONS")
+	//routes.API.HandleFunc("/payments/{id}", h.DeletePayment).Methods("DELETE", "OPTIONS")
+	routes.API.HandleFunc("/payments/{id}/status", h.UpdatePaymentStatus).Methods("PUT", "OPTIONS")
+}
+
 // GetPayment godoc
 // @Summary Get a payment by ID
 // @Description Get payment details by payment ID
@@ -26,29 +36,28 @@ func NewPaymentHandler(usecase *usecase.PaymentUsecase) *PaymentHandler {
 // @Accept  json
 // @Produce  json
 // @Param id path string true "Payment ID"
-// @Success 200 {object} entity.Payment
-// @Failure 400 {object} map[string]string
-// @Failure 404 {object} map[string]string
-// @Failure 500 {object} map[string]string
+// @Success 200 {object} dto.PaymentResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
 // @Router /payments/{id} [get]
 func (h *PaymentHandler) GetPayment(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	id, err := uuid.Parse(vars["id"])
 	if err != nil {
-		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		response.Error(w, r, er.BadRequest("invalid ID"))
 		return
 	}
 	payment, err := h.usecase.GetPayment(r.Context(), id)
 	if err != nil {
 		if errors.Is(err, er.ErrRecordNotFound) {
-			http.Error(w, "Payment not found", http.StatusNotFound)
-		} else {
-			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			err = er.NotFound("payment not found")
 		}
+		response.Error(w, r, err)
 		return
 	}
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(payment)
+	json.NewEncoder(w).Encode(dto.NewPaymentResponse(payment))
 }
 
 // CreatePayment godoc
@@ -57,23 +66,26 @@ func (h *PaymentHandler) GetPayment(w http.ResponseWriter, r *http.Request) {
 // @Tags payments
 // @Accept  json
 // @Produce  json
-// @Param payment body entity.Payment true "Create payment"
-// @Success 201 {object} entity.Payment
-// @Failure 400 {object} map[string]string
+// @Param payment body dto.CreatePaymentRequest true "Create payment"
+// @Param Idempotency-Key header string false "Unique key making retries of this request safe"
+// @Success 201 {object} dto.PaymentResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 409 {object} response.ErrorResponse
+// @Failure 422 {object} response.ErrorResponse
 // @Router /payments [post]
 func (h *PaymentHandler) CreatePayment(w http.ResponseWriter, r *http.Request) {
-	var payment entity.Payment
-	if err := json.NewDecoder(r.Body).Decode(&payment); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+	var req dto.CreatePaymentRequest
+	if !decodeRequest(w, r, &req) {
 		return
 	}
-	if err := h.usecase.CreatePayment(r.Context(), &payment); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+	payment := req.ToEntity()
+	if err := h.usecase.CreatePayment(r.Context(), payment); err != nil {
+		response.Error(w, r, err)
 		return
 	}
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
-	json.NewEncoder(w).Encode(payment)
+	json.NewEncoder(w).Encode(dto.NewPaymentResponse(payment))
 }
 
 // UpdatePayment godoc
@@ -83,29 +95,36 @@ func (h *PaymentHandler) CreatePayment(w http.ResponseWriter, r *http.Request) {
 // @Accept  json
 // @Produce  json
 // @Param id path string true "Payment ID"
-// @Param payment body entity.Payment true "Update payment"
-// @Success 200 {object} entity.Payment
-// @Failure 400 {object} map[string]string
+// @Param payment body dto.UpdatePaymentRequest true "Update payment"
+// @Success 200 {object} dto.PaymentResponse
+// @Failure 400 {object} response.ErrorResponse
 // @Router /payments/{id} [put]
 func (h *PaymentHandler) UpdatePayment(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	id, err := uuid.Parse(vars["id"])
 	if err != nil {
-		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		response.Error(w, r, er.BadRequest("invalid ID"))
 		return
 	}
-	var payment entity.Payment
-	if err := json.NewDecoder(r.Body).Decode(&payment); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+	var req dto.UpdatePaymentRequest
+	if !decodeRequest(w, r, &req) {
 		return
 	}
-	payment.ID = id
-	if err := h.usecase.UpdatePayment(r.Context(), &payment); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+	payment, err := h.usecase.GetPayment(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, er.ErrRecordNotFound) {
+			err = er.NotFound("payment not found")
+		}
+		response.Error(w, r, err)
+		return
+	}
+	req.ApplyTo(payment)
+	if err := h.usecase.UpdatePayment(r.Context(), payment); err != nil {
+		response.Error(w, r, err)
 		return
 	}
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(payment)
+	json.NewEncoder(w).Encode(dto.NewPaymentResponse(payment))
 }
 
 // UpdatePaymentStatus godoc
@@ -116,44 +135,41 @@ func (h *PaymentHandler) UpdatePayment(w http.ResponseWriter, r *http.Request) {
 // @Produce  json
 // @Param id path string true "Payment ID"
 // @Param status body object{status=entity.PaymentStatus} true "Payment status"
-// @Success 200 {object} entity.Payment
-// @Failure 400 {object} map[string]string
+// @Success 200 {object} dto.PaymentResponse
+// @Failure 400 {object} response.ErrorResponse
 // @Router /payments/{id}/status [put]
 func (h *PaymentHandler) UpdatePaymentStatus(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	id, err := uuid.Parse(vars["id"])
 	if err != nil {
-		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		response.Error(w, r, er.BadRequest("invalid ID"))
 		return
 	}
-	var input struct {
-		Status entity.PaymentStatus `json:"status"`
-	}
-	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+	var req dto.UpdatePaymentStatusRequest
+	if !decodeRequest(w, r, &req) {
 		return
 	}
-	payment, err := h.usecase.UpdatePaymentStatus(r.Context(), id, input.Status)
+	payment, err := h.usecase.UpdatePaymentStatus(r.Context(), id, req.Status)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		response.Error(w, r, err)
 		return
 	}
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(payment)
+	json.NewEncoder(w).Encode(dto.NewPaymentResponse(payment))
 }
 
 //func (h *PaymentHandler) DeletePayment(w http.ResponseWriter, r *http.Request) {
 //	vars := mux.Vars(r)
 //	id, err := uuid.Parse(vars["id"])
 //	if err != nil {
-//		http.Error(w, "Invalid ID", http.StatusBadRequest)
+//		response.Error(w, r, er.BadRequest("invalid ID"))
 //		return
 //	}
 //	if err := h.usecase.DeletePayment(r.Context(), id); err != nil {
 //		if err == entity.ErrRecordNotFound {
-//			http.Error(w, "Payment not found", http.StatusNotFound)
+//			response.Error(w, r, er.NotFound("payment not found"))
 //		} else {
-//			http.Error(w, "Internal server error", http.StatusInternalServerError)
+//			response.Error(w, r, err)
 //		}
 //		return
 //	}
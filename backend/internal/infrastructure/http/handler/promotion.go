@@ -0,0 +1,158 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+
+	"github.com/Vanv1k/BeautyTON/internal/domain/entity"
+	er "github.com/Vanv1k/BeautyTON/internal/domain/errors"
+	"github.com/Vanv1k/BeautyTON/internal/infrastructure/http/dto"
+	"github.com/Vanv1k/BeautyTON/internal/infrastructure/http/response"
+	"github.com/Vanv1k/BeautyTON/internal/usecase"
+)
+
+type PromotionHandler struct {
+	usecase *usecase.PromotionUsecase
+}
+
+func NewPromotionHandler(usecase *usecase.PromotionUsecase) *PromotionHandler {
+	return &PromotionHandler{usecase: usecase}
+}
+
+// RegisterRoutes registers the promotion routes.
+func (h *PromotionHandler) RegisterRoutes(routes *Routes) {
+	masterOnly := routes.MasterOnly
+	routes.API.HandleFunc("/promotions/{id}", h.GetPromotion).Methods("GET", "OPTIONS")
+	routes.API.Handle("/promotions", masterOnly(http.HandlerFunc(h.CreatePromotion))).Methods("POST", "OPTIONS")
+	routes.API.Handle("/promotions/{id}/cancel", masterOnly(http.HandlerFunc(h.CancelPromotion))).Methods("POST", "OPTIONS")
+	routes.API.Handle("/promotions/{id}/click", routes.RateLimit(entity.RateLimitGroupClicks)(http.HandlerFunc(h.RecordPromotionClick))).Methods("POST", "OPTIONS")
+}
+
+// GetPromotion godoc
+// @Summary Get a promotion campaign by ID
+// @Description Get promotion campaign details with impression and click counters
+// @Tags promotions
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Promotion ID"
+// @Success 200 {object} dto.PromotionResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /promotions/{id} [get]
+func (h *PromotionHandler) GetPromotion(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := uuid.Parse(vars["id"])
+	if err != nil {
+		response.Error(w, r, er.BadRequest("invalid ID"))
+		return
+	}
+	promotion, err := h.usecase.GetPromotion(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, er.ErrRecordNotFound) {
+			err = er.NotFound("promotion not found")
+		}
+		response.Error(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(dto.NewPromotionResponse(promotion))
+}
+
+// CreatePromotion godoc
+// @Summary Create a promotion campaign
+// @Description Create a campaign placing the master of the current user into catalog results for a city and category. The campaign is pending until a TON payment of its budget with this promotion_id completes
+// @Tags promotions
+// @Accept  json
+// @Produce  json
+// @Param promotion body dto.CreatePromotionRequest true "Create promotion"
+// @Param Idempotency-Key header string false "Unique key making retries of this request safe"
+// @Success 201 {object} dto.PromotionResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 409 {object} response.ErrorResponse
+// @Failure 422 {object} response.ErrorResponse
+// @Router /promotions [post]
+func (h *PromotionHandler) CreatePromotion(w http.ResponseWriter, r *http.Request) {
+	var req dto.CreatePromotionRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+	userID, _ := currentUser(r)
+	promotion := req.ToEntity()
+	if err := h.usecase.CreatePromotion(r.Context(), userID, promotion); err != nil {
+		response.Error(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(dto.NewPromotionResponse(promotion))
+}
+
+// CancelPromotion godoc
+// @Summary Cancel a promotion campaign
+// @Description Stop a pending or active promotion campaign of the master of the current user
+// @Tags promotions
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Promotion ID"
+// @Success 200 {object} dto.PromotionResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Router /promotions/{id}/cancel [post]
+func (h *PromotionHandler) CancelPromotion(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := uuid.Parse(vars["id"])
+	if err != nil {
+		response.Error(w, r, er.BadRequest("invalid ID"))
+		return
+	}
+	userID, _ := currentUser(r)
+	promotion, err := h.usecase.CancelPromotion(r.Context(), userID, id)
+	if err != nil {
+		if errors.Is(err, er.ErrRecordNotFound) {
+			err = er.NotFound("promotion not found")
+		}
+		response.Error(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(dto.NewPromotionResponse(promotion))
+}
+
+// RecordPromotionClick godoc
+// @Summary Record a click on a promoted listing
+// @Description Count a click of the current user on a promotion shown in the catalog. Each user counts once per promotion, repeated clicks are accepted but not counted
+// @Tags promotions
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Promotion ID"
+// @Success 204
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 429 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /promotions/{id}/click [post]
+func (h *PromotionHandler) RecordPromotionClick(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := uuid.Parse(vars["id"])
+	if err != nil {
+		response.Error(w, r, er.BadRequest("invalid ID"))
+		return
+	}
+	userID, _ := currentUser(r)
+	if err := h.usecase.RecordClick(r.Context(), userID, id); err != nil {
+		if errors.Is(err, er.ErrRecordNotFound) {
+			err = er.NotFound("promotion not found")
+		}
+		response.Error(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
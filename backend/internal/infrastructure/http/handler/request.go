@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/google/uuid"
+
+	"github.com/Vanv1k/BeautyTON/internal/domain/entity"
+	er "github.com/Vanv1k/BeautyTON/internal/domain/errors"
+	"github.com/Vanv1k/BeautyTON/internal/infrastructure/http/dto"
+	"github.com/Vanv1k/BeautyTON/internal/infrastructure/http/response"
+)
+
+// decodeRequest decodes the JSON body into req and validates it. Unknown
+// fields are rejected so clients cannot silently set server-managed values.
+// On failure it writes a 400 response and returns false.
+func decodeRequest(w http.ResponseWriter, r *http.Request, req interface{}) bool {
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(req); err != nil {
+		response.Error(w, r, er.BadRequest("invalid request body"))
+		return false
+	}
+	if fields := dto.Validate(req); fields != nil {
+		response.Error(w, r, er.Validation("validation failed").WithDetails(fields))
+		return false
+	}
+	return true
+}
+
+// currentUser returns the authenticated user and their role, set by
+// TelegramAuthMiddleware.
+func currentUser(r *http.Request) (uuid.UUID, entity.UserRole) {
+	id, _ := r.Context().Value("user_id").(uuid.UUID)
+	role, _ := r.Context().Value("user_role").(string)
+	return id, entity.UserRole(role)
+}
+
+// sniffContentType detects the type of an upload from its first bytes
+// instead of trusting the type sent by the client, then rewinds it.
+func sniffContentType(file io.ReadSeeker) (string, error) {
+	head := make([]byte, 512)
+	n, err := io.ReadFull(file, head)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return "", err
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+	return http.DetectContentType(head[:n]), nil
+}
@@ -10,6 +10,8 @@ import (
 
 	"github.com/Vanv1k/BeautyTON/internal/domain/entity"
 	er "github.com/Vanv1k/BeautyTON/internal/domain/errors"
+	"github.com/Vanv1k/BeautyTON/internal/infrastructure/http/dto"
+	"github.com/Vanv1k/BeautyTON/internal/infrastructure/http/response"
 	"github.com/Vanv1k/BeautyTON/internal/usecase"
 )
 
@@ -21,6 +23,14 @@ func NewReviewHandler(usecase *usecase.ReviewUsecase) *ReviewHandler {
 	return &ReviewHandler{usecase: usecase}
 }
 
+// RegisterRoutes registers the review routes.
+func (h *ReviewHandler) RegisterRoutes(routes *Routes) {
+	routes.API.HandleFunc("/reviews/{id}", h.GetReview).Methods("GET", "OPTIONS")
+	routes.API.Handle("/reviews", routes.RateLimit(entity.RateLimitGroupReviews)(http.HandlerFunc(h.CreateReview))).Methods("POST", "OPTIONS")
+	routes.API.HandleFunc("/reviews/{id}", h.UpdateReview).Methods("PUT", "OPTIONS")
+	routes.API.HandleFunc("/reviews/{id}", h.DeleteReview).Methods("DELETE", "OPTIONS")
+}
+
 // GetReview godoc
 // @Summary Get a review by ID
 // @Description Get review details by review ID
@@ -28,29 +38,28 @@ func NewReviewHandler(usecase *usecase.ReviewUsecase) *ReviewHandler {
 // @Accept  json
 // @Produce  json
 // @Param id path string true "Review ID"
-// @Success 200 {object} entity.Review
-// @Failure 400 {object} map[string]string
-// @Failure 404 {object} map[string]string
-// @Failure 500 {object} map[string]string
+// @Success 200 {object} dto.ReviewResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
 // @Router /reviews/{id} [get]
 func (h *ReviewHandler) GetReview(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	id, err := uuid.Parse(vars["id"])
 	if err != nil {
-		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		response.Error(w, r, er.BadRequest("invalid ID"))
 		return
 	}
 	review, err := h.usecase.GetReview(r.Context(), id)
 	if err != nil {
 		if errors.Is(err, er.ErrRecordNotFound) {
-			http.Error(w, "Review not found", http.StatusNotFound)
-		} else {
-			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			err = er.NotFound("review not found")
 		}
+		response.Error(w, r, err)
 		return
 	}
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(review)
+	json.NewEncoder(w).Encode(dto.NewReviewResponse(review))
 }
 
 // CreateReview godoc
@@ -59,23 +68,24 @@ func (h *ReviewHandler) GetReview(w http.ResponseWriter, r *http.Request) {
 // @Tags reviews
 // @Accept  json
 // @Produce  json
-// @Param review body entity.Review true "Create review"
-// @Success 201 {object} entity.Review
-// @Failure 400 {object} map[string]string
+// @Param review body dto.CreateReviewRequest true "Create review"
+// @Success 201 {object} dto.ReviewResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 429 {object} response.ErrorResponse
 // @Router /reviews [post]
 func (h *ReviewHandler) CreateReview(w http.ResponseWriter, r *http.Request) {
-	var review entity.Review
-	if err := json.NewDecoder(r.Body).Decode(&review); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+	var req dto.CreateReviewRequest
+	if !decodeRequest(w, r, &req) {
 		return
 	}
-	if err := h.usecase.CreateReview(r.Context(), &review); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+	review := req.ToEntity()
+	if err := h.usecase.CreateReview(r.Context(), review); err != nil {
+		response.Error(w, r, err)
 		return
 	}
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
-	json.NewEncoder(w).Encode(review)
+	json.NewEncoder(w).Encode(dto.NewReviewResponse(review))
 }
 
 // UpdateReview godoc
@@ -85,29 +95,36 @@ func (h *ReviewHandler) CreateReview(w http.ResponseWriter, r *http.Request) {
 // @Accept  json
 // @Produce  json
 // @Param id path string true "Review ID"
-// @Param review body entity.Review true "Update review"
-// @Success 200 {object} entity.Review
-// @Failure 400 {object} map[string]string
+// @Param review body dto.UpdateReviewRequest true "Update review"
+// @Success 200 {object} dto.ReviewResponse
+// @Failure 400 {object} response.ErrorResponse
 // @Router /reviews/{id} [put]
 func (h *ReviewHandler) UpdateReview(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	id, err := uuid.Parse(vars["id"])
 	if err != nil {
-		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		response.Error(w, r, er.BadRequest("invalid ID"))
+		return
+	}
+	var req dto.UpdateReviewRequest
+	if !decodeRequest(w, r, &req) {
 		return
 	}
-	var review entity.Review
-	if err := json.NewDecoder(r.Body).Decode(&review); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+	review, err := h.usecase.GetReview(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, er.ErrRecordNotFound) {
+			err = er.NotFound("review not found")
+		}
+		response.Error(w, r, err)
 		return
 	}
-	review.ID = id
-	if err := h.usecase.UpdateReview(r.Context(), &review); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+	req.ApplyTo(review)
+	if err := h.usecase.UpdateReview(r.Context(), review); err != nil {
+		response.Error(w, r, err)
 		return
 	}
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(review)
+	json.NewEncoder(w).Encode(dto.NewReviewResponse(review))
 }
 
 // DeleteReview godoc
@@ -118,23 +135,22 @@ func (h *ReviewHandler) UpdateReview(w http.ResponseWriter, r *http.Request) {
 // @Produce  json
 // @Param id path string true "Review ID"
 // @Success 204
-// @Failure 400 {object} map[string]string
-// @Failure 404 {object} map[string]string
-// @Failure 500 {object} map[string]string
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
 // @Router /reviews/{id} [delete]
 func (h *ReviewHandler) DeleteReview(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	id, err := uuid.Parse(vars["id"])
 	if err != nil {
-		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		response.Error(w, r, er.BadRequest("invalid ID"))
 		return
 	}
 	if err := h.usecase.DeleteReview(r.Context(), id); err != nil {
 		if errors.Is(err, er.ErrRecordNotFound) {
-			http.Error(w, "Review not found", http.StatusNotFound)
-		} else {
-			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			err = er.NotFound("review not found")
 		}
+		response.Error(w, r, err)
 		return
 	}
 	w.WriteHeader(http.StatusNoContent)
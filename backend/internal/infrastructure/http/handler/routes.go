@@ -0,0 +1,30 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// Routes are the route groups of one API version. Each handler registers
+// its routes into them, so another version can mount its own handlers next
+// to it.
+type Routes struct {
+	// Public routes skip authentication, they are limited by IP
+	Public *mux.Router
+	// API routes require an authenticated user
+	API *mux.Router
+	// Admin routes require the admin role, every change is recorded in the
+	// audit trail. Paths are relative to /admin
+	Admin *mux.Router
+	// MasterOnly restricts a route to masters
+	MasterOnly func(http.Handler) http.Handler
+	// RateLimit applies the limit of a group, see entity.RateLimitGroup*,
+	// on top of the default one
+	RateLimit func(group string) func(http.Handler) http.Handler
+}
+
+// RouteRegistrar is implemented by handlers that serve API routes.
+type RouteRegistrar interface {
+	RegisterRoutes(routes *Routes)
+}
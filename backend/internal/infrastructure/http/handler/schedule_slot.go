@@ -8,8 +8,9 @@ import (
 	"github.com/google/uuid"
 	"github.com/gorilla/mux"
 
-	"github.com/Vanv1k/BeautyTON/internal/domain/entity"
 	er "github.com/Vanv1k/BeautyTON/internal/domain/errors"
+	"github.com/Vanv1k/BeautyTON/internal/infrastructure/http/dto"
+	"github.com/Vanv1k/BeautyTON/internal/infrastructure/http/response"
 	"github.com/Vanv1k/BeautyTON/internal/usecase"
 )
 
@@ -23,6 +24,17 @@ func NewScheduleSlotHandler(usecase *usecase.ScheduleSlotUsecase,
 	return &ScheduleSlotHandler{usecase: usecase, userUsecase: userUsecase}
 }
 
+// RegisterRoutes registers the schedule slot routes.
+func (h *ScheduleSlotHandler) RegisterRoutes(routes *Routes) {
+	masterOnly := routes.MasterOnly
+	routes.API.HandleFunc("/schedule_slots/{id}", h.GetScheduleSlot).Methods("GET", "OPTIONS")
+	routes.API.HandleFunc("/schedule_slots", h.ListScheduleSlots).Methods("GET", "OPTIONS")
+	routes.API.Handle("/schedule_slots", masterOnly(http.HandlerFunc(h.CreateScheduleSlot))).Methods("POST", "OPTIONS")
+	routes.API.Handle("/schedule_slots/{id}", masterOnly(http.HandlerFunc(h.UpdateScheduleSlot))).Methods("PUT", "OPTIONS")
+	routes.API.Handle("/schedule_slots/{id}", masterOnly(http.HandlerFunc(h.PatchScheduleSlot))).Methods("PATCH", "OPTIONS")
+	routes.API.Handle("/schedule_slots/{id}", masterOnly(http.HandlerFunc(h.DeleteScheduleSlot))).Methods("DELETE", "OPTIONS")
+}
+
 // GetScheduleSlot godoc
 // @Summary Get a schedule slot by ID
 // @Description Get schedule slot details by ID
@@ -30,65 +42,74 @@ func NewScheduleSlotHandler(usecase *usecase.ScheduleSlotUsecase,
 // @Accept json
 // @Produce json
 // @Param id path string true "Schedule Slot ID"
-// @Success 200 {object} entity.ScheduleSlot
-// @Failure 400 {object} map[string]string
-// @Failure 404 {object} map[string]string
-// @Failure 500 {object} map[string]string
+// @Success 200 {object} dto.ScheduleSlotResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
 // @Router /schedule_slots/{id} [get]
 func (h *ScheduleSlotHandler) GetScheduleSlot(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	id, err := uuid.Parse(vars["id"])
 	if err != nil {
-		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		response.Error(w, r, er.BadRequest("invalid ID"))
 		return
 	}
 	slot, err := h.usecase.GetScheduleSlot(r.Context(), id)
 	if err != nil {
 		if errors.Is(err, er.ErrRecordNotFound) {
-			http.Error(w, "Schedule slot not found", http.StatusNotFound)
-		} else {
-			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			err = er.NotFound("schedule slot not found")
 		}
+		response.Error(w, r, err)
 		return
 	}
+	setETag(w, slot.Version)
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(slot)
+	json.NewEncoder(w).Encode(dto.NewScheduleSlotResponse(slot))
 }
 
 // ListScheduleSlots godoc
 // @Summary List schedule slots for a master
-// @Description Get all schedule slots for a given master ID
+// @Description Get schedule slots for a given master ID. Slots reserved for models are only listed with for_models=true
 // @Tags schedule_slots
 // @Accept json
 // @Produce json
 // @Param master_id query string true "Master ID"
-// @Success 200 {array} entity.ScheduleSlot
-// @Failure 400 {object} map[string]string
-// @Failure 404 {object} map[string]string
-// @Failure 500 {object} map[string]string
+// @Param for_models query string false "true for model slots only, false (default) for regular slots, all for both"
+// @Success 200 {object} dto.ScheduleSlotListResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
 // @Router /schedule_slots [get]
 func (h *ScheduleSlotHandler) ListScheduleSlots(w http.ResponseWriter, r *http.Request) {
 	masterIDStr := r.URL.Query().Get("master_id")
 	masterID, err := uuid.Parse(masterIDStr)
 	if err != nil {
-		http.Error(w, "Invalid master_id", http.StatusBadRequest)
+		response.Error(w, r, er.BadRequest("invalid master_id"))
+		return
+	}
+	var forModels *bool
+	switch r.URL.Query().Get("for_models") {
+	case "", "false":
+		forModels = new(bool)
+	case "true":
+		forModels = new(bool)
+		*forModels = true
+	case "all":
+	default:
+		response.Error(w, r, er.BadRequest("invalid for_models"))
 		return
 	}
-	slots, err := h.usecase.ListScheduleSlots(r.Context(), masterID)
+	slots, err := h.usecase.ListScheduleSlots(r.Context(), masterID, forModels)
 	if err != nil {
-		if err.Error() == "master profile not found" {
-			http.Error(w, err.Error(), http.StatusNotFound)
-		} else {
-			http.Error(w, "Internal server error", http.StatusInternalServerError)
-		}
+		response.Error(w, r, err)
 		return
 	}
 	w.Header().Set("Content-Type", "application/json")
-	response := map[string]interface{}{
-		"results": slots,
+	result := dto.ScheduleSlotListResponse{
+		Results: dto.NewScheduleSlotResponses(slots),
 	}
 
-	json.NewEncoder(w).Encode(response)
+	json.NewEncoder(w).Encode(result)
 }
 
 // CreateScheduleSlot godoc
@@ -97,22 +118,21 @@ func (h *ScheduleSlotHandler) ListScheduleSlots(w http.ResponseWriter, r *http.R
 // @Tags schedule_slots
 // @Accept json
 // @Produce json
-// @Param slot body entity.ScheduleSlot true "Create schedule slot"
-// @Success 201 {object} entity.ScheduleSlot
-// @Failure 400 {object} map[string]string
-// @Failure 401 {object} map[string]string
-// @Failure 404 {object} map[string]string
+// @Param slot body dto.ScheduleSlotRequest true "Create schedule slot"
+// @Success 201 {object} dto.ScheduleSlotResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
 // @Router /schedule_slots [post]
 func (h *ScheduleSlotHandler) CreateScheduleSlot(w http.ResponseWriter, r *http.Request) {
 	telegramUserID, ok := r.Context().Value("telegram_user_id").(int64)
 	if !ok {
-		http.Error(w, "Unauthorized: Telegram user ID missing", http.StatusUnauthorized)
+		response.Error(w, r, er.Unauthorized("unauthorized: Telegram user ID missing"))
 		return
 	}
 
-	var slot entity.ScheduleSlot
-	if err := json.NewDecoder(r.Body).Decode(&slot); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+	var req dto.ScheduleSlotRequest
+	if !decodeRequest(w, r, &req) {
 		return
 	}
 
@@ -120,30 +140,25 @@ func (h *ScheduleSlotHandler) CreateScheduleSlot(w http.ResponseWriter, r *http.
 	master, err := h.userUsecase.GetUserByTelegramID(r.Context(), telegramUserID)
 	if err != nil {
 		if errors.Is(err, er.ErrRecordNotFound) {
-			http.Error(w, "Master profile not found for this user", http.StatusForbidden)
+			response.Error(w, r, er.Forbidden("master profile not found for this user"))
 			return
 		}
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
-		return
-	}
-
-	// Check if master_id in request body matches authenticated master
-	if slot.MasterID != uuid.Nil && slot.MasterID != master.ID {
-		http.Error(w, "Cannot create slot for another master", http.StatusForbidden)
+		response.Error(w, r, err)
 		return
 	}
 
-	// Set master_id from authenticated user
-	slot.MasterID = master.ID
+	// The slot always belongs to the authenticated master
+	slot := req.ToEntity(master.ID)
 
-	if err := h.usecase.CreateScheduleSlot(r.Context(), &slot); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+	if err := h.usecase.CreateScheduleSlot(r.Context(), slot); err != nil {
+		response.Error(w, r, err)
 		return
 	}
 
+	setETag(w, slot.Version)
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
-	json.NewEncoder(w).Encode(slot)
+	json.NewEncoder(w).Encode(dto.NewScheduleSlotResponse(slot))
 }
 
 // UpdateScheduleSlot godoc
@@ -153,79 +168,151 @@ func (h *ScheduleSlotHandler) CreateScheduleSlot(w http.ResponseWriter, r *http.
 // @Accept json
 // @Produce json
 // @Param id path string true "Schedule Slot ID"
-// @Param slot body entity.ScheduleSlot true "Update schedule slot"
-// @Success 200 {object} entity.ScheduleSlot
-// @Failure 400 {object} map[string]string
-// @Failure 401 {object} map[string]string
-// @Failure 404 {object} map[string]string
+// @Param slot body dto.ScheduleSlotRequest true "Update schedule slot"
+// @Param If-Match header string true "ETag of the version being updated"
+// @Success 200 {object} dto.ScheduleSlotResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 412 {object} response.ErrorResponse
+// @Failure 428 {object} response.ErrorResponse
 // @Router /schedule_slots/{id} [put]
 func (h *ScheduleSlotHandler) UpdateScheduleSlot(w http.ResponseWriter, r *http.Request) {
 	telegramUserID, ok := r.Context().Value("telegram_user_id").(int64)
 	if !ok {
-		http.Error(w, "Unauthorized: Telegram user ID missing", http.StatusUnauthorized)
+		response.Error(w, r, er.Unauthorized("unauthorized: Telegram user ID missing"))
 		return
 	}
 
 	vars := mux.Vars(r)
 	id, err := uuid.Parse(vars["id"])
 	if err != nil {
-		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		response.Error(w, r, er.BadRequest("invalid ID"))
 		return
 	}
 
-	var slot entity.ScheduleSlot
-	if err := json.NewDecoder(r.Body).Decode(&slot); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+	var req dto.ScheduleSlotRequest
+	if !decodeRequest(w, r, &req) {
 		return
 	}
-	slot.ID = id
 
 	// Get MasterID for the authenticated user
 	master, err := h.userUsecase.GetUserByTelegramID(r.Context(), telegramUserID)
 	if err != nil {
 		if errors.Is(err, er.ErrRecordNotFound) {
-			http.Error(w, "Master profile not found for this user", http.StatusForbidden)
+			response.Error(w, r, er.Forbidden("master profile not found for this user"))
 			return
 		}
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		response.Error(w, r, err)
 		return
 	}
 
 	// Check if the slot belongs to the authenticated master
-	existingSlot, err := h.usecase.GetScheduleSlot(r.Context(), id)
+	slot, err := h.usecase.GetScheduleSlot(r.Context(), id)
 	if err != nil {
 		if errors.Is(err, er.ErrRecordNotFound) {
-			http.Error(w, "Schedule slot not found", http.StatusNotFound)
-		} else {
-			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			err = er.NotFound("schedule slot not found")
 		}
+		response.Error(w, r, err)
 		return
 	}
-	if existingSlot.MasterID != master.ID {
-		http.Error(w, "Cannot update slot for another master", http.StatusForbidden)
+	if slot.MasterID != master.ID {
+		response.Error(w, r, er.Forbidden("cannot update slot for another master"))
+		return
+	}
+	if !checkIfMatch(w, r, slot.Version) {
 		return
 	}
 
-	// Check if master_id in request body matches authenticated master
-	if slot.MasterID != uuid.Nil && slot.MasterID != master.ID {
-		http.Error(w, "Cannot update slot with another master's ID", http.StatusForbidden)
+	req.ApplyTo(slot)
+	if err := h.usecase.UpdateScheduleSlot(r.Context(), slot); err != nil {
+		if errors.Is(err, er.ErrRecordNotFound) {
+			err = er.NotFound("schedule slot not found")
+		}
+		response.Error(w, r, err)
 		return
 	}
 
-	// Set master_id from authenticated user
-	slot.MasterID = master.ID
+	setETag(w, slot.Version)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(dto.NewScheduleSlotResponse(slot))
+}
+
+// PatchScheduleSlot godoc
+// @Summary Partially update a schedule slot
+// @Description Apply a JSON Merge Patch (RFC 7386) to a schedule slot by ID. Members set to null are cleared
+// @Tags schedule_slots
+// @Accept json
+// @Accept application/merge-patch+json
+// @Produce json
+// @Param id path string true "Schedule Slot ID"
+// @Param slot body dto.ScheduleSlotRequest true "Fields to change"
+// @Param If-Match header string true "ETag of the version being updated"
+// @Success 200 {object} dto.ScheduleSlotResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 412 {object} response.ErrorResponse
+// @Failure 428 {object} response.ErrorResponse
+// @Router /schedule_slots/{id} [patch]
+func (h *ScheduleSlotHandler) PatchScheduleSlot(w http.ResponseWriter, r *http.Request) {
+	telegramUserID, ok := r.Context().Value("telegram_user_id").(int64)
+	if !ok {
+		response.Error(w, r, er.Unauthorized("unauthorized: Telegram user ID missing"))
+		return
+	}
 
-	if err := h.usecase.UpdateScheduleSlot(r.Context(), &slot); err != nil {
+	vars := mux.Vars(r)
+	id, err := uuid.Parse(vars["id"])
+	if err != nil {
+		response.Error(w, r, er.BadRequest("invalid ID"))
+		return
+	}
+
+	// Get MasterID for the authenticated user
+	master, err := h.userUsecase.GetUserByTelegramID(r.Context(), telegramUserID)
+	if err != nil {
+		if errors.Is(err, er.ErrRecordNotFound) {
+			response.Error(w, r, er.Forbidden("master profile not found for this user"))
+			return
+		}
+		response.Error(w, r, err)
+		return
+	}
+
+	// Check if the slot belongs to the authenticated master
+	slot, err := h.usecase.GetScheduleSlot(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, er.ErrRecordNotFound) {
+			err = er.NotFound("schedule slot not found")
+		}
+		response.Error(w, r, err)
+		return
+	}
+	if slot.MasterID != master.ID {
+		response.Error(w, r, er.Forbidden("cannot update slot for another master"))
+		return
+	}
+	if !checkIfMatch(w, r, slot.Version) {
+		return
+	}
+
+	req := dto.NewScheduleSlotRequest(slot)
+	if !decodePatch(w, r, &req) {
+		return
+	}
+	req.ApplyTo(slot)
+	if err := h.usecase.UpdateScheduleSlot(r.Context(), slot); err != nil {
 		if errors.Is(err, er.ErrRecordNotFound) {
-			http.Error(w, "Schedule slot not found", http.StatusNotFound)
-		} else {
-			http.Error(w, err.Error(), http.StatusBadRequest)
+			err = er.NotFound("schedule slot not found")
 		}
+		response.Error(w, r, err)
 		return
 	}
 
+	setETag(w, slot.Version)
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(slot)
+	json.NewEncoder(w).Encode(dto.NewScheduleSlotResponse(slot))
 }
 
 // DeleteScheduleSlot godoc
@@ -236,21 +323,21 @@ func (h *ScheduleSlotHandler) UpdateScheduleSlot(w http.ResponseWriter, r *http.
 // @Produce json
 // @Param id path string true "Schedule Slot ID"
 // @Success 204
-// @Failure 400 {object} map[string]string
-// @Failure 401 {object} map[string]string
-// @Failure 404 {object} map[string]string
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
 // @Router /schedule_slots/{id} [delete]
 func (h *ScheduleSlotHandler) DeleteScheduleSlot(w http.ResponseWriter, r *http.Request) {
 	telegramUserID, ok := r.Context().Value("telegram_user_id").(int64)
 	if !ok {
-		http.Error(w, "Unauthorized: Telegram user ID missing", http.StatusUnauthorized)
+		response.Error(w, r, er.Unauthorized("unauthorized: Telegram user ID missing"))
 		return
 	}
 
 	vars := mux.Vars(r)
 	id, err := uuid.Parse(vars["id"])
 	if err != nil {
-		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		response.Error(w, r, er.BadRequest("invalid ID"))
 		return
 	}
 
@@ -258,10 +345,10 @@ func (h *ScheduleSlotHandler) DeleteScheduleSlot(w http.ResponseWriter, r *http.
 	master, err := h.userUsecase.GetUserByTelegramID(r.Context(), telegramUserID)
 	if err != nil {
 		if errors.Is(err, er.ErrRecordNotFound) {
-			http.Error(w, "Master profile not found for this user", http.StatusForbidden)
+			response.Error(w, r, er.Forbidden("master profile not found for this user"))
 			return
 		}
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		response.Error(w, r, err)
 		return
 	}
 
@@ -269,23 +356,21 @@ func (h *ScheduleSlotHandler) DeleteScheduleSlot(w http.ResponseWriter, r *http.
 	slot, err := h.usecase.GetScheduleSlot(r.Context(), id)
 	if err != nil {
 		if errors.Is(err, er.ErrRecordNotFound) {
-			http.Error(w, "Schedule slot not found", http.StatusNotFound)
-		} else {
-			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			err = er.NotFound("schedule slot not found")
 		}
+		response.Error(w, r, err)
 		return
 	}
 	if slot.MasterID != master.ID {
-		http.Error(w, "Cannot delete slot for another master", http.StatusForbidden)
+		response.Error(w, r, er.Forbidden("cannot delete slot for another master"))
 		return
 	}
 
 	if err := h.usecase.DeleteScheduleSlot(r.Context(), id); err != nil {
 		if errors.Is(err, er.ErrRecordNotFound) {
-			http.Error(w, "Schedule slot not found", http.StatusNotFound)
-		} else {
-			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			err = er.NotFound("schedule slot not found")
 		}
+		response.Error(w, r, err)
 		return
 	}
 
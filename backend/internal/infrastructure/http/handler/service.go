@@ -10,6 +10,8 @@ import (
 
 	"github.com/Vanv1k/BeautyTON/internal/domain/entity"
 	er "github.com/Vanv1k/BeautyTON/internal/domain/errors"
+	"github.com/Vanv1k/BeautyTON/internal/infrastructure/http/dto"
+	"github.com/Vanv1k/BeautyTON/internal/infrastructure/http/response"
 	"github.com/Vanv1k/BeautyTON/internal/usecase"
 )
 
@@ -21,6 +23,19 @@ func NewServiceHandler(usecase *usecase.ServiceUsecase) *ServiceHandler {
 	return &ServiceHandler{usecase: usecase}
 }
 
+// RegisterRoutes registers the service routes.
+func (h *ServiceHandler) RegisterRoutes(routes *Routes) {
+	masterOnly := routes.MasterOnly
+	routes.API.HandleFunc("/services/{id}", h.GetService).Methods("GET", "OPTIONS")
+	routes.API.Handle("/services", masterOnly(http.HandlerFunc(h.CreateService))).Methods("POST", "OPTIONS")
+	routes.API.Handle("/services/{id}", masterOnly(http.HandlerFunc(h.UpdateService))).Methods("PUT", "OPTIONS")
+	routes.API.Handle("/services/{id}", masterOnly(http.HandlerFunc(h.PatchService))).Methods("PATCH", "OPTIONS")
+	routes.API.Handle("/services/{id}", masterOnly(http.HandlerFunc(h.DeleteService))).Methods("DELETE", "OPTIONS")
+	routes.API.Handle("/services/{id}/photo", masterOnly(routes.RateLimit(entity.RateLimitGroupUploads)(http.HandlerFunc(h.UploadServicePhoto)))).Methods("POST", "OPTIONS")
+	routes.API.Handle("/services/{id}/photo/presign", masterOnly(routes.RateLimit(entity.RateLimitGroupUploads)(http.HandlerFunc(h.PresignServicePhoto)))).Methods("POST", "OPTIONS")
+	routes.API.Handle("/services/{id}/photo/complete", masterOnly(http.HandlerFunc(h.CompleteServicePhoto))).Methods("POST", "OPTIONS")
+}
+
 // GetService godoc
 // @Summary Get a service by ID
 // @Description Get service details by service ID
@@ -28,29 +43,29 @@ func NewServiceHandler(usecase *usecase.ServiceUsecase) *ServiceHandler {
 // @Accept  json
 // @Produce  json
 // @Param id path string true "Service ID"
-// @Success 200 {object} entity.Service
-// @Failure 400 {object} map[string]string
-// @Failure 404 {object} map[string]string
-// @Failure 500 {object} map[string]string
+// @Success 200 {object} dto.ServiceResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
 // @Router /services/{id} [get]
 func (h *ServiceHandler) GetService(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	id, err := uuid.Parse(vars["id"])
 	if err != nil {
-		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		response.Error(w, r, er.BadRequest("invalid ID"))
 		return
 	}
 	service, err := h.usecase.GetService(r.Context(), id)
 	if err != nil {
 		if errors.Is(err, er.ErrRecordNotFound) {
-			http.Error(w, "Service not found", http.StatusNotFound)
-		} else {
-			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			err = er.NotFound("service not found")
 		}
+		response.Error(w, r, err)
 		return
 	}
+	setETag(w, service.Version)
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(service)
+	json.NewEncoder(w).Encode(h.serviceResponse(r, service))
 }
 
 // CreateService godoc
@@ -59,23 +74,24 @@ func (h *ServiceHandler) GetService(w http.ResponseWriter, r *http.Request) {
 // @Tags services
 // @Accept  json
 // @Produce  json
-// @Param service body entity.Service true "Create service"
-// @Success 201 {object} entity.Service
-// @Failure 400 {object} map[string]string
+// @Param service body dto.CreateServiceRequest true "Create service"
+// @Success 201 {object} dto.ServiceResponse
+// @Failure 400 {object} response.ErrorResponse
 // @Router /services [post]
 func (h *ServiceHandler) CreateService(w http.ResponseWriter, r *http.Request) {
-	var service entity.Service
-	if err := json.NewDecoder(r.Body).Decode(&service); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+	var req dto.CreateServiceRequest
+	if !decodeRequest(w, r, &req) {
 		return
 	}
-	if err := h.usecase.CreateService(r.Context(), &service); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+	service := req.ToEntity()
+	if err := h.usecase.CreateService(r.Context(), service); err != nil {
+		response.Error(w, r, err)
 		return
 	}
+	setETag(w, service.Version)
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
-	json.NewEncoder(w).Encode(service)
+	json.NewEncoder(w).Encode(h.serviceResponse(r, service))
 }
 
 // UpdateService godoc
@@ -85,29 +101,91 @@ func (h *ServiceHandler) CreateService(w http.ResponseWriter, r *http.Request) {
 // @Accept  json
 // @Produce  json
 // @Param id path string true "Service ID"
-// @Param service body entity.Service true "Update service"
-// @Success 200 {object} entity.Service
-// @Failure 400 {object} map[string]string
+// @Param service body dto.UpdateServiceRequest true "Update service"
+// @Param If-Match header string true "ETag of the version being updated"
+// @Success 200 {object} dto.ServiceResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 412 {object} response.ErrorResponse
+// @Failure 428 {object} response.ErrorResponse
 // @Router /services/{id} [put]
 func (h *ServiceHandler) UpdateService(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	id, err := uuid.Parse(vars["id"])
 	if err != nil {
-		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		response.Error(w, r, er.BadRequest("invalid ID"))
+		return
+	}
+	var req dto.UpdateServiceRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+	service, err := h.usecase.GetService(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, er.ErrRecordNotFound) {
+			err = er.NotFound("service not found")
+		}
+		response.Error(w, r, err)
+		return
+	}
+	if !checkIfMatch(w, r, service.Version) {
+		return
+	}
+	req.ApplyTo(service)
+	if err := h.usecase.UpdateService(r.Context(), service); err != nil {
+		response.Error(w, r, err)
+		return
+	}
+	setETag(w, service.Version)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(h.serviceResponse(r, service))
+}
+
+// PatchService godoc
+// @Summary Partially update a service
+// @Description Apply a JSON Merge Patch (RFC 7386) to a service by service ID. Members set to null are cleared
+// @Tags services
+// @Accept  json
+// @Accept  application/merge-patch+json
+// @Produce  json
+// @Param id path string true "Service ID"
+// @Param service body dto.UpdateServiceRequest true "Fields to change"
+// @Param If-Match header string true "ETag of the version being updated"
+// @Success 200 {object} dto.ServiceResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 412 {object} response.ErrorResponse
+// @Failure 428 {object} response.ErrorResponse
+// @Router /services/{id} [patch]
+func (h *ServiceHandler) PatchService(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := uuid.Parse(vars["id"])
+	if err != nil {
+		response.Error(w, r, er.BadRequest("invalid ID"))
+		return
+	}
+	service, err := h.usecase.GetService(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, er.ErrRecordNotFound) {
+			err = er.NotFound("service not found")
+		}
+		response.Error(w, r, err)
 		return
 	}
-	var service entity.Service
-	if err := json.NewDecoder(r.Body).Decode(&service); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+	if !checkIfMatch(w, r, service.Version) {
 		return
 	}
-	service.ID = id
-	if err := h.usecase.UpdateService(r.Context(), &service); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+	req := dto.NewUpdateServiceRequest(service)
+	if !decodePatch(w, r, &req) {
 		return
 	}
+	req.ApplyTo(service)
+	if err := h.usecase.UpdateService(r.Context(), service); err != nil {
+		response.Error(w, r, err)
+		return
+	}
+	setETag(w, service.Version)
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(service)
+	json.NewEncoder(w).Encode(h.serviceResponse(r, service))
 }
 
 // DeleteService godoc
@@ -118,23 +196,22 @@ func (h *ServiceHandler) UpdateService(w http.ResponseWriter, r *http.Request) {
 // @Produce  json
 // @Param id path string true "Service ID"
 // @Success 204
-// @Failure 400 {object} map[string]string
-// @Failure 404 {object} map[string]string
-// @Failure 500 {object} map[string]string
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
 // @Router /services/{id} [delete]
 func (h *ServiceHandler) DeleteService(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	id, err := uuid.Parse(vars["id"])
 	if err != nil {
-		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		response.Error(w, r, er.BadRequest("invalid ID"))
 		return
 	}
 	if err := h.usecase.DeleteService(r.Context(), id); err != nil {
 		if errors.Is(err, er.ErrRecordNotFound) {
-			http.Error(w, "Service not found", http.StatusNotFound)
-		} else {
-			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			err = er.NotFound("service not found")
 		}
+		response.Error(w, r, err)
 		return
 	}
 	w.WriteHeader(http.StatusNoContent)
@@ -142,43 +219,137 @@ func (h *ServiceHandler) DeleteService(w http.ResponseWriter, r *http.Request) {
 
 // UploadServicePhoto godoc
 // @Summary Upload service photo
-// @Description Upload a photo for a service
+// @Description Upload a JPEG, PNG or WebP photo for a service. It is stored upright, without metadata, in the thumb, card and full sizes
 // @Tags services
 // @Accept  multipart/form-data
 // @Produce  json
 // @Param id path string true "Service ID"
 // @Param file formData file true "Photo file"
-// @Success 200 {object} map[string]string
-// @Failure 400 {object} map[string]string
+// @Success 200 {object} dto.FileUploadResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 422 {object} response.ErrorResponse
+// @Failure 429 {object} response.ErrorResponse
 // @Router /services/{id}/photo [post]
 func (h *ServiceHandler) UploadServicePhoto(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	id, err := uuid.Parse(vars["id"])
 	if err != nil {
-		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		response.Error(w, r, er.BadRequest("invalid ID"))
 		return
 	}
 	if err := r.ParseMultipartForm(10 << 20); err != nil { // 10 MB limit
-		http.Error(w, "File too large or invalid form", http.StatusBadRequest)
+		response.Error(w, r, er.BadRequest("file too large or invalid form"))
 		return
 	}
 	file, header, err := r.FormFile("file")
 	if err != nil {
-		http.Error(w, "Invalid file", http.StatusBadRequest)
+		response.Error(w, r, er.BadRequest("invalid file"))
 		return
 	}
 	defer file.Close()
+	mimeType, err := sniffContentType(file)
+	if err != nil {
+		response.Error(w, r, er.BadRequest("invalid file"))
+		return
+	}
+	ownerID, _ := currentUser(r)
 	fileEntity := &entity.File{
 		ID:       uuid.New().String(),
 		Name:     header.Filename,
+		OwnerID:  ownerID,
 		Size:     header.Size,
-		MimeType: header.Header.Get("Content-Type"),
+		MimeType: mimeType,
 	}
 	if err := h.usecase.UploadServicePhoto(r.Context(), id, fileEntity, file); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		response.Error(w, r, err)
 		return
 	}
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(map[string]string{"file_id": fileEntity.ID})
+	json.NewEncoder(w).Encode(dto.FileUploadResponse{FileID: fileEntity.ID, Variants: dto.PhotoURLs(fileEntity.ID, h.usecase.PhotoURLs(r.Context(), fileEntity.ID))})
+}
+
+// PresignServicePhoto godoc
+// @Summary Presign a service photo upload
+// @Description Register a JPEG, PNG or WebP photo and get a presigned request uploading it to the storage directly. The storage accepts only the declared size, type and checksum. The photo is set once the upload is completed
+// @Tags services
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Service ID"
+// @Param file body dto.PresignUploadRequest true "Photo to upload"
+// @Success 200 {object} dto.PresignUploadResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 422 {object} response.ErrorResponse
+// @Failure 429 {object} response.ErrorResponse
+// @Failure 501 {object} response.ErrorResponse
+// @Router /services/{id}/photo/presign [post]
+func (h *ServiceHandler) PresignServicePhoto(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := uuid.Parse(vars["id"])
+	if err != nil {
+		response.Error(w, r, er.BadRequest("invalid ID"))
+		return
+	}
+	var req dto.PresignUploadRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+	ownerID, _ := currentUser(r)
+	file := req.ToEntity(ownerID)
+	request, err := h.usecase.PresignServicePhoto(r.Context(), id, file)
+	if err != nil {
+		if errors.Is(err, er.ErrRecordNotFound) {
+			err = er.NotFound("service not found")
+		}
+		response.Error(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(dto.NewPresignUploadResponse(file.ID, request))
+}
+
+// CompleteServicePhoto godoc
+// @Summary Complete a service photo upload
+// @Description Verify a presigned upload against its declaration and set it as the photo of the service, processed like an uploaded photo
+// @Tags services
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Service ID"
+// @Param upload body dto.CompleteUploadRequest true "Uploaded photo"
+// @Success 200 {object} dto.FileUploadResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 409 {object} response.ErrorResponse
+// @Failure 422 {object} response.ErrorResponse
+// @Router /services/{id}/photo/complete [post]
+func (h *ServiceHandler) CompleteServicePhoto(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := uuid.Parse(vars["id"])
+	if err != nil {
+		response.Error(w, r, er.BadRequest("invalid ID"))
+		return
+	}
+	var req dto.CompleteUploadRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+	ownerID, _ := currentUser(r)
+	file, err := h.usecase.CompleteServicePhoto(r.Context(), id, ownerID, req.FileID)
+	if err != nil {
+		if errors.Is(err, er.ErrRecordNotFound) {
+			err = er.NotFound("service not found")
+		}
+		response.Error(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(dto.FileUploadResponse{FileID: file.ID, Variants: dto.PhotoURLs(file.ID, h.usecase.PhotoURLs(r.Context(), file.ID))})
+}
+
+// serviceResponse links the photo of the service to its storage URLs.
+func (h *ServiceHandler) serviceResponse(r *http.Request, service *entity.Service) dto.ServiceResponse {
+	return dto.NewServiceResponse(service, h.usecase.PhotoURLs(r.Context(), service.PhotoURL))
 }
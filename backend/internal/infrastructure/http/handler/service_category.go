@@ -3,8 +3,9 @@ package handler
 import (
 	"encoding/json"
 	"errors"
-	"github.com/Vanv1k/BeautyTON/internal/domain/entity"
 	er "github.com/Vanv1k/BeautyTON/internal/domain/errors"
+	"github.com/Vanv1k/BeautyTON/internal/infrastructure/http/dto"
+	"github.com/Vanv1k/BeautyTON/internal/infrastructure/http/response"
 	"github.com/Vanv1k/BeautyTON/internal/usecase"
 	"github.com/google/uuid"
 	"github.com/gorilla/mux"
@@ -19,6 +20,16 @@ func NewServiceCategoryHandler(usecase *usecase.ServiceCategoryUsecase) *Service
 	return &ServiceCategoryHandler{usecase: usecase}
 }
 
+// RegisterRoutes registers the service category routes.
+func (h *ServiceCategoryHandler) RegisterRoutes(routes *Routes) {
+	// TODO: init db data
+	routes.API.HandleFunc("/service_categories/{id}", h.GetServiceCategory).Methods("GET", "OPTIONS")
+
+	routes.Admin.HandleFunc("/service_categories", h.CreateServiceCategory).Methods("POST", "OPTIONS")
+	routes.Admin.HandleFunc("/service_categories/{id}", h.UpdateServiceCategory).Methods("PUT", "OPTIONS")
+	routes.Admin.HandleFunc("/service_categories/{id}", h.DeleteServiceCategory).Methods("DELETE", "OPTIONS")
+}
+
 // GetServiceCategory godoc
 // @Summary Get a service category by ID
 // @Description Get service category details by service category ID
@@ -26,29 +37,28 @@ func NewServiceCategoryHandler(usecase *usecase.ServiceCategoryUsecase) *Service
 // @Accept  json
 // @Produce  json
 // @Param id path string true "Service Category ID"
-// @Success 200 {object} entity.ServiceCategory
-// @Failure 400 {object} map[string]string
-// @Failure 404 {object} map[string]string
-// @Failure 500 {object} map[string]string
+// @Success 200 {object} dto.ServiceCategoryResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
 // @Router /service_categories/{id} [get]
 func (h *ServiceCategoryHandler) GetServiceCategory(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	id, err := uuid.Parse(vars["id"])
 	if err != nil {
-		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		response.Error(w, r, er.BadRequest("invalid ID"))
 		return
 	}
 	category, err := h.usecase.GetServiceCategory(r.Context(), id)
 	if err != nil {
 		if errors.Is(err, er.ErrRecordNotFound) {
-			http.Error(w, "Service category not found", http.StatusNotFound)
-		} else {
-			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			err = er.NotFound("service category not found")
 		}
+		response.Error(w, r, err)
 		return
 	}
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(category)
+	json.NewEncoder(w).Encode(dto.NewServiceCategoryResponse(category))
 }
 
 // CreateServiceCategory godoc
@@ -57,23 +67,24 @@ func (h *ServiceCategoryHandler) GetServiceCategory(w http.ResponseWriter, r *ht
 // @Tags service-categories
 // @Accept  json
 // @Produce  json
-// @Param category body entity.ServiceCategory true "Create service category"
-// @Success 201 {object} entity.ServiceCategory
-// @Failure 400 {object} map[string]string
-// @Router /service_categories [post]
+// @Param category body dto.ServiceCategoryRequest true "Create service category"
+// @Success 201 {object} dto.ServiceCategoryResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Router /admin/service_categories [post]
 func (h *ServiceCategoryHandler) CreateServiceCategory(w http.ResponseWriter, r *http.Request) {
-	var category entity.ServiceCategory
-	if err := json.NewDecoder(r.Body).Decode(&category); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+	var req dto.ServiceCategoryRequest
+	if !decodeRequest(w, r, &req) {
 		return
 	}
-	if err := h.usecase.CreateServiceCategory(r.Context(), &category); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+	category := req.ToEntity()
+	if err := h.usecase.CreateServiceCategory(r.Context(), category); err != nil {
+		response.Error(w, r, err)
 		return
 	}
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
-	json.NewEncoder(w).Encode(category)
+	json.NewEncoder(w).Encode(dto.NewServiceCategoryResponse(category))
 }
 
 // UpdateServiceCategory godoc
@@ -83,29 +94,37 @@ func (h *ServiceCategoryHandler) CreateServiceCategory(w http.ResponseWriter, r
 // @Accept  json
 // @Produce  json
 // @Param id path string true "Service Category ID"
-// @Param category body entity.ServiceCategory true "Update service category"
-// @Success 200 {object} entity.ServiceCategory
-// @Failure 400 {object} map[string]string
-// @Router /service_categories/{id} [put]
+// @Param category body dto.ServiceCategoryRequest true "Update service category"
+// @Success 200 {object} dto.ServiceCategoryResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Router /admin/service_categories/{id} [put]
 func (h *ServiceCategoryHandler) UpdateServiceCategory(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	id, err := uuid.Parse(vars["id"])
 	if err != nil {
-		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		response.Error(w, r, er.BadRequest("invalid ID"))
+		return
+	}
+	var req dto.ServiceCategoryRequest
+	if !decodeRequest(w, r, &req) {
 		return
 	}
-	var category entity.ServiceCategory
-	if err := json.NewDecoder(r.Body).Decode(&category); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+	category, err := h.usecase.GetServiceCategory(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, er.ErrRecordNotFound) {
+			err = er.NotFound("service category not found")
+		}
+		response.Error(w, r, err)
 		return
 	}
-	category.ID = id
-	if err := h.usecase.UpdateServiceCategory(r.Context(), &category); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+	req.ApplyTo(category)
+	if err := h.usecase.UpdateServiceCategory(r.Context(), category); err != nil {
+		response.Error(w, r, err)
 		return
 	}
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(category)
+	json.NewEncoder(w).Encode(dto.NewServiceCategoryResponse(category))
 }
 
 // DeleteServiceCategory godoc
@@ -116,23 +135,23 @@ func (h *ServiceCategoryHandler) UpdateServiceCategory(w http.ResponseWriter, r
 // @Produce  json
 // @Param id path string true "Service Category ID"
 // @Success 204
-// @Failure 400 {object} map[string]string
-// @Failure 404 {object} map[string]string
-// @Failure 500 {object} map[string]string
-// @Router /service_categories/{id} [delete]
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Router /admin/service_categories/{id} [delete]
 func (h *ServiceCategoryHandler) DeleteServiceCategory(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	id, err := uuid.Parse(vars["id"])
 	if err != nil {
-		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		response.Error(w, r, er.BadRequest("invalid ID"))
 		return
 	}
 	if err := h.usecase.DeleteServiceCategory(r.Context(), id); err != nil {
 		if errors.Is(err, er.ErrRecordNotFound) {
-			http.Error(w, "Service category not found", http.StatusNotFound)
-		} else {
-			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			err = er.NotFound("service category not found")
 		}
+		response.Error(w, r, err)
 		return
 	}
 	w.WriteHeader(http.StatusNoContent)
@@ -8,8 +8,9 @@ import (
 	"github.com/google/uuid"
 	"github.com/gorilla/mux"
 
-	"github.com/Vanv1k/BeautyTON/internal/domain/entity"
 	er "github.com/Vanv1k/BeautyTON/internal/domain/errors"
+	"github.com/Vanv1k/BeautyTON/internal/infrastructure/http/dto"
+	"github.com/Vanv1k/BeautyTON/internal/infrastructure/http/response"
 	"github.com/Vanv1k/BeautyTON/internal/usecase"
 )
 
@@ -21,6 +22,14 @@ func NewSubscriptionHandler(usecase *usecase.SubscriptionUsecase) *SubscriptionH
 	return &SubscriptionHandler{usecase: usecase}
 }
 
+// RegisterRoutes registers the subscription routes.
+func (h *SubscriptionHandler) RegisterRoutes(routes *Routes) {
+	routes.API.HandleFunc("/subscriptions/{id}", h.GetSubscription).Methods("GET", "OPTIONS")
+	routes.API.HandleFunc("/subscriptions", h.CreateSubscription).Methods("POST", "OPTIONS")
+	routes.API.HandleFunc("/subscriptions/{id}", h.UpdateSubscription).Methods("PUT", "OPTIONS")
+	routes.API.HandleFunc("/subscriptions/{id}", h.DeleteSubscription).Methods("DELETE", "OPTIONS")
+}
+
 // GetSubscription godoc
 // @Summary Get a subscription by ID
 // @Description Get subscription details by subscription ID
@@ -28,29 +37,28 @@ func NewSubscriptionHandler(usecase *usecase.SubscriptionUsecase) *SubscriptionH
 // @Accept  json
 // @Produce  json
 // @Param id path string true "Subscription ID"
-// @Success 200 {object} entity.Subscription
-// @Failure 400 {object} map[string]string
-// @Failure 404 {object} map[string]string
-// @Failure 500 {object} map[string]string
+// @Success 200 {object} dto.SubscriptionResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
 // @Router /subscriptions/{id} [get]
 func (h *SubscriptionHandler) GetSubscription(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	id, err := uuid.Parse(vars["id"])
 	if err != nil {
-		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		response.Error(w, r, er.BadRequest("invalid ID"))
 		return
 	}
 	subscription, err := h.usecase.GetSubscription(r.Context(), id)
 	if err != nil {
 		if errors.Is(err, er.ErrRecordNotFound) {
-			http.Error(w, "Subscription not found", http.StatusNotFound)
-		} else {
-			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			err = er.NotFound("subscription not found")
 		}
+		response.Error(w, r, err)
 		return
 	}
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(subscription)
+	json.NewEncoder(w).Encode(dto.NewSubscriptionResponse(subscription))
 }
 
 // CreateSubscription godoc
@@ -59,23 +67,23 @@ func (h *SubscriptionHandler) GetSubscription(w http.ResponseWriter, r *http.Req
 // @Tags subscriptions
 // @Accept  json
 // @Produce  json
-// @Param subscription body entity.Subscription true "Create subscription"
-// @Success 201 {object} entity.Subscription
-// @Failure 400 {object} map[string]string
+// @Param subscription body dto.SubscriptionRequest true "Create subscription"
+// @Success 201 {object} dto.SubscriptionResponse
+// @Failure 400 {object} response.ErrorResponse
 // @Router /subscriptions [post]
 func (h *SubscriptionHandler) CreateSubscription(w http.ResponseWriter, r *http.Request) {
-	var subscription entity.Subscription
-	if err := json.NewDecoder(r.Body).Decode(&subscription); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+	var req dto.SubscriptionRequest
+	if !decodeRequest(w, r, &req) {
 		return
 	}
-	if err := h.usecase.CreateSubscription(r.Context(), &subscription); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+	subscription := req.ToEntity()
+	if err := h.usecase.CreateSubscription(r.Context(), subscription); err != nil {
+		response.Error(w, r, err)
 		return
 	}
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
-	json.NewEncoder(w).Encode(subscription)
+	json.NewEncoder(w).Encode(dto.NewSubscriptionResponse(subscription))
 }
 
 // UpdateSubscription godoc
@@ -85,29 +93,36 @@ func (h *SubscriptionHandler) CreateSubscription(w http.ResponseWriter, r *http.
 // @Accept  json
 // @Produce  json
 // @Param id path string true "Subscription ID"
-// @Param subscription body entity.Subscription true "Update subscription"
-// @Success 200 {object} entity.Subscription
-// @Failure 400 {object} map[string]string
+// @Param subscription body dto.SubscriptionRequest true "Update subscription"
+// @Success 200 {object} dto.SubscriptionResponse
+// @Failure 400 {object} response.ErrorResponse
 // @Router /subscriptions/{id} [put]
 func (h *SubscriptionHandler) UpdateSubscription(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	id, err := uuid.Parse(vars["id"])
 	if err != nil {
-		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		response.Error(w, r, er.BadRequest("invalid ID"))
+		return
+	}
+	var req dto.SubscriptionRequest
+	if !decodeRequest(w, r, &req) {
 		return
 	}
-	var subscription entity.Subscription
-	if err := json.NewDecoder(r.Body).Decode(&subscription); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+	subscription, err := h.usecase.GetSubscription(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, er.ErrRecordNotFound) {
+			err = er.NotFound("subscription not found")
+		}
+		response.Error(w, r, err)
 		return
 	}
-	subscription.ID = id
-	if err := h.usecase.UpdateSubscription(r.Context(), &subscription); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+	req.ApplyTo(subscription)
+	if err := h.usecase.UpdateSubscription(r.Context(), subscription); err != nil {
+		response.Error(w, r, err)
 		return
 	}
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(subscription)
+	json.NewEncoder(w).Encode(dto.NewSubscriptionResponse(subscription))
 }
 
 // DeleteSubscription godoc
@@ -118,23 +133,22 @@ func (h *SubscriptionHandler) UpdateSubscription(w http.ResponseWriter, r *http.
 // @Produce  json
 // @Param id path string true "Subscription ID"
 // @Success 204
-// @Failure 400 {object} map[string]string
-// @Failure 404 {object} map[string]string
-// @Failure 500 {object} map[string]string
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
 // @Router /subscriptions/{id} [delete]
 func (h *SubscriptionHandler) DeleteSubscription(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	id, err := uuid.Parse(vars["id"])
 	if err != nil {
-		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		response.Error(w, r, er.BadRequest("invalid ID"))
 		return
 	}
 	if err := h.usecase.DeleteSubscription(r.Context(), id); err != nil {
 		if errors.Is(err, er.ErrRecordNotFound) {
-			http.Error(w, "Subscription not found", http.StatusNotFound)
-		} else {
-			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			err = er.NotFound("subscription not found")
 		}
+		response.Error(w, r, err)
 		return
 	}
 	w.WriteHeader(http.StatusNoContent)
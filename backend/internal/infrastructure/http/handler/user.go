@@ -10,6 +10,8 @@ import (
 
 	"github.com/Vanv1k/BeautyTON/internal/domain/entity"
 	er "github.com/Vanv1k/BeautyTON/internal/domain/errors"
+	"github.com/Vanv1k/BeautyTON/internal/infrastructure/http/dto"
+	"github.com/Vanv1k/BeautyTON/internal/infrastructure/http/response"
 	"github.com/Vanv1k/BeautyTON/internal/usecase"
 )
 
@@ -21,6 +23,18 @@ func NewUserHandler(usecase *usecase.UserUsecase) *UserHandler {
 	return &UserHandler{usecase: usecase}
 }
 
+// RegisterRoutes registers the user routes.
+func (h *UserHandler) RegisterRoutes(routes *Routes) {
+	routes.API.HandleFunc("/users/{id}", h.GetUser).Methods("GET", "OPTIONS")
+	routes.API.HandleFunc("/users", h.CreateUser).Methods("POST", "OPTIONS")
+	routes.API.HandleFunc("/users/{id}", h.UpdateUser).Methods("PUT", "OPTIONS")
+	routes.API.HandleFunc("/users/{id}", h.PatchUser).Methods("PATCH", "OPTIONS")
+	routes.API.HandleFunc("/users/{id}", h.DeleteUser).Methods("DELETE", "OPTIONS")
+	routes.API.Handle("/users/{id}/photo", routes.RateLimit(entity.RateLimitGroupUploads)(http.HandlerFunc(h.UploadUserPhoto))).Methods("POST", "OPTIONS")
+	routes.API.Handle("/users/{id}/photo/presign", routes.RateLimit(entity.RateLimitGroupUploads)(http.HandlerFunc(h.PresignUserPhoto))).Methods("POST", "OPTIONS")
+	routes.API.HandleFunc("/users/{id}/photo/complete", h.CompleteUserPhoto).Methods("POST", "OPTIONS")
+}
+
 // GetUser godoc
 // @Summary Get a user by ID
 // @Description Get user details by user ID
@@ -28,29 +42,29 @@ func NewUserHandler(usecase *usecase.UserUsecase) *UserHandler {
 // @Accept  json
 // @Produce  json
 // @Param id path string true "User ID"
-// @Success 200 {object} entity.User
-// @Failure 400 {object} map[string]string
-// @Failure 404 {object} map[string]string
-// @Failure 500 {object} map[string]string
+// @Success 200 {object} dto.UserResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
 // @Router /users/{id} [get]
 func (h *UserHandler) GetUser(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	id, err := uuid.Parse(vars["id"])
 	if err != nil {
-		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		response.Error(w, r, er.BadRequest("invalid ID"))
 		return
 	}
 	user, err := h.usecase.GetUser(r.Context(), id)
 	if err != nil {
 		if errors.Is(err, er.ErrRecordNotFound) {
-			http.Error(w, "User not found", http.StatusNotFound)
-		} else {
-			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			err = er.NotFound("user not found")
 		}
+		response.Error(w, r, err)
 		return
 	}
+	setETag(w, user.Version)
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(user)
+	json.NewEncoder(w).Encode(h.userResponse(r, user))
 }
 
 // CreateUser godoc
@@ -59,23 +73,24 @@ func (h *UserHandler) GetUser(w http.ResponseWriter, r *http.Request) {
 // @Tags users
 // @Accept  json
 // @Produce  json
-// @Param user body entity.User true "Create user"
-// @Success 201 {object} entity.User
-// @Failure 400 {object} map[string]string
+// @Param user body dto.CreateUserRequest true "Create user"
+// @Success 201 {object} dto.UserResponse
+// @Failure 400 {object} response.ErrorResponse
 // @Router /users [post]
 func (h *UserHandler) CreateUser(w http.ResponseWriter, r *http.Request) {
-	var user entity.User
-	if err := json.NewDecoder(r.Body).Decode(&user); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+	var req dto.CreateUserRequest
+	if !decodeRequest(w, r, &req) {
 		return
 	}
-	if err := h.usecase.CreateUser(r.Context(), &user); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+	user := req.ToEntity()
+	if err := h.usecase.CreateUser(r.Context(), user); err != nil {
+		response.Error(w, r, err)
 		return
 	}
+	setETag(w, user.Version)
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
-	json.NewEncoder(w).Encode(user)
+	json.NewEncoder(w).Encode(h.userResponse(r, user))
 }
 
 // UpdateUser godoc
@@ -85,29 +100,91 @@ func (h *UserHandler) CreateUser(w http.ResponseWriter, r *http.Request) {
 // @Accept  json
 // @Produce  json
 // @Param id path string true "User ID"
-// @Param user body entity.User true "Update user"
-// @Success 200 {object} entity.User
-// @Failure 400 {object} map[string]string
+// @Param user body dto.UpdateUserRequest true "Update user"
+// @Param If-Match header string true "ETag of the version being updated"
+// @Success 200 {object} dto.UserResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 412 {object} response.ErrorResponse
+// @Failure 428 {object} response.ErrorResponse
 // @Router /users/{id} [put]
 func (h *UserHandler) UpdateUser(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	id, err := uuid.Parse(vars["id"])
 	if err != nil {
-		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		response.Error(w, r, er.BadRequest("invalid ID"))
+		return
+	}
+	var req dto.UpdateUserRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+	user, err := h.usecase.GetUser(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, er.ErrRecordNotFound) {
+			err = er.NotFound("user not found")
+		}
+		response.Error(w, r, err)
+		return
+	}
+	if !checkIfMatch(w, r, user.Version) {
+		return
+	}
+	req.ApplyTo(user)
+	if err := h.usecase.UpdateUser(r.Context(), user); err != nil {
+		response.Error(w, r, err)
+		return
+	}
+	setETag(w, user.Version)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(h.userResponse(r, user))
+}
+
+// PatchUser godoc
+// @Summary Partially update a user
+// @Description Apply a JSON Merge Patch (RFC 7386) to a user by user ID. Members set to null are cleared
+// @Tags users
+// @Accept  json
+// @Accept  application/merge-patch+json
+// @Produce  json
+// @Param id path string true "User ID"
+// @Param user body dto.UpdateUserRequest true "Fields to change"
+// @Param If-Match header string true "ETag of the version being updated"
+// @Success 200 {object} dto.UserResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 412 {object} response.ErrorResponse
+// @Failure 428 {object} response.ErrorResponse
+// @Router /users/{id} [patch]
+func (h *UserHandler) PatchUser(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := uuid.Parse(vars["id"])
+	if err != nil {
+		response.Error(w, r, er.BadRequest("invalid ID"))
+		return
+	}
+	user, err := h.usecase.GetUser(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, er.ErrRecordNotFound) {
+			err = er.NotFound("user not found")
+		}
+		response.Error(w, r, err)
 		return
 	}
-	var user entity.User
-	if err := json.NewDecoder(r.Body).Decode(&user); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+	if !checkIfMatch(w, r, user.Version) {
 		return
 	}
-	user.ID = id
-	if err := h.usecase.UpdateUser(r.Context(), &user); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+	req := dto.NewUpdateUserRequest(user)
+	if !decodePatch(w, r, &req) {
 		return
 	}
+	req.ApplyTo(user)
+	if err := h.usecase.UpdateUser(r.Context(), user); err != nil {
+		response.Error(w, r, err)
+		return
+	}
+	setETag(w, user.Version)
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(user)
+	json.NewEncoder(w).Encode(h.userResponse(r, user))
 }
 
 // DeleteUser godoc
@@ -118,23 +195,22 @@ func (h *UserHandler) UpdateUser(w http.ResponseWriter, r *http.Request) {
 // @Produce  json
 // @Param id path string true "User ID"
 // @Success 204
-// @Failure 400 {object} map[string]string
-// @Failure 404 {object} map[string]string
-// @Failure 500 {object} map[string]string
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
 // @Router /users/{id} [delete]
 func (h *UserHandler) DeleteUser(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	id, err := uuid.Parse(vars["id"])
 	if err != nil {
-		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		response.Error(w, r, er.BadRequest("invalid ID"))
 		return
 	}
 	if err := h.usecase.DeleteUser(r.Context(), id); err != nil {
 		if errors.Is(err, er.ErrRecordNotFound) {
-			http.Error(w, "User not found", http.StatusNotFound)
-		} else {
-			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			err = er.NotFound("user not found")
 		}
+		response.Error(w, r, err)
 		return
 	}
 	w.WriteHeader(http.StatusNoContent)
@@ -142,43 +218,137 @@ func (h *UserHandler) DeleteUser(w http.ResponseWriter, r *http.Request) {
 
 // UploadUserPhoto godoc
 // @Summary Upload user photo
-// @Description Upload a photo for a user
+// @Description Upload a JPEG, PNG or WebP photo for a user. It is stored upright, without metadata, in the thumb, card and full sizes
 // @Tags users
 // @Accept  multipart/form-data
 // @Produce  json
 // @Param id path string true "User ID"
 // @Param file formData file true "Photo file"
-// @Success 200 {object} map[string]string
-// @Failure 400 {object} map[string]string
+// @Success 200 {object} dto.FileUploadResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 422 {object} response.ErrorResponse
+// @Failure 429 {object} response.ErrorResponse
 // @Router /users/{id}/photo [post]
 func (h *UserHandler) UploadUserPhoto(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	id, err := uuid.Parse(vars["id"])
 	if err != nil {
-		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		response.Error(w, r, er.BadRequest("invalid ID"))
 		return
 	}
 	if err := r.ParseMultipartForm(10 << 20); err != nil { // 10 MB limit
-		http.Error(w, "File too large or invalid form", http.StatusBadRequest)
+		response.Error(w, r, er.BadRequest("file too large or invalid form"))
 		return
 	}
 	file, header, err := r.FormFile("file")
 	if err != nil {
-		http.Error(w, "Invalid file", http.StatusBadRequest)
+		response.Error(w, r, er.BadRequest("invalid file"))
 		return
 	}
 	defer file.Close()
+	mimeType, err := sniffContentType(file)
+	if err != nil {
+		response.Error(w, r, er.BadRequest("invalid file"))
+		return
+	}
+	ownerID, _ := currentUser(r)
 	fileEntity := &entity.File{
 		ID:       uuid.New().String(),
 		Name:     header.Filename,
+		OwnerID:  ownerID,
 		Size:     header.Size,
-		MimeType: header.Header.Get("Content-Type"),
+		MimeType: mimeType,
 	}
 	if err := h.usecase.UploadUserPhoto(r.Context(), id, fileEntity, file); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		response.Error(w, r, err)
 		return
 	}
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(map[string]string{"file_id": fileEntity.ID})
+	json.NewEncoder(w).Encode(dto.FileUploadResponse{FileID: fileEntity.ID, Variants: dto.PhotoURLs(fileEntity.ID, h.usecase.PhotoURLs(r.Context(), fileEntity.ID))})
+}
+
+// PresignUserPhoto godoc
+// @Summary Presign a user photo upload
+// @Description Register a JPEG, PNG or WebP photo and get a presigned request uploading it to the storage directly. The storage accepts only the declared size, type and checksum. The photo is set once the upload is completed
+// @Tags users
+// @Accept  json
+// @Produce  json
+// @Param id path string true "User ID"
+// @Param file body dto.PresignUploadRequest true "Photo to upload"
+// @Success 200 {object} dto.PresignUploadResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 422 {object} response.ErrorResponse
+// @Failure 429 {object} response.ErrorResponse
+// @Failure 501 {object} response.ErrorResponse
+// @Router /users/{id}/photo/presign [post]
+func (h *UserHandler) PresignUserPhoto(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := uuid.Parse(vars["id"])
+	if err != nil {
+		response.Error(w, r, er.BadRequest("invalid ID"))
+		return
+	}
+	var req dto.PresignUploadRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+	ownerID, _ := currentUser(r)
+	file := req.ToEntity(ownerID)
+	request, err := h.usecase.PresignUserPhoto(r.Context(), id, file)
+	if err != nil {
+		if errors.Is(err, er.ErrRecordNotFound) {
+			err = er.NotFound("user not found")
+		}
+		response.Error(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(dto.NewPresignUploadResponse(file.ID, request))
+}
+
+// CompleteUserPhoto godoc
+// @Summary Complete a user photo upload
+// @Description Verify a presigned upload against its declaration and set it as the photo of the user, processed like an uploaded photo
+// @Tags users
+// @Accept  json
+// @Produce  json
+// @Param id path string true "User ID"
+// @Param upload body dto.CompleteUploadRequest true "Uploaded photo"
+// @Success 200 {object} dto.FileUploadResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 409 {object} response.ErrorResponse
+// @Failure 422 {object} response.ErrorResponse
+// @Router /users/{id}/photo/complete [post]
+func (h *UserHandler) CompleteUserPhoto(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := uuid.Parse(vars["id"])
+	if err != nil {
+		response.Error(w, r, er.BadRequest("invalid ID"))
+		return
+	}
+	var req dto.CompleteUploadRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+	ownerID, _ := currentUser(r)
+	file, err := h.usecase.CompleteUserPhoto(r.Context(), id, ownerID, req.FileID)
+	if err != nil {
+		if errors.Is(err, er.ErrRecordNotFound) {
+			err = er.NotFound("user not found")
+		}
+		response.Error(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(dto.FileUploadResponse{FileID: file.ID, Variants: dto.PhotoURLs(file.ID, h.usecase.PhotoURLs(r.Context(), file.ID))})
+}
+
+// userResponse links the photo of the user to its storage URLs.
+func (h *UserHandler) userResponse(r *http.Request, user *entity.User) dto.UserResponse {
+	return dto.NewUserResponse(user, h.usecase.PhotoURLs(r.Context(), user.PhotoURL))
 }
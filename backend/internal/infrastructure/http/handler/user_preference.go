@@ -7,8 +7,9 @@ import (
 	"github.com/google/uuid"
 	"github.com/gorilla/mux"
 
-	"github.com/Vanv1k/BeautyTON/internal/domain/entity"
 	"github.com/Vanv1k/BeautyTON/internal/domain/errors"
+	"github.com/Vanv1k/BeautyTON/internal/infrastructure/http/dto"
+	"github.com/Vanv1k/BeautyTON/internal/infrastructure/http/response"
 	"github.com/Vanv1k/BeautyTON/internal/usecase"
 )
 
@@ -20,6 +21,14 @@ func NewUserPreferencesHandler(usecase *usecase.UserPreferencesUsecase) *UserPre
 	return &UserPreferencesHandler{usecase: usecase}
 }
 
+// RegisterRoutes registers the user preferences routes.
+func (h *UserPreferencesHandler) RegisterRoutes(routes *Routes) {
+	routes.API.HandleFunc("/user_preferences/{id}", h.GetUserPreferences).Methods("GET", "OPTIONS")
+	routes.API.HandleFunc("/user_preferences", h.CreateUserPreferences).Methods("POST", "OPTIONS")
+	routes.API.HandleFunc("/user_preferences/{id}", h.UpdateUserPreferences).Methods("PUT", "OPTIONS")
+	routes.API.HandleFunc("/user_preferences/{id}", h.DeleteUserPreferences).Methods("DELETE", "OPTIONS")
+}
+
 // GetUserPreferences godoc
 // @Summary Get user preferences by ID
 // @Description Get user preferences details by user preferences ID
@@ -27,29 +36,28 @@ func NewUserPreferencesHandler(usecase *usecase.UserPreferencesUsecase) *UserPre
 // @Accept  json
 // @Produce  json
 // @Param id path string true "User Preferences ID"
-// @Success 200 {object} entity.UserPreferences
-// @Failure 400 {object} map[string]string
-// @Failure 404 {object} map[string]string
-// @Failure 500 {object} map[string]string
+// @Success 200 {object} dto.UserPreferencesResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
 // @Router /user_preferences/{id} [get]
 func (h *UserPreferencesHandler) GetUserPreferences(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	id, err := uuid.Parse(vars["id"])
 	if err != nil {
-		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		response.Error(w, r, errors.BadRequest("invalid ID"))
 		return
 	}
 	preferences, err := h.usecase.GetUserPreferences(r.Context(), id)
 	if err != nil {
 		if err == errors.ErrRecordNotFound {
-			http.Error(w, "User preferences not found", http.StatusNotFound)
-		} else {
-			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			err = errors.NotFound("user preferences not found")
 		}
+		response.Error(w, r, err)
 		return
 	}
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(preferences)
+	json.NewEncoder(w).Encode(dto.NewUserPreferencesResponse(preferences))
 }
 
 // CreateUserPreferences godoc
@@ -58,23 +66,23 @@ func (h *UserPreferencesHandler) GetUserPreferences(w http.ResponseWriter, r *ht
 // @Tags user-preferences
 // @Accept  json
 // @Produce  json
-// @Param preferences body entity.UserPreferences true "Create user preferences"
-// @Success 201 {object} entity.UserPreferences
-// @Failure 400 {object} map[string]string
+// @Param preferences body dto.CreateUserPreferencesRequest true "Create user preferences"
+// @Success 201 {object} dto.UserPreferencesResponse
+// @Failure 400 {object} response.ErrorResponse
 // @Router /user_preferences [post]
 func (h *UserPreferencesHandler) CreateUserPreferences(w http.ResponseWriter, r *http.Request) {
-	var preferences entity.UserPreferences
-	if err := json.NewDecoder(r.Body).Decode(&preferences); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+	var req dto.CreateUserPreferencesRequest
+	if !decodeRequest(w, r, &req) {
 		return
 	}
-	if err := h.usecase.CreateUserPreferences(r.Context(), &preferences); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+	preferences := req.ToEntity()
+	if err := h.usecase.CreateUserPreferences(r.Context(), preferences); err != nil {
+		response.Error(w, r, err)
 		return
 	}
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
-	json.NewEncoder(w).Encode(preferences)
+	json.NewEncoder(w).Encode(dto.NewUserPreferencesResponse(preferences))
 }
 
 // UpdateUserPreferences godoc
@@ -84,29 +92,36 @@ func (h *UserPreferencesHandler) CreateUserPreferences(w http.ResponseWriter, r
 // @Accept  json
 // @Produce  json
 // @Param id path string true "User Preferences ID"
-// @Param preferences body entity.UserPreferences true "Update user preferences"
-// @Success 200 {object} entity.UserPreferences
-// @Failure 400 {object} map[string]string
+// @Param preferences body dto.UpdateUserPreferencesRequest true "Update user preferences"
+// @Success 200 {object} dto.UserPreferencesResponse
+// @Failure 400 {object} response.ErrorResponse
 // @Router /user_preferences/{id} [put]
 func (h *UserPreferencesHandler) UpdateUserPreferences(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	id, err := uuid.Parse(vars["id"])
 	if err != nil {
-		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		response.Error(w, r, errors.BadRequest("invalid ID"))
+		return
+	}
+	var req dto.UpdateUserPreferencesRequest
+	if !decodeRequest(w, r, &req) {
 		return
 	}
-	var preferences entity.UserPreferences
-	if err := json.NewDecoder(r.Body).Decode(&preferences); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+	preferences, err := h.usecase.GetUserPreferences(r.Context(), id)
+	if err != nil {
+		if err == errors.ErrRecordNotFound {
+			err = errors.NotFound("user preferences not found")
+		}
+		response.Error(w, r, err)
 		return
 	}
-	preferences.ID = id
-	if err := h.usecase.UpdateUserPreferences(r.Context(), &preferences); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+	req.ApplyTo(preferences)
+	if err := h.usecase.UpdateUserPreferences(r.Context(), preferences); err != nil {
+		response.Error(w, r, err)
 		return
 	}
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(preferences)
+	json.NewEncoder(w).Encode(dto.NewUserPreferencesResponse(preferences))
 }
 
 // DeleteUserPreferences godoc
@@ -117,23 +132,22 @@ func (h *UserPreferencesHandler) UpdateUserPreferences(w http.ResponseWriter, r
 // @Produce  json
 // @Param id path string true "User Preferences ID"
 // @Success 204
-// @Failure 400 {object} map[string]string
-// @Failure 404 {object} map[string]string
-// @Failure 500 {object} map[string]string
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
 // @Router /user_preferences/{id} [delete]
 func (h *UserPreferencesHandler) DeleteUserPreferences(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	id, err := uuid.Parse(vars["id"])
 	if err != nil {
-		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		response.Error(w, r, errors.BadRequest("invalid ID"))
 		return
 	}
 	if err := h.usecase.DeleteUserPreferences(r.Context(), id); err != nil {
 		if err == errors.ErrRecordNotFound {
-			http.Error(w, "User preferences not found", http.StatusNotFound)
-		} else {
-			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			err = errors.NotFound("user preferences not found")
 		}
+		response.Error(w, r, err)
 		return
 	}
 	w.WriteHeader(http.StatusNoContent)
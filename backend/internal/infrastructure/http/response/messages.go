@@ -0,0 +1,150 @@
+package response
+
+import (
+	"net/http"
+	"strings"
+)
+
+// translations maps a language to translations of English error messages.
+// Messages without a translation are returned in English.
+var translations = map[string]map[string]string{
+	"ru": {
+		"internal server error":                             "внутренняя ошибка сервера",
+		"record not found":                                  "запись не найдена",
+		"access denied":                                     "доступ запрещен",
+		"model bookings limit reached":                      "лимит записей моделей исчерпан",
+		"resource was modified by another request":          "ресурс был изменен другим запросом",
+		"If-Match header is required":                       "требуется заголовок If-Match",
+		"range not satisfiable":                             "запрошенный диапазон недоступен",
+		"storage does not support presigned requests":       "хранилище не поддерживает подписанные запросы",
+		"invalid idempotency key":                           "некорректный ключ идемпотентности",
+		"idempotency key was used with a different request": "ключ идемпотентности использован с другим запросом",
+		"request with this idempotency key is in progress":  "запрос с этим ключом идемпотентности еще выполняется",
+		"invalid access token":                              "некорректный токен доступа",
+		"invalid refresh token":                             "некорректный токен обновления",
+		"session revoked":                                   "сессия отозвана",
+		"refresh token reused, session revoked":             "токен обновления использован повторно, сессия отозвана",
+		"request is not authenticated with a session":       "запрос не аутентифицирован сессией",
+		"invalid request body":                              "некорректное тело запроса",
+		"validation failed":                                 "ошибка валидации",
+		"invalid ID":                                        "некорректный идентификатор",
+		"invalid page number":                               "некорректный номер страницы",
+		"invalid page size":                                 "некорректный размер страницы",
+		"invalid cursor":                                    "некорректный курсор",
+		"cursor does not match sort":                        "курсор не соответствует сортировке",
+		"cursor does not match filter":                      "курсор не соответствует фильтрам",
+		"invalid sort":                                      "некорректная сортировка",
+		"invalid file":                                      "некорректный файл",
+		"file too large or invalid form":                    "файл слишком большой или форма некорректна",
+		"invalid variant":                                   "некорректный размер изображения",
+		"unsupported image format":                          "неподдерживаемый формат изображения",
+		"image is too large":                                "изображение слишком большое",
+		"invalid image":                                     "некорректное изображение",
+		"file is too large":                                 "файл слишком большой",
+		"invalid checksum":                                  "некорректная контрольная сумма",
+		"file upload is already completed":                  "загрузка файла уже завершена",
+		"file is not uploaded":                              "файл не загружен",
+		"uploaded file does not match":                      "загруженный файл не соответствует заявленному",
+
+		"user not found":                         "пользователь не найден",
+		"user preferences not found":             "настройки пользователя не найдены",
+		"master profile not found":               "профиль мастера не найден",
+		"service not found":                      "услуга не найдена",
+		"service category not found":             "категория услуг не найдена",
+		"booking not found":                      "запись не найдена",
+		"schedule slot not found":                "слот расписания не найден",
+		"review not found":                       "отзыв не найден",
+		"payment not found":                      "платеж не найден",
+		"subscription not found":                 "подписка не найдена",
+		"my master not found":                    "мастер не найден в списке",
+		"city not found":                         "город не найден",
+		"country not found":                      "страна не найдена",
+		"promotion not found":                    "кампания продвижения не найдена",
+		"file not found":                         "файл не найден",
+		"master profile not found for this user": "профиль мастера для этого пользователя не найден",
+
+		"cannot update slot for another master":  "нельзя изменить слот другого мастера",
+		"cannot delete slot for another master":  "нельзя удалить слот другого мастера",
+		"unauthorized: Telegram user ID missing": "не авторизован: отсутствует Telegram ID пользователя",
+		"authorization header missing":           "отсутствует заголовок авторизации",
+		"invalid authorization header format":    "некорректный формат заголовка авторизации",
+		"invalid init data":                      "некорректные данные инициализации",
+		"user not registered":                    "пользователь не зарегистрирован",
+		"insufficient permissions":               "недостаточно прав",
+
+		"invalid user role":                                          "некорректная роль пользователя",
+		"username cannot be empty":                                   "имя пользователя не может быть пустым",
+		"user must have master role":                                 "пользователь должен быть мастером",
+		"client_id must refer to a client":                           "client_id должен указывать на клиента",
+		"master_id must refer to a master":                           "master_id должен указывать на мастера",
+		"user_id must refer to a master":                             "user_id должен указывать на мастера",
+		"booking_time must be in the future":                         "время записи должно быть в будущем",
+		"invalid booking status":                                     "некорректный статус записи",
+		"invalid payment status":                                     "некорректный статус платежа",
+		"invalid payment type":                                       "некорректный тип платежа",
+		"too many requests":                                          "слишком много запросов",
+		"unsupported currency":                                       "валюта не поддерживается",
+		"amount must be positive":                                    "сумма должна быть положительной",
+		"invalid booking_id":                                         "некорректный booking_id",
+		"client_id does not match booking":                           "client_id не совпадает с клиентом записи",
+		"master_id does not match booking":                           "master_id не совпадает с мастером записи",
+		"booking is free":                                            "запись бесплатная",
+		"amount does not match booking price":                        "сумма не совпадает с ценой записи",
+		"invalid promotion_id":                                       "некорректный promotion_id",
+		"payment cannot be for both a booking and a promotion":       "платеж не может относиться к записи и кампании одновременно",
+		"promotion must be paid in TON":                              "кампания оплачивается в TON",
+		"amount does not match promotion budget":                     "сумма не совпадает с бюджетом кампании",
+		"promotion is not awaiting payment":                          "кампания не ожидает оплаты",
+		"price cannot be negative":                                   "цена не может быть отрицательной",
+		"rating must be between 0 and 5":                             "рейтинг должен быть от 0 до 5",
+		"rating must be between 1 and 5":                             "оценка должна быть от 1 до 5",
+		"service has no model offer":                                 "у услуги нет предложения для моделей",
+		"service must belong to the master":                          "услуга должна принадлежать мастеру",
+		"model booking requires portfolio_consent":                   "запись модели требует согласия на портфолио",
+		"model slot can only hold a model booking":                   "слот для моделей может содержать только запись модели",
+		"slot overlaps with existing booked, busy, or reserved slot": "слот пересекается с занятым или зарезервированным слотом",
+		"end_time must be after start_time":                          "время окончания должно быть позже времени начала",
+		"ends_at must be after starts_at":                            "дата окончания должна быть позже даты начала",
+		"ends_at must be in the future":                              "дата окончания должна быть в будущем",
+		"budget_ton must be positive":                                "бюджет должен быть положительным",
+		"only pending or active promotions can be canceled":          "отменить можно только ожидающую оплаты или активную кампанию",
+		"sorting by relevance requires a query":                      "сортировка по релевантности требует поискового запроса",
+		"sorting by distance requires lat and lon":                   "сортировка по расстоянию требует координат",
+		"latitude and longitude must be set together":                "широта и долгота должны быть указаны вместе",
+		"latitude must be between -90 and 90":                        "широта должна быть от -90 до 90",
+		"longitude must be between -180 and 180":                     "долгота должна быть от -180 до 180",
+		"invalid admin_id":                                           "неверный admin_id",
+		"invalid status":                                             "неверный статус",
+		"user is banned":                                             "пользователь заблокирован",
+		"user is suspended":                                          "пользователь временно заблокирован",
+		"photo not found":                                            "фото не найдено",
+		"cannot block yourself":                                      "нельзя заблокировать самого себя",
+		"cannot change your own role":                                "нельзя изменить собственную роль",
+		"invalid moderation status":                                  "неверный статус модерации",
+		"reason is required to reject":                               "для отклонения нужно указать причину",
+		"suspension must end in the future":                          "блокировка должна заканчиваться в будущем",
+		"invalid action":                                             "неверное действие",
+		"invalid actor_id":                                           "неверный actor_id",
+		"invalid from":                                               "неверное значение from",
+		"invalid to":                                                 "неверное значение to",
+	},
+}
+
+// Localize translates message into the first supported language of the
+// request's Accept-Language header.
+func Localize(r *http.Request, message string) string {
+	for _, tag := range strings.Split(r.Header.Get("Accept-Language"), ",") {
+		lang := strings.ToLower(strings.TrimSpace(strings.SplitN(tag, ";", 2)[0]))
+		lang = strings.SplitN(lang, "-", 2)[0]
+		if lang == "en" {
+			return message
+		}
+		if messages, ok := translations[lang]; ok {
+			if translated, ok := messages[message]; ok {
+				return translated
+			}
+			return message
+		}
+	}
+	return message
+}
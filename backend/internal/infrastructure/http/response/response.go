@@ -0,0 +1,74 @@
+package response
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	er "github.com/Vanv1k/BeautyTON/internal/domain/errors"
+)
+
+// ErrorBody is the payload of every error response.
+type ErrorBody struct {
+	Code      er.Code     `json:"code" swaggertype:"string" example:"not_found"`
+	Message   string      `json:"message"`
+	Details   interface{} `json:"details,omitempty"`
+	RequestID string      `json:"request_id,omitempty"`
+}
+
+type ErrorResponse struct {
+	Error ErrorBody `json:"error"`
+}
+
+var statuses = map[er.Code]int{
+	er.CodeBadRequest:           http.StatusBadRequest,
+	er.CodeValidation:           http.StatusBadRequest,
+	er.CodeUnauthorized:         http.StatusUnauthorized,
+	er.CodePaymentRequired:      http.StatusPaymentRequired,
+	er.CodeForbidden:            http.StatusForbidden,
+	er.CodeNotFound:             http.StatusNotFound,
+	er.CodeConflict:             http.StatusConflict,
+	er.CodePreconditionFailed:   http.StatusPreconditionFailed,
+	er.CodePreconditionRequired: http.StatusPreconditionRequired,
+	er.CodeUnprocessable:        http.StatusUnprocessableEntity,
+	er.CodeTooManyRequests:      http.StatusTooManyRequests,
+	er.CodeInternal:             http.StatusInternalServerError,
+	er.CodeNotImplemented:       http.StatusNotImplemented,
+	er.CodeRangeNotSatisfiable:  http.StatusRequestedRangeNotSatisfiable,
+}
+
+// Status returns the HTTP status for a domain error code.
+func Status(code er.Code) int {
+	if status, ok := statuses[code]; ok {
+		return status
+	}
+	return http.StatusInternalServerError
+}
+
+// JSON writes v as a JSON response with the given status.
+func JSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// Error writes err as a JSON error response. Domain errors are mapped to
+// their status and a message in the client's language; anything else is
+// logged and reported as an internal error without leaking its text.
+func Error(w http.ResponseWriter, r *http.Request, err error) {
+	e, ok := er.As(err)
+	if !ok {
+		slog.ErrorContext(r.Context(), "request failed", "method", r.Method, "path", r.URL.Path, "error", err)
+		e = er.New(er.CodeInternal, "internal server error")
+	} else if e.Err != nil {
+		slog.ErrorContext(r.Context(), "request failed", "method", r.Method, "path", r.URL.Path, "error", e)
+	}
+
+	requestID, _ := r.Context().Value("request_id").(string)
+	JSON(w, Status(e.Code), ErrorResponse{Error: ErrorBody{
+		Code:      e.Code,
+		Message:   Localize(r, e.Message),
+		Details:   e.Details,
+		RequestID: requestID,
+	}})
+}
@@ -0,0 +1,55 @@
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Vanv1k/BeautyTON/internal/domain/entity"
+	"github.com/Vanv1k/BeautyTON/internal/infrastructure/database/memory"
+	"github.com/Vanv1k/BeautyTON/internal/infrastructure/http/handler"
+	"github.com/Vanv1k/BeautyTON/internal/usecase"
+)
+
+// pingModule serves an authenticated route.
+type pingModule struct{}
+
+func (pingModule) RegisterRoutes(routes *handler.Routes) {
+	routes.API.HandleFunc("/ping", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}).Methods("GET")
+}
+
+func TestUnauthenticatedRequestsAreLimitedByIP(t *testing.T) {
+	router := NewRouter(Options{
+		RateLimitUsecase: usecase.NewRateLimitUsecase(memory.NewRateLimitRepository(), map[string]entity.RateLimit{
+			entity.RateLimitGroupIP:      {Requests: 2, Period: time.Minute},
+			entity.RateLimitGroupDefault: {Requests: 100, Period: time.Minute},
+		}),
+	}, Version{Prefix: "/v1", Modules: []handler.RouteRegistrar{pingModule{}}})
+
+	get := func(path, remoteAddr string) int {
+		r := httptest.NewRequest("GET", path, nil)
+		r.RemoteAddr = remoteAddr
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, r)
+		return w.Code
+	}
+
+	// Requests without credentials count before authentication rejects them
+	for i := 0; i < 2; i++ {
+		if code := get("/v1/ping", "192.0.2.1:1234"); code != http.StatusUnauthorized {
+			t.Fatalf("request %d returned %d, want 401", i+1, code)
+		}
+	}
+	if code := get("/v1/ping", "192.0.2.1:1234"); code != http.StatusTooManyRequests {
+		t.Fatalf("request over the IP limit returned %d, want 429", code)
+	}
+	if code := get("/swagger/index.html", "192.0.2.1:5678"); code != http.StatusTooManyRequests {
+		t.Fatalf("docs request over the IP limit returned %d, want 429", code)
+	}
+	if code := get("/v1/ping", "192.0.2.2:1234"); code != http.StatusUnauthorized {
+		t.Fatalf("request from another IP returned %d, want 401", code)
+	}
+}
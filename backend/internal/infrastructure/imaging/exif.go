@@ -0,0 +1,157 @@
+package imaging
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+)
+
+const exifOrientationTag = 0x0112
+
+var exifHeader = []byte("Exif\x00\x00")
+
+// exifOrientation returns the EXIF orientation of a JPEG or WebP photo, 1
+// (upright) when it has none or it cannot be read.
+func exifOrientation(format string, data []byte) int {
+	var tiff []byte
+	switch format {
+	case "jpeg":
+		tiff = jpegExif(data)
+	case "webp":
+		tiff = webpExif(data)
+	}
+	return tiffOrientation(tiff)
+}
+
+// jpegExif finds the APP1 segment holding EXIF, it precedes the image data.
+func jpegExif(data []byte) []byte {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return nil
+	}
+	for i := 2; i+4 <= len(data); {
+		if data[i] != 0xFF {
+			return nil
+		}
+		marker := data[i+1]
+		switch {
+		case marker == 0xFF:
+			// Заполняющий байт перед маркером
+			i++
+			continue
+		case marker == 0x01 || (marker >= 0xD0 && marker <= 0xD7):
+			// Маркеры без длины
+			i += 2
+			continue
+		case marker == 0xDA || marker == 0xD9:
+			return nil
+		}
+		length := int(binary.BigEndian.Uint16(data[i+2:]))
+		if length < 2 || i+2+length > len(data) {
+			return nil
+		}
+		segment := data[i+4 : i+2+length]
+		if marker == 0xE1 && bytes.HasPrefix(segment, exifHeader) {
+			return segment[len(exifHeader):]
+		}
+		i += 2 + length
+	}
+	return nil
+}
+
+// webpExif finds the EXIF chunk of a RIFF WebP container.
+func webpExif(data []byte) []byte {
+	if len(data) < 12 || string(data[0:4]) != "RIFF" || string(data[8:12]) != "WEBP" {
+		return nil
+	}
+	for i := 12; i+8 <= len(data); {
+		size := int(binary.LittleEndian.Uint32(data[i+4:]))
+		if size > len(data)-i-8 {
+			return nil
+		}
+		if string(data[i:i+4]) == "EXIF" {
+			// Некоторые кодировщики оставляют заголовок JPEG
+			return bytes.TrimPrefix(data[i+8:i+8+size], exifHeader)
+		}
+		// Чанки выровнены по четной границе
+		i += 8 + size + size&1
+	}
+	return nil
+}
+
+// tiffOrientation reads the orientation tag from the first IFD of EXIF
+// data, which is laid out as TIFF.
+func tiffOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 1
+	}
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+	if order.Uint16(tiff[2:]) != 42 {
+		return 1
+	}
+	offset := order.Uint32(tiff[4:])
+	if offset < 8 || uint64(offset)+2 > uint64(len(tiff)) {
+		return 1
+	}
+	ifd := int(offset)
+	entries := int(order.Uint16(tiff[ifd:]))
+	for k := 0; k < entries; k++ {
+		entry := ifd + 2 + 12*k
+		if entry+12 > len(tiff) {
+			return 1
+		}
+		if order.Uint16(tiff[entry:]) != exifOrientationTag {
+			continue
+		}
+		// Значение типа SHORT хранится в начале поля
+		value := int(order.Uint16(tiff[entry+8:]))
+		if order.Uint16(tiff[entry+2:]) != 3 || value < 1 || value > 8 {
+			return 1
+		}
+		return value
+	}
+	return 1
+}
+
+// orient turns an image upright according to its EXIF orientation.
+func orient(src *image.NRGBA, orientation int) *image.NRGBA {
+	if orientation < 2 || orientation > 8 {
+		return src
+	}
+	w, h := src.Rect.Dx(), src.Rect.Dy()
+	dw, dh := w, h
+	if orientation >= 5 {
+		dw, dh = h, w
+	}
+	dst := image.NewNRGBA(image.Rect(0, 0, dw, dh))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			var dx, dy int
+			switch orientation {
+			case 2: // отражение по горизонтали
+				dx, dy = w-1-x, y
+			case 3: // поворот на 180°
+				dx, dy = w-1-x, h-1-y
+			case 4: // отражение по вертикали
+				dx, dy = x, h-1-y
+			case 5: // транспонирование
+				dx, dy = y, x
+			case 6: // поворот на 90° по часовой
+				dx, dy = h-1-y, x
+			case 7: // поперечное отражение
+				dx, dy = h-1-y, w-1-x
+			case 8: // поворот на 90° против часовой
+				dx, dy = y, w-1-x
+			}
+			copy(dst.Pix[dst.PixOffset(dx, dy):][:4], src.Pix[src.PixOffset(x, y):][:4])
+		}
+	}
+	return dst
+}
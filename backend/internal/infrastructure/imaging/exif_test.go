@@ -0,0 +1,219 @@
+package imaging
+
+import (
+	"encoding/binary"
+	"image"
+	"image/color"
+	"testing"
+)
+
+// exifTIFF builds EXIF data whose first IFD holds the orientation tag only.
+func exifTIFF(order binary.ByteOrder, orientation int) []byte {
+	tiff := make([]byte, 8+2+12+4)
+	if order == binary.LittleEndian {
+		copy(tiff, "II")
+	} else {
+		copy(tiff, "MM")
+	}
+	order.PutUint16(tiff[2:], 42)
+	order.PutUint32(tiff[4:], 8)
+	order.PutUint16(tiff[8:], 1)
+	order.PutUint16(tiff[10:], exifOrientationTag)
+	order.PutUint16(tiff[12:], 3)
+	order.PutUint32(tiff[14:], 1)
+	order.PutUint16(tiff[18:], uint16(orientation))
+	return tiff
+}
+
+// jpegWithExif puts an APP1 segment holding tiff after the start of image
+// marker of a JPEG.
+func jpegWithExif(jpeg, tiff []byte) []byte {
+	payload := append(append([]byte(nil), exifHeader...), tiff...)
+	segment := []byte{0xFF, 0xE1, 0, 0}
+	binary.BigEndian.PutUint16(segment[2:], uint16(len(payload)+2))
+	data := append([]byte(nil), jpeg[:2]...)
+	data = append(data, segment...)
+	data = append(data, payload...)
+	return append(data, jpeg[2:]...)
+}
+
+// webpWithExif builds a RIFF WebP container holding an empty VP8L chunk and
+// an EXIF chunk.
+func webpWithExif(tiff []byte) []byte {
+	chunk := func(id string, payload []byte) []byte {
+		header := make([]byte, 8)
+		copy(header, id)
+		binary.LittleEndian.PutUint32(header[4:], uint32(len(payload)))
+		data := append(header, payload...)
+		if len(payload)%2 == 1 {
+			data = append(data, 0)
+		}
+		return data
+	}
+	body := append([]byte("WEBP"), chunk("VP8L", []byte{0x2F})...)
+	body = append(body, chunk("EXIF", tiff)...)
+	header := make([]byte, 8)
+	copy(header, "RIFF")
+	binary.LittleEndian.PutUint32(header[4:], uint32(len(body)))
+	return append(header, body...)
+}
+
+// emptyJPEG is the start and end of image markers only, the parser does not
+// look further.
+var emptyJPEG = []byte{0xFF, 0xD8, 0xFF, 0xD9}
+
+func TestExifOrientation(t *testing.T) {
+	for orientation := 1; orientation <= 8; orientation++ {
+		for _, order := range []binary.ByteOrder{binary.LittleEndian, binary.BigEndian} {
+			tiff := exifTIFF(order, orientation)
+			if got := exifOrientation("jpeg", jpegWithExif(emptyJPEG, tiff)); got != orientation {
+				t.Errorf("JPEG %v orientation %d: got %d", order, orientation, got)
+			}
+			if got := exifOrientation("webp", webpWithExif(tiff)); got != orientation {
+				t.Errorf("WebP %v orientation %d: got %d", order, orientation, got)
+			}
+			prefixed := append(append([]byte(nil), exifHeader...), tiff...)
+			if got := exifOrientation("webp", webpWithExif(prefixed)); got != orientation {
+				t.Errorf("WebP with a JPEG EXIF header %v orientation %d: got %d", order, orientation, got)
+			}
+		}
+	}
+	if got := exifOrientation("png", exifTIFF(binary.BigEndian, 6)); got != 1 {
+		t.Errorf("PNG: got %d, want 1", got)
+	}
+}
+
+func TestMalformedExifFallsBackToUpright(t *testing.T) {
+	valid := exifTIFF(binary.BigEndian, 6)
+	with := func(mutate func(tiff []byte)) []byte {
+		tiff := append([]byte(nil), valid...)
+		mutate(tiff)
+		return tiff
+	}
+
+	tiffs := []struct {
+		name string
+		tiff []byte
+	}{
+		{name: "empty"},
+		{name: "header only", tiff: valid[:8]},
+		{name: "unknown byte order", tiff: with(func(tiff []byte) { copy(tiff, "XX") })},
+		{name: "wrong magic", tiff: with(func(tiff []byte) { binary.BigEndian.PutUint16(tiff[2:], 43) })},
+		{name: "IFD inside the header", tiff: with(func(tiff []byte) { binary.BigEndian.PutUint32(tiff[4:], 4) })},
+		{name: "IFD past the end", tiff: with(func(tiff []byte) { binary.BigEndian.PutUint32(tiff[4:], 0xFFFFFFFF) })},
+		{name: "more entries than data", tiff: with(func(tiff []byte) { binary.BigEndian.PutUint16(tiff[8:], 0xFFFF) })[:20]},
+		{name: "entry cut short", tiff: valid[:16]},
+		{name: "wrong type", tiff: with(func(tiff []byte) { binary.BigEndian.PutUint16(tiff[12:], 4) })},
+		{name: "orientation 0", tiff: with(func(tiff []byte) { binary.BigEndian.PutUint16(tiff[18:], 0) })},
+		{name: "orientation 9", tiff: with(func(tiff []byte) { binary.BigEndian.PutUint16(tiff[18:], 9) })},
+	}
+	for _, tt := range tiffs {
+		t.Run("TIFF "+tt.name, func(t *testing.T) {
+			if got := tiffOrientation(tt.tiff); got != 1 {
+				t.Fatalf("got %d, want 1", got)
+			}
+		})
+	}
+
+	jpeg := jpegWithExif(emptyJPEG, valid)
+	jpegs := []struct {
+		name string
+		data []byte
+	}{
+		{name: "no start of image", data: jpeg[2:]},
+		{name: "garbage after start of image", data: []byte{0xFF, 0xD8, 0x00, 0x00, 0x00}},
+		{name: "segment length past the end", data: []byte{0xFF, 0xD8, 0xFF, 0xE1, 0xFF, 0xFF, 'E', 'x'}},
+		{name: "segment length below 2", data: []byte{0xFF, 0xD8, 0xFF, 0xE1, 0x00, 0x01, 0xFF, 0xD9}},
+		{name: "APP1 cut short", data: jpegWithExif(emptyJPEG, nil)[:8]},
+		{name: "APP1 without EXIF header", data: []byte{0xFF, 0xD8, 0xFF, 0xE1, 0x00, 0x04, 'X', 'Y', 0xFF, 0xD9}},
+		{name: "EXIF after start of scan", data: append([]byte{0xFF, 0xD8, 0xFF, 0xDA}, jpeg[2:]...)},
+		{name: "fill bytes only", data: []byte{0xFF, 0xD8, 0xFF, 0xFF, 0xFF, 0xFF}},
+	}
+	for _, tt := range jpegs {
+		t.Run("JPEG "+tt.name, func(t *testing.T) {
+			if got := exifOrientation("jpeg", tt.data); got != 1 {
+				t.Fatalf("got %d, want 1", got)
+			}
+		})
+	}
+
+	webp := webpWithExif(valid)
+	webps := []struct {
+		name string
+		data []byte
+	}{
+		{name: "not RIFF", data: append([]byte("RIFX"), webp[4:]...)},
+		{name: "not WebP", data: append(append(append([]byte(nil), webp[:8]...), "WAVE"...), webp[12:]...)},
+		{name: "chunk size past the end", data: func() []byte {
+			data := append([]byte(nil), webp...)
+			binary.LittleEndian.PutUint32(data[16:], 0xFFFFFFF0)
+			return data
+		}()},
+		{name: "chunk header cut short", data: webp[:16]},
+	}
+	for _, tt := range webps {
+		t.Run("WebP "+tt.name, func(t *testing.T) {
+			if got := exifOrientation("webp", tt.data); got != 1 {
+				t.Fatalf("got %d, want 1", got)
+			}
+		})
+	}
+
+	// Cutting a file anywhere must not panic, the orientation is either
+	// read in full or not at all
+	for _, file := range []struct {
+		format string
+		data   []byte
+	}{{"jpeg", jpeg}, {"webp", webp}} {
+		for n := range file.data {
+			if got := exifOrientation(file.format, file.data[:n]); got != 1 && got != 6 {
+				t.Fatalf("%s cut at %d bytes: got %d", file.format, n, got)
+			}
+		}
+	}
+}
+
+func TestOrient(t *testing.T) {
+	// A 3x2 photo with a color in each corner
+	topLeft := color.NRGBA{R: 255, A: 255}
+	topRight := color.NRGBA{G: 255, A: 255}
+	bottomLeft := color.NRGBA{B: 255, A: 255}
+	bottomRight := color.NRGBA{R: 255, G: 255, A: 255}
+	src := image.NewNRGBA(image.Rect(0, 0, 3, 2))
+	src.SetNRGBA(0, 0, topLeft)
+	src.SetNRGBA(2, 0, topRight)
+	src.SetNRGBA(0, 1, bottomLeft)
+	src.SetNRGBA(2, 1, bottomRight)
+
+	tests := []struct {
+		orientation int
+		width       int
+		height      int
+		// Corners of the stored photo that end up at the top of the
+		// upright one
+		topLeft  color.NRGBA
+		topRight color.NRGBA
+	}{
+		{orientation: 1, width: 3, height: 2, topLeft: topLeft, topRight: topRight},
+		{orientation: 2, width: 3, height: 2, topLeft: topRight, topRight: topLeft},
+		{orientation: 3, width: 3, height: 2, topLeft: bottomRight, topRight: bottomLeft},
+		{orientation: 4, width: 3, height: 2, topLeft: bottomLeft, topRight: bottomRight},
+		{orientation: 5, width: 2, height: 3, topLeft: topLeft, topRight: bottomLeft},
+		{orientation: 6, width: 2, height: 3, topLeft: bottomLeft, topRight: topLeft},
+		{orientation: 7, width: 2, height: 3, topLeft: bottomRight, topRight: topRight},
+		{orientation: 8, width: 2, height: 3, topLeft: topRight, topRight: bottomRight},
+	}
+	for _, tt := range tests {
+		dst := orient(src, tt.orientation)
+		if dst.Rect.Dx() != tt.width || dst.Rect.Dy() != tt.height {
+			t.Errorf("orientation %d: got %dx%d, want %dx%d", tt.orientation, dst.Rect.Dx(), dst.Rect.Dy(), tt.width, tt.height)
+			continue
+		}
+		if got := dst.NRGBAAt(0, 0); got != tt.topLeft {
+			t.Errorf("orientation %d: top left is %v, want %v", tt.orientation, got, tt.topLeft)
+		}
+		if got := dst.NRGBAAt(tt.width-1, 0); got != tt.topRight {
+			t.Errorf("orientation %d: top right is %v, want %v", tt.orientation, got, tt.topRight)
+		}
+	}
+}
@@ -0,0 +1,142 @@
+package imaging
+
+import (
+	"bytes"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"io"
+
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+
+	"github.com/Vanv1k/BeautyTON/internal/domain/entity"
+	er "github.com/Vanv1k/BeautyTON/internal/domain/errors"
+)
+
+const (
+	// maxPixels rejects decompression bombs before they are decoded
+	maxPixels   = 40_000_000
+	jpegQuality = 85
+)
+
+// variantSize bounds a variant. A cropped variant is cut to the aspect
+// ratio of its bounds around the center first. Photos are never upscaled.
+type variantSize struct {
+	variant entity.ImageVariant
+	width   int
+	height  int
+	crop    bool
+}
+
+var variantSizes = []variantSize{
+	{variant: entity.ImageVariantThumb, width: 200, height: 200, crop: true},
+	{variant: entity.ImageVariantCard, width: 800, height: 800},
+	{variant: entity.ImageVariantFull, width: 2048, height: 2048},
+}
+
+// Processor turns uploaded photos into their variants with pure-Go codecs.
+// JPEG, PNG and WebP are accepted. Variants are encoded as JPEG, or PNG when
+// the photo has transparency, so no metadata of the upload is kept.
+type Processor struct{}
+
+func NewProcessor() *Processor {
+	return &Processor{}
+}
+
+func (p *Processor) Process(content io.Reader) ([]entity.Image, error) {
+	data, err := io.ReadAll(content)
+	if err != nil {
+		return nil, err
+	}
+	config, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil || (format != "jpeg" && format != "png" && format != "webp") {
+		return nil, er.Unprocessable("unsupported image format")
+	}
+	if int64(config.Width)*int64(config.Height) > maxPixels {
+		return nil, er.Unprocessable("image is too large")
+	}
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, er.Unprocessable("invalid image")
+	}
+
+	orientation := exifOrientation(format, data)
+	opaque := isOpaque(src)
+	images := make([]entity.Image, 0, len(variantSizes))
+	for _, size := range variantSizes {
+		variant := orient(scale(src, size, orientation), orientation)
+		encoded, mimeType, err := encode(variant, opaque)
+		if err != nil {
+			return nil, err
+		}
+		images = append(images, entity.Image{
+			Variant:  size.variant,
+			MimeType: mimeType,
+			Width:    variant.Bounds().Dx(),
+			Height:   variant.Bounds().Dy(),
+			Data:     encoded,
+		})
+	}
+	return images, nil
+}
+
+// scale resizes src to a variant before it is oriented, which is cheaper
+// than rotating the full photo. Bounds are given for the oriented photo, so
+// they are swapped for orientations that turn it by 90 degrees.
+func scale(src image.Image, size variantSize, orientation int) *image.NRGBA {
+	width, height := size.width, size.height
+	if orientation >= 5 {
+		width, height = height, width
+	}
+
+	rect := src.Bounds()
+	if size.crop {
+		// Обрезаем по центру до пропорций варианта
+		w, h := rect.Dx(), rect.Dy()
+		if w*height > h*width {
+			w = h * width / height
+		} else {
+			h = w * height / width
+		}
+		origin := rect.Min.Add(image.Pt((rect.Dx()-w)/2, (rect.Dy()-h)/2))
+		rect = image.Rectangle{Min: origin, Max: origin.Add(image.Pt(w, h))}
+	}
+
+	w, h := fit(rect.Dx(), rect.Dy(), width, height)
+	dst := image.NewNRGBA(image.Rect(0, 0, w, h))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, rect, draw.Src, nil)
+	return dst
+}
+
+// fit scales w x h down into the bounds keeping the aspect ratio.
+func fit(w, h, maxWidth, maxHeight int) (int, int) {
+	if w <= maxWidth && h <= maxHeight {
+		return w, h
+	}
+	if w*maxHeight > h*maxWidth {
+		return maxWidth, max(1, h*maxWidth/w)
+	}
+	return max(1, w*maxHeight/h), maxHeight
+}
+
+func isOpaque(img image.Image) bool {
+	if o, ok := img.(interface{ Opaque() bool }); ok {
+		return o.Opaque()
+	}
+	return false
+}
+
+func encode(img image.Image, opaque bool) ([]byte, string, error) {
+	var buf bytes.Buffer
+	if !opaque {
+		if err := png.Encode(&buf, img); err != nil {
+			return nil, "", err
+		}
+		return buf.Bytes(), "image/png", nil
+	}
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: jpegQuality}); err != nil {
+		return nil, "", err
+	}
+	return buf.Bytes(), "image/jpeg", nil
+}
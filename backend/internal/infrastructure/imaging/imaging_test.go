@@ -0,0 +1,114 @@
+package imaging
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"testing"
+
+	"github.com/Vanv1k/BeautyTON/internal/domain/entity"
+)
+
+// testJPEG encodes an opaque photo of the given size.
+func testJPEG(t *testing.T, width, height int) []byte {
+	t.Helper()
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for i := range img.Pix {
+		img.Pix[i] = 0xFF
+	}
+	img.SetNRGBA(0, 0, color.NRGBA{R: 255, A: 255})
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, nil); err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestProcessVariantSizes(t *testing.T) {
+	type size struct{ width, height int }
+	tests := []struct {
+		name        string
+		width       int
+		height      int
+		orientation int
+		// Thumbs are cropped to 200x200, cards fit within 800x800 and full
+		// photos within 2048x2048
+		want map[entity.ImageVariant]size
+	}{
+		{
+			name: "landscape", width: 2400, height: 1600,
+			want: map[entity.ImageVariant]size{
+				entity.ImageVariantThumb: {200, 200},
+				entity.ImageVariantCard:  {800, 533},
+				entity.ImageVariantFull:  {2048, 1365},
+			},
+		},
+		{
+			name: "portrait", width: 1000, height: 4000,
+			want: map[entity.ImageVariant]size{
+				entity.ImageVariantThumb: {200, 200},
+				entity.ImageVariantCard:  {200, 800},
+				entity.ImageVariantFull:  {512, 2048},
+			},
+		},
+		{
+			name: "square", width: 800, height: 800,
+			want: map[entity.ImageVariant]size{
+				entity.ImageVariantThumb: {200, 200},
+				entity.ImageVariantCard:  {800, 800},
+				entity.ImageVariantFull:  {800, 800},
+			},
+		},
+		{
+			// Small photos are not upscaled, the thumb is still square
+			name: "small", width: 150, height: 90,
+			want: map[entity.ImageVariant]size{
+				entity.ImageVariantThumb: {90, 90},
+				entity.ImageVariantCard:  {150, 90},
+				entity.ImageVariantFull:  {150, 90},
+			},
+		},
+		{
+			// Stored landscape, shown portrait: bounds apply to the upright photo
+			name: "rotated by EXIF", width: 2400, height: 1600, orientation: 6,
+			want: map[entity.ImageVariant]size{
+				entity.ImageVariantThumb: {200, 200},
+				entity.ImageVariantCard:  {533, 800},
+				entity.ImageVariantFull:  {1365, 2048},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := testJPEG(t, tt.width, tt.height)
+			if tt.orientation != 0 {
+				data = jpegWithExif(data, exifTIFF(binary.BigEndian, tt.orientation))
+			}
+			images, err := NewProcessor().Process(bytes.NewReader(data))
+			if err != nil {
+				t.Fatalf("process: %v", err)
+			}
+			if len(images) != len(tt.want) {
+				t.Fatalf("got %d variants, want %d", len(images), len(tt.want))
+			}
+			for _, img := range images {
+				want := tt.want[img.Variant]
+				if img.Width != want.width || img.Height != want.height {
+					t.Errorf("%s is %dx%d, want %dx%d", img.Variant, img.Width, img.Height, want.width, want.height)
+				}
+				if img.MimeType != "image/jpeg" {
+					t.Errorf("%s is %s, want image/jpeg", img.Variant, img.MimeType)
+				}
+				config, err := jpeg.DecodeConfig(bytes.NewReader(img.Data))
+				if err != nil {
+					t.Fatalf("%s does not decode: %v", img.Variant, err)
+				}
+				if config.Width != img.Width || config.Height != img.Height {
+					t.Errorf("%s decodes as %dx%d, reported as %dx%d", img.Variant, config.Width, config.Height, img.Width, img.Height)
+				}
+			}
+		})
+	}
+}
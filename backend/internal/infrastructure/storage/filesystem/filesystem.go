@@ -0,0 +1,219 @@
+// Package filesystem keeps files in a local directory. It suits local runs
+// of a single instance.
+package filesystem
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	conf "github.com/Vanv1k/BeautyTON/internal/config"
+	"github.com/Vanv1k/BeautyTON/internal/domain/entity"
+	er "github.com/Vanv1k/BeautyTON/internal/domain/errors"
+	"github.com/Vanv1k/BeautyTON/internal/domain/repository"
+)
+
+// defaultMimeType is the type of a file stored without one.
+const defaultMimeType = "application/octet-stream"
+
+// metadata is kept next to the content, which the file system stores
+// without a type.
+type metadata struct {
+	MimeType string `json:"mime_type"`
+	Checksum string `json:"checksum"`
+}
+
+// FileRepository keeps the content of a file under objects/<key> and its
+// metadata under meta/<key>.json in the root directory.
+type FileRepository struct {
+	objects string
+	meta    string
+}
+
+func NewFileRepository(cfg conf.FilesystemConfig) (repository.FileRepository, error) {
+	r := &FileRepository{
+		objects: filepath.Join(cfg.Root, "objects"),
+		meta:    filepath.Join(cfg.Root, "meta"),
+	}
+	for _, dir := range []string{r.objects, r.meta} {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+func (r *FileRepository) Upload(ctx context.Context, file *entity.File, content io.Reader) error {
+	if !validKey(file.ID) {
+		return er.Validation("invalid file ID")
+	}
+	meta := metadata{MimeType: file.MimeType}
+	if meta.MimeType == "" {
+		meta.MimeType = defaultMimeType
+	}
+
+	// Пишем во временный файл и переименовываем, чтобы читатели не видели
+	// недописанный объект
+	hash := sha256.New()
+	err := writeFile(r.objects, file.ID, func(w io.Writer) error {
+		_, err := io.Copy(w, io.TeeReader(content, hash))
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	meta.Checksum = hex.EncodeToString(hash.Sum(nil))
+	return writeFile(r.meta, file.ID+".json", func(w io.Writer) error {
+		return json.NewEncoder(w).Encode(meta)
+	})
+}
+
+func (r *FileRepository) Get(ctx context.Context, id string, rng *entity.ByteRange) (*entity.File, io.ReadCloser, error) {
+	if !validKey(id) {
+		return nil, nil, er.ErrRecordNotFound
+	}
+	content, err := os.Open(filepath.Join(r.objects, id))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil, er.ErrRecordNotFound
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	file, err := r.describe(id, content)
+	if err != nil {
+		content.Close()
+		return nil, nil, err
+	}
+	if rng == nil {
+		return file, content, nil
+	}
+	part, ok := rng.Resolve(file.Size)
+	if !ok {
+		content.Close()
+		return nil, nil, er.ErrRangeNotSatisfiable
+	}
+	file.Range = &part
+	return file, &section{Reader: io.NewSectionReader(content, part.Start, part.Length()), Closer: content}, nil
+}
+
+func (r *FileRepository) Stat(ctx context.Context, id string) (*entity.File, error) {
+	if !validKey(id) {
+		return nil, er.ErrRecordNotFound
+	}
+	content, err := os.Open(filepath.Join(r.objects, id))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, er.ErrRecordNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer content.Close()
+	return r.describe(id, content)
+}
+
+// PresignUpload is not supported, clients upload through the API.
+func (r *FileRepository) PresignUpload(ctx context.Context, file *entity.File, ttl time.Duration) (*entity.PresignedRequest, error) {
+	return nil, er.ErrPresignNotSupported
+}
+
+// PresignDownload is not supported, clients download through the API.
+func (r *FileRepository) PresignDownload(ctx context.Context, id string, ttl time.Duration) (*entity.PresignedRequest, error) {
+	return nil, er.ErrPresignNotSupported
+}
+
+func (r *FileRepository) Delete(ctx context.Context, id string) error {
+	if !validKey(id) {
+		return nil
+	}
+	if err := os.Remove(filepath.Join(r.objects, id)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	if err := os.Remove(filepath.Join(r.meta, id+".json")); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// Ping checks that the root directory is still there.
+func (r *FileRepository) Ping(ctx context.Context) error {
+	info, err := os.Stat(r.objects)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("%s is not a directory", r.objects)
+	}
+	return nil
+}
+
+// describe reads the size from the open content, which a concurrent upload
+// does not change, and the rest from the metadata.
+func (r *FileRepository) describe(id string, content *os.File) (*entity.File, error) {
+	info, err := content.Stat()
+	if err != nil {
+		return nil, err
+	}
+	file := &entity.File{
+		ID:         id,
+		Name:       id,
+		Size:       info.Size(),
+		MimeType:   defaultMimeType,
+		ModifiedAt: info.ModTime(),
+	}
+	data, err := os.ReadFile(filepath.Join(r.meta, id+".json"))
+	if errors.Is(err, fs.ErrNotExist) {
+		return file, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var meta metadata
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return nil, err
+	}
+	file.MimeType = meta.MimeType
+	file.Checksum = meta.Checksum
+	if meta.Checksum != "" {
+		// Как и у S3, ETag меняется с содержимым
+		file.ETag = `"` + meta.Checksum + `"`
+	}
+	return file, nil
+}
+
+// section reads a range of an open file and closes the file.
+type section struct {
+	io.Reader
+	io.Closer
+}
+
+// validKey accepts keys that name a file right in a directory. Temporary
+// files start with a dot, so such keys are rejected too.
+func validKey(key string) bool {
+	return key != "" && !strings.HasPrefix(key, ".") && !strings.ContainsAny(key, `/\`) && filepath.IsLocal(key)
+}
+
+// writeFile replaces dir/name with what write produces, atomically.
+func writeFile(dir, name string, write func(w io.Writer) error) error {
+	tmp, err := os.CreateTemp(dir, ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if err := write(tmp); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), filepath.Join(dir, name))
+}
@@ -0,0 +1,16 @@
+package filesystem
+
+import (
+	"testing"
+
+	conf "github.com/Vanv1k/BeautyTON/internal/config"
+	"github.com/Vanv1k/BeautyTON/internal/infrastructure/storage/storagetest"
+)
+
+func TestConformance(t *testing.T) {
+	repo, err := NewFileRepository(conf.FilesystemConfig{Root: t.TempDir()})
+	if err != nil {
+		t.Fatalf("new repository: %v", err)
+	}
+	storagetest.Run(t, repo, storagetest.Options{Checksum: true})
+}
@@ -0,0 +1,118 @@
+// Package memory keeps files in the process. It suits tests and local runs;
+// files are lost on restart and not shared between instances.
+package memory
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/Vanv1k/BeautyTON/internal/domain/entity"
+	er "github.com/Vanv1k/BeautyTON/internal/domain/errors"
+	"github.com/Vanv1k/BeautyTON/internal/domain/repository"
+)
+
+// defaultMimeType is the type of a file stored without one.
+const defaultMimeType = "application/octet-stream"
+
+type object struct {
+	data       []byte
+	mimeType   string
+	checksum   string
+	modifiedAt time.Time
+}
+
+type FileRepository struct {
+	mu      sync.RWMutex
+	objects map[string]*object
+}
+
+func NewFileRepository() repository.FileRepository {
+	return &FileRepository{objects: map[string]*object{}}
+}
+
+func (r *FileRepository) Upload(ctx context.Context, file *entity.File, content io.Reader) error {
+	data, err := io.ReadAll(content)
+	if err != nil {
+		return err
+	}
+	mimeType := file.MimeType
+	if mimeType == "" {
+		mimeType = defaultMimeType
+	}
+	sum := sha256.Sum256(data)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	// Объект заменяется целиком, читатели прежнего не затрагиваются
+	r.objects[file.ID] = &object{data: data, mimeType: mimeType, checksum: hex.EncodeToString(sum[:]), modifiedAt: time.Now()}
+	return nil
+}
+
+func (r *FileRepository) Get(ctx context.Context, id string, rng *entity.ByteRange) (*entity.File, io.ReadCloser, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	obj, ok := r.objects[id]
+	if !ok {
+		return nil, nil, er.ErrRecordNotFound
+	}
+	file := obj.file(id)
+	data := obj.data
+	if rng != nil {
+		part, ok := rng.Resolve(file.Size)
+		if !ok {
+			return nil, nil, er.ErrRangeNotSatisfiable
+		}
+		file.Range = &part
+		data = data[part.Start : part.End+1]
+	}
+	return file, io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (r *FileRepository) Stat(ctx context.Context, id string) (*entity.File, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	obj, ok := r.objects[id]
+	if !ok {
+		return nil, er.ErrRecordNotFound
+	}
+	return obj.file(id), nil
+}
+
+// PresignUpload is not supported, clients upload through the API.
+func (r *FileRepository) PresignUpload(ctx context.Context, file *entity.File, ttl time.Duration) (*entity.PresignedRequest, error) {
+	return nil, er.ErrPresignNotSupported
+}
+
+// PresignDownload is not supported, clients download through the API.
+func (r *FileRepository) PresignDownload(ctx context.Context, id string, ttl time.Duration) (*entity.PresignedRequest, error) {
+	return nil, er.ErrPresignNotSupported
+}
+
+func (r *FileRepository) Delete(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.objects, id)
+	return nil
+}
+
+func (r *FileRepository) Ping(ctx context.Context) error {
+	return nil
+}
+
+func (o *object) file(id string) *entity.File {
+	return &entity.File{
+		ID:       id,
+		Name:     id,
+		Size:     int64(len(o.data)),
+		MimeType: o.mimeType,
+		Checksum: o.checksum,
+		// Как и у S3, ETag меняется с содержимым
+		ETag:       `"` + o.checksum + `"`,
+		ModifiedAt: o.modifiedAt,
+	}
+}
@@ -0,0 +1,11 @@
+package memory
+
+import (
+	"testing"
+
+	"github.com/Vanv1k/BeautyTON/internal/infrastructure/storage/storagetest"
+)
+
+func TestConformance(t *testing.T) {
+	storagetest.Run(t, NewFileRepository(), storagetest.Options{Checksum: true})
+}
@@ -2,21 +2,33 @@ package s3
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
 	"io"
+	"net/http"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/smithy-go"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/aws/aws-sdk-go-v2/otelaws"
 
 	conf "github.com/Vanv1k/BeautyTON/internal/config"
 	"github.com/Vanv1k/BeautyTON/internal/domain/entity"
+	er "github.com/Vanv1k/BeautyTON/internal/domain/errors"
 	"github.com/Vanv1k/BeautyTON/internal/domain/repository"
+	"github.com/Vanv1k/BeautyTON/internal/metrics"
 )
 
 type FileRepository struct {
-	client *s3.Client
-	bucket string
+	client  *s3.Client
+	presign *s3.PresignClient
+	bucket  string
 }
 
 func NewFileRepository(cfg conf.S3Config) (repository.FileRepository, error) {
@@ -28,6 +40,7 @@ func NewFileRepository(cfg conf.S3Config) (repository.FileRepository, error) {
 	if err != nil {
 		return nil, err
 	}
+	otelaws.AppendMiddlewares(&awsCfg.APIOptions)
 
 	client := s3.NewFromConfig(awsCfg, func(o *s3.Options) {
 		if cfg.Endpoint != "" {
@@ -37,13 +50,15 @@ func NewFileRepository(cfg conf.S3Config) (repository.FileRepository, error) {
 	})
 
 	return &FileRepository{
-		client: client,
-		bucket: cfg.Bucket,
+		client:  client,
+		presign: s3.NewPresignClient(client),
+		bucket:  cfg.Bucket,
 	}, nil
 }
 
-func (r *FileRepository) Upload(ctx context.Context, file *entity.File, content io.Reader) error {
-	_, err := r.client.PutObject(ctx, &s3.PutObjectInput{
+func (r *FileRepository) Upload(ctx context.Context, file *entity.File, content io.Reader) (err error) {
+	defer func(start time.Time) { metrics.ObserveS3("upload", start, err) }(time.Now())
+	_, err = r.client.PutObject(ctx, &s3.PutObjectInput{
 		Bucket:      aws.String(r.bucket),
 		Key:         aws.String(file.ID),
 		Body:        content,
@@ -52,20 +67,164 @@ func (r *FileRepository) Upload(ctx context.Context, file *entity.File, content
 	return err
 }
 
-func (r *FileRepository) Get(ctx context.Context, id string) (*entity.File, io.Reader, error) {
-	output, err := r.client.GetObject(ctx, &s3.GetObjectInput{
+func (r *FileRepository) Get(ctx context.Context, id string, rng *entity.ByteRange) (*entity.File, io.ReadCloser, error) {
+	input := &s3.GetObjectInput{
 		Bucket: aws.String(r.bucket),
 		Key:    aws.String(id),
-	})
+	}
+	if rng != nil {
+		input.Range = aws.String(rangeHeader(*rng))
+	}
+	start := time.Now()
+	output, err := r.client.GetObject(ctx, input)
+	metrics.ObserveS3("get", start, err)
+	var noSuchKey *types.NoSuchKey
+	if errors.As(err, &noSuchKey) {
+		return nil, nil, er.ErrRecordNotFound
+	}
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) && apiErr.ErrorCode() == "InvalidRange" {
+		return nil, nil, er.ErrRangeNotSatisfiable
+	}
 	if err != nil {
 		return nil, nil, err
 	}
 
 	file := &entity.File{
-		ID:       id,
-		Name:     id,
-		Size:     *output.ContentLength,
-		MimeType: *output.ContentType,
+		ID:         id,
+		Name:       id,
+		Size:       aws.ToInt64(output.ContentLength),
+		MimeType:   aws.ToString(output.ContentType),
+		ETag:       aws.ToString(output.ETag),
+		ModifiedAt: aws.ToTime(output.LastModified),
+	}
+	// Для диапазона размер всего объекта есть только в Content-Range
+	if part, size, ok := parseContentRange(aws.ToString(output.ContentRange)); ok {
+		file.Size = size
+		file.Range = &part
 	}
 	return file, output.Body, nil
 }
+
+func (r *FileRepository) Stat(ctx context.Context, id string) (*entity.File, error) {
+	start := time.Now()
+	output, err := r.client.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket:       aws.String(r.bucket),
+		Key:          aws.String(id),
+		ChecksumMode: types.ChecksumModeEnabled,
+	})
+	metrics.ObserveS3("stat", start, err)
+	var notFound *types.NotFound
+	if errors.As(err, &notFound) {
+		return nil, er.ErrRecordNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	file := &entity.File{
+		ID:         id,
+		Name:       id,
+		Size:       aws.ToInt64(output.ContentLength),
+		MimeType:   aws.ToString(output.ContentType),
+		ETag:       aws.ToString(output.ETag),
+		ModifiedAt: aws.ToTime(output.LastModified),
+	}
+	// Контрольная сумма есть только у объектов, загруженных с ней
+	if sum, err := base64.StdEncoding.DecodeString(aws.ToString(output.ChecksumSHA256)); err == nil && len(sum) > 0 {
+		file.Checksum = hex.EncodeToString(sum)
+	}
+	return file, nil
+}
+
+// PresignUpload signs the length, type and checksum headers of the upload,
+// S3 rejects a request whose headers differ from them.
+func (r *FileRepository) PresignUpload(ctx context.Context, file *entity.File, ttl time.Duration) (*entity.PresignedRequest, error) {
+	input := &s3.PutObjectInput{
+		Bucket:        aws.String(r.bucket),
+		Key:           aws.String(file.ID),
+		ContentLength: aws.Int64(file.Size),
+		ContentType:   aws.String(file.MimeType),
+	}
+	if file.Checksum != "" {
+		sum, err := hex.DecodeString(file.Checksum)
+		if err != nil {
+			return nil, er.Validation("invalid checksum")
+		}
+		input.ChecksumSHA256 = aws.String(base64.StdEncoding.EncodeToString(sum))
+	}
+	request, err := r.presign.PresignPutObject(ctx, input, s3.WithPresignExpires(ttl))
+	if err != nil {
+		return nil, err
+	}
+	return newPresignedRequest(request.Method, request.URL, request.SignedHeader, ttl), nil
+}
+
+func (r *FileRepository) PresignDownload(ctx context.Context, id string, ttl time.Duration) (*entity.PresignedRequest, error) {
+	request, err := r.presign.PresignGetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(r.bucket),
+		Key:    aws.String(id),
+	}, s3.WithPresignExpires(ttl))
+	if err != nil {
+		return nil, err
+	}
+	return newPresignedRequest(request.Method, request.URL, request.SignedHeader, ttl), nil
+}
+
+// newPresignedRequest keeps the headers a client has to send, the host is
+// set by the client from the URL.
+func newPresignedRequest(method, url string, signed http.Header, ttl time.Duration) *entity.PresignedRequest {
+	headers := make(map[string]string, len(signed))
+	for name := range signed {
+		if name == "Host" {
+			continue
+		}
+		headers[name] = signed.Get(name)
+	}
+	return &entity.PresignedRequest{
+		Method:    method,
+		URL:       url,
+		Headers:   headers,
+		ExpiresAt: time.Now().Add(ttl),
+	}
+}
+
+func (r *FileRepository) Delete(ctx context.Context, id string) (err error) {
+	defer func(start time.Time) { metrics.ObserveS3("delete", start, err) }(time.Now())
+	_, err = r.client.DeleteObject(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(r.bucket),
+		Key:    aws.String(id),
+	})
+	return err
+}
+
+// Ping checks that the bucket exists and the credentials give access to it.
+func (r *FileRepository) Ping(ctx context.Context) error {
+	_, err := r.client.HeadBucket(ctx, &s3.HeadBucketInput{
+		Bucket: aws.String(r.bucket),
+	})
+	return err
+}
+
+// rangeHeader formats rng as an HTTP Range header, which S3 accepts as is.
+func rangeHeader(rng entity.ByteRange) string {
+	switch {
+	case rng.Start < 0:
+		return fmt.Sprintf("bytes=%d", rng.Start)
+	case rng.End < 0:
+		return fmt.Sprintf("bytes=%d-", rng.Start)
+	default:
+		return fmt.Sprintf("bytes=%d-%d", rng.Start, rng.End)
+	}
+}
+
+// parseContentRange reads the bytes S3 returned and the object size from a
+// Content-Range header, e.g. "bytes 0-99/1234".
+func parseContentRange(header string) (entity.ByteRange, int64, bool) {
+	var part entity.ByteRange
+	var size int64
+	if _, err := fmt.Sscanf(header, "bytes %d-%d/%d", &part.Start, &part.End, &size); err != nil {
+		return entity.ByteRange{}, 0, false
+	}
+	return part, size, true
+}
@@ -0,0 +1,33 @@
+package s3
+
+import (
+	"os"
+	"testing"
+
+	conf "github.com/Vanv1k/BeautyTON/internal/config"
+	"github.com/Vanv1k/BeautyTON/internal/infrastructure/storage/storagetest"
+)
+
+// TestConformance runs against the bucket given by the TEST_S3_* variables,
+// e.g. of a local MinIO. Without TEST_S3_BUCKET the test is skipped.
+func TestConformance(t *testing.T) {
+	bucket := os.Getenv("TEST_S3_BUCKET")
+	if bucket == "" {
+		t.Skip("TEST_S3_BUCKET is not set")
+	}
+	region := os.Getenv("TEST_S3_REGION")
+	if region == "" {
+		region = "us-east-1"
+	}
+	repo, err := NewFileRepository(conf.S3Config{
+		AccessKeyID:     os.Getenv("TEST_S3_ACCESS_KEY_ID"),
+		SecretAccessKey: os.Getenv("TEST_S3_SECRET_ACCESS_KEY"),
+		Region:          region,
+		Bucket:          bucket,
+		Endpoint:        os.Getenv("TEST_S3_ENDPOINT"),
+	})
+	if err != nil {
+		t.Fatalf("new repository: %v", err)
+	}
+	storagetest.Run(t, repo, storagetest.Options{Presign: true})
+}
@@ -0,0 +1,22 @@
+// Package storage picks the backend that keeps the files.
+package storage
+
+import (
+	conf "github.com/Vanv1k/BeautyTON/internal/config"
+	"github.com/Vanv1k/BeautyTON/internal/domain/repository"
+	"github.com/Vanv1k/BeautyTON/internal/infrastructure/storage/filesystem"
+	"github.com/Vanv1k/BeautyTON/internal/infrastructure/storage/memory"
+	"github.com/Vanv1k/BeautyTON/internal/infrastructure/storage/s3"
+)
+
+// NewFileRepository returns the backend chosen by cfg.Backend.
+func NewFileRepository(cfg conf.StorageConfig) (repository.FileRepository, error) {
+	switch cfg.Backend {
+	case "filesystem":
+		return filesystem.NewFileRepository(cfg.Filesystem)
+	case "memory":
+		return memory.NewFileRepository(), nil
+	default:
+		return s3.NewFileRepository(cfg.S3)
+	}
+}
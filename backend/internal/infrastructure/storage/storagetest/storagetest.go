@@ -0,0 +1,238 @@
+// Package storagetest checks that a file storage backend behaves like the
+// others, so the API does not depend on the backend chosen.
+package storagetest
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Vanv1k/BeautyTON/internal/domain/entity"
+	er "github.com/Vanv1k/BeautyTON/internal/domain/errors"
+	"github.com/Vanv1k/BeautyTON/internal/domain/repository"
+)
+
+// Options describe what a backend supports.
+type Options struct {
+	// Presign is set for backends that sign requests sent to them directly.
+	// The others must return ErrPresignNotSupported
+	Presign bool
+	// Checksum is set for backends that always know the checksum of a file.
+	// The others may leave it empty, but never report a wrong one
+	Checksum bool
+}
+
+// content is 10 bytes, so ranges are easy to follow.
+const content = "0123456789"
+
+// Run checks repo against the behavior every backend shares. Files are
+// stored under random keys and deleted at the end.
+func Run(t *testing.T, repo repository.FileRepository, opts Options) {
+	ctx := context.Background()
+	key := func(t *testing.T) string {
+		t.Helper()
+		id := "storagetest-" + uuid.NewString()
+		t.Cleanup(func() { repo.Delete(ctx, id) })
+		return id
+	}
+	upload := func(t *testing.T, id, data string) {
+		t.Helper()
+		if err := repo.Upload(ctx, &entity.File{ID: id, MimeType: "text/plain"}, bytes.NewReader([]byte(data))); err != nil {
+			t.Fatalf("upload: %v", err)
+		}
+	}
+
+	t.Run("upload then get and stat", func(t *testing.T) {
+		id := key(t)
+		upload(t, id, content)
+
+		file, body := get(t, repo, id, nil)
+		if body != content {
+			t.Fatalf("get returned %q, want %q", body, content)
+		}
+		if file.ID != id || file.Size != int64(len(content)) || file.MimeType != "text/plain" || file.Range != nil {
+			t.Fatalf("get described the file as %+v", file)
+		}
+
+		stat, err := repo.Stat(ctx, id)
+		if err != nil {
+			t.Fatalf("stat: %v", err)
+		}
+		if stat.ID != id || stat.Size != int64(len(content)) || stat.MimeType != "text/plain" {
+			t.Fatalf("stat described the file as %+v", stat)
+		}
+		if stat.ModifiedAt.IsZero() || !stat.ModifiedAt.Equal(file.ModifiedAt) {
+			t.Fatalf("stat modified at %v, get at %v, want the same time", stat.ModifiedAt, file.ModifiedAt)
+		}
+	})
+
+	t.Run("missing key", func(t *testing.T) {
+		id := key(t)
+		if _, _, err := repo.Get(ctx, id, nil); !errors.Is(err, er.ErrRecordNotFound) {
+			t.Fatalf("get: got %v, want record not found", err)
+		}
+		if _, err := repo.Stat(ctx, id); !errors.Is(err, er.ErrRecordNotFound) {
+			t.Fatalf("stat: got %v, want record not found", err)
+		}
+		if err := repo.Delete(ctx, id); err != nil {
+			t.Fatalf("delete: %v", err)
+		}
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		id := key(t)
+		upload(t, id, content)
+		if err := repo.Delete(ctx, id); err != nil {
+			t.Fatalf("delete: %v", err)
+		}
+		if _, err := repo.Stat(ctx, id); !errors.Is(err, er.ErrRecordNotFound) {
+			t.Fatalf("stat after delete: got %v, want record not found", err)
+		}
+		if err := repo.Delete(ctx, id); err != nil {
+			t.Fatalf("second delete: %v", err)
+		}
+	})
+
+	t.Run("ranges", func(t *testing.T) {
+		id := key(t)
+		upload(t, id, content)
+
+		tests := []struct {
+			name  string
+			rng   entity.ByteRange
+			want  string
+			start int64
+			end   int64
+		}{
+			{name: "closed", rng: entity.ByteRange{Start: 2, End: 4}, want: "234", start: 2, end: 4},
+			{name: "past the end", rng: entity.ByteRange{Start: 5, End: 100}, want: "56789", start: 5, end: 9},
+			{name: "open-ended", rng: entity.ByteRange{Start: 7, End: -1}, want: "789", start: 7, end: 9},
+			{name: "suffix", rng: entity.ByteRange{Start: -3, End: -1}, want: "789", start: 7, end: 9},
+			{name: "suffix longer than the file", rng: entity.ByteRange{Start: -20, End: -1}, want: content, start: 0, end: 9},
+			{name: "last byte", rng: entity.ByteRange{Start: 9, End: 9}, want: "9", start: 9, end: 9},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				rng := tt.rng
+				file, body := get(t, repo, id, &rng)
+				if body != tt.want {
+					t.Fatalf("got %q, want %q", body, tt.want)
+				}
+				if file.Range == nil || file.Range.Start != tt.start || file.Range.End != tt.end {
+					t.Fatalf("range is %+v, want %d-%d", file.Range, tt.start, tt.end)
+				}
+				if file.Size != int64(len(content)) {
+					t.Fatalf("size is %d, want the size of the whole file", file.Size)
+				}
+			})
+		}
+
+		for _, rng := range []entity.ByteRange{{Start: 10, End: -1}, {Start: 10, End: 20}, {Start: 100, End: 200}} {
+			_, body, err := repo.Get(ctx, id, &rng)
+			if body != nil {
+				body.Close()
+			}
+			if !errors.Is(err, er.ErrRangeNotSatisfiable) {
+				t.Fatalf("range %+v: got %v, want range not satisfiable", rng, err)
+			}
+		}
+	})
+
+	t.Run("checksum", func(t *testing.T) {
+		id := key(t)
+		upload(t, id, content)
+		stat, err := repo.Stat(ctx, id)
+		if err != nil {
+			t.Fatalf("stat: %v", err)
+		}
+		sum := sha256.Sum256([]byte(content))
+		want := hex.EncodeToString(sum[:])
+		if stat.Checksum == "" && !opts.Checksum {
+			return
+		}
+		if stat.Checksum != want {
+			t.Fatalf("checksum is %q, want %q", stat.Checksum, want)
+		}
+	})
+
+	t.Run("etag", func(t *testing.T) {
+		id := key(t)
+		upload(t, id, content)
+		etag := func() string {
+			t.Helper()
+			file, _ := get(t, repo, id, nil)
+			stat, err := repo.Stat(ctx, id)
+			if err != nil {
+				t.Fatalf("stat: %v", err)
+			}
+			if file.ETag == "" || file.ETag != stat.ETag {
+				t.Fatalf("get returned ETag %q, stat %q, want the same one", file.ETag, stat.ETag)
+			}
+			return file.ETag
+		}
+
+		first := etag()
+		rng := entity.ByteRange{Start: 0, End: 4}
+		if file, _ := get(t, repo, id, &rng); file.ETag != first {
+			t.Fatalf("range returned ETag %q, want that of the file %q", file.ETag, first)
+		}
+		upload(t, id, content)
+		if again := etag(); again != first {
+			t.Fatalf("ETag changed from %q to %q on the same content", first, again)
+		}
+		upload(t, id, "9876543210")
+		if changed := etag(); changed == first {
+			t.Fatalf("ETag stayed %q after the content changed", changed)
+		}
+	})
+
+	t.Run("presign", func(t *testing.T) {
+		id := key(t)
+		file := &entity.File{ID: id, MimeType: "text/plain", Size: int64(len(content))}
+		upload, err := repo.PresignUpload(ctx, file, time.Minute)
+		if !opts.Presign {
+			if !errors.Is(err, er.ErrPresignNotSupported) {
+				t.Fatalf("presign upload: got %v, want presign not supported", err)
+			}
+			if _, err := repo.PresignDownload(ctx, id, time.Minute); !errors.Is(err, er.ErrPresignNotSupported) {
+				t.Fatalf("presign download: got %v, want presign not supported", err)
+			}
+			return
+		}
+		if err != nil {
+			t.Fatalf("presign upload: %v", err)
+		}
+		if upload.Method != "PUT" || upload.URL == "" || upload.ExpiresAt.IsZero() {
+			t.Fatalf("presigned upload is %+v", upload)
+		}
+		download, err := repo.PresignDownload(ctx, id, time.Minute)
+		if err != nil {
+			t.Fatalf("presign download: %v", err)
+		}
+		if download.Method != "GET" || download.URL == "" || download.ExpiresAt.IsZero() {
+			t.Fatalf("presigned download is %+v", download)
+		}
+	})
+}
+
+// get reads a file or a range of it in full.
+func get(t *testing.T, repo repository.FileRepository, id string, rng *entity.ByteRange) (*entity.File, string) {
+	t.Helper()
+	file, body, err := repo.Get(context.Background(), id, rng)
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	defer body.Close()
+	data, err := io.ReadAll(body)
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	return file, string(data)
+}
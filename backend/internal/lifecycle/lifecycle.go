@@ -0,0 +1,120 @@
+// Package lifecycle starts and stops the long-running parts of the
+// application, such as servers and background workers, in a fixed order.
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+)
+
+// Component is a part of the application with a lifetime. Either function
+// may be nil.
+type Component struct {
+	Name  string
+	Start func(ctx context.Context) error
+	Stop  func(ctx context.Context) error
+}
+
+// Manager starts components in the order they were added and stops them in
+// reverse, so that a component is stopped before the ones it depends on.
+type Manager struct {
+	components []Component
+	started    int
+}
+
+func NewManager() *Manager {
+	return &Manager{}
+}
+
+func (m *Manager) Add(component Component) {
+	m.components = append(m.components, component)
+}
+
+// Start starts all components. If one fails, the ones already started are
+// stopped and the error is returned.
+func (m *Manager) Start(ctx context.Context) error {
+	for _, component := range m.components {
+		if component.Start != nil {
+			slog.InfoContext(ctx, "starting component", "component", component.Name)
+			if err := component.Start(ctx); err != nil {
+				err = fmt.Errorf("failed to start %s: %w", component.Name, err)
+				return errors.Join(err, m.Stop(ctx))
+			}
+		}
+		m.started++
+	}
+	return nil
+}
+
+// Stop stops the started components in reverse order. Each one gets the
+// time left until ctx expires. All components are stopped even if some
+// fail, their errors are joined.
+func (m *Manager) Stop(ctx context.Context) error {
+	var errs []error
+	for ; m.started > 0; m.started-- {
+		component := m.components[m.started-1]
+		if component.Stop == nil {
+			continue
+		}
+		slog.InfoContext(ctx, "stopping component", "component", component.Name)
+		if err := component.Stop(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("failed to stop %s: %w", component.Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Server serves HTTP. It listens on start, so a port that is taken fails
+// the start, and on stop it stops accepting connections and waits for
+// in-flight requests to finish.
+func Server(name string, server *http.Server) Component {
+	return Component{
+		Name: name,
+		Start: func(ctx context.Context) error {
+			listener, err := net.Listen("tcp", server.Addr)
+			if err != nil {
+				return err
+			}
+			slog.InfoContext(ctx, "listening", "component", name, "addr", listener.Addr().String())
+			go func() {
+				if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+					slog.Error("server stopped", "component", name, "error", err)
+				}
+			}()
+			return nil
+		},
+		Stop: server.Shutdown,
+	}
+}
+
+// Worker runs a background loop, which must return once its context is
+// canceled. Stop cancels it and waits for it to return.
+func Worker(name string, run func(ctx context.Context)) Component {
+	var cancel context.CancelFunc
+	done := make(chan struct{})
+	return Component{
+		Name: name,
+		Start: func(ctx context.Context) error {
+			var runCtx context.Context
+			runCtx, cancel = context.WithCancel(context.WithoutCancel(ctx))
+			go func() {
+				defer close(done)
+				run(runCtx)
+			}()
+			return nil
+		},
+		Stop: func(ctx context.Context) error {
+			cancel()
+			select {
+			case <-done:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		},
+	}
+}
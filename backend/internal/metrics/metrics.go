@@ -0,0 +1,110 @@
+// Package metrics holds the Prometheus collectors of the application. They
+// are registered on Registry, which is served by Handler on the admin
+// listener.
+package metrics
+
+import (
+	"database/sql"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "beautyton"
+
+var Registry = prometheus.NewRegistry()
+
+var (
+	HTTPRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "request_duration_seconds",
+		Help:      "Duration of HTTP requests by route template.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	HTTPRequestsInFlight = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "requests_in_flight",
+		Help:      "Number of HTTP requests being served.",
+	})
+
+	S3OperationDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "s3",
+		Name:      "operation_duration_seconds",
+		Help:      "Duration of S3 operations.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"operation"})
+
+	S3OperationFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "s3",
+		Name:      "operation_failures_total",
+		Help:      "Number of failed S3 operations.",
+	}, []string{"operation"})
+
+	BookingsCreated = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "bookings_created_total",
+		Help:      "Number of bookings created.",
+	})
+
+	BookingsCanceled = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "bookings_canceled_total",
+		Help:      "Number of bookings canceled.",
+	})
+
+	PaymentsCompleted = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "payments_completed_total",
+		Help:      "Number of payments completed.",
+	})
+
+	// RemindersSent counts booking reminders. No reminders are sent yet, the
+	// counter is exported so that dashboards and alerts can rely on it
+	RemindersSent = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "reminders_sent_total",
+		Help:      "Number of booking reminders sent.",
+	})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		HTTPRequestDuration,
+		HTTPRequestsInFlight,
+		S3OperationDuration,
+		S3OperationFailures,
+		BookingsCreated,
+		BookingsCanceled,
+		PaymentsCompleted,
+		RemindersSent,
+	)
+}
+
+// RegisterDB exposes the connection pool statistics of db.
+func RegisterDB(db *sql.DB, name string) error {
+	return Registry.Register(collectors.NewDBStatsCollector(db, name))
+}
+
+// ObserveS3 records the duration and outcome of an S3 operation started at
+// start.
+func ObserveS3(operation string, start time.Time, err error) {
+	S3OperationDuration.WithLabelValues(operation).Observe(time.Since(start).Seconds())
+	if err != nil {
+		S3OperationFailures.WithLabelValues(operation).Inc()
+	}
+}
+
+// Handler serves the metrics in the Prometheus exposition format.
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{Registry: Registry})
+}
@@ -0,0 +1,92 @@
+package middleware
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+// accessLogEntry collects what is only known further down the chain.
+type accessLogEntry struct {
+	userID uuid.UUID
+}
+
+// AccessLogMiddleware logs every request with its status, size, latency and
+// the authenticated user. Server errors are logged at error level. Must run
+// after RequestIDMiddleware so that the entry carries the request ID.
+func AccessLogMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		entry := &accessLogEntry{}
+		ctx := context.WithValue(r.Context(), "access_log", entry)
+		writer := &statusWriter{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(writer, r.WithContext(ctx))
+
+		attrs := []any{
+			"method", r.Method,
+			"path", r.URL.Path,
+			"route", routeTemplate(r),
+			"status", writer.status,
+			"bytes", writer.bytes,
+			"duration_ms", milliseconds(time.Since(start)),
+			"remote_addr", r.RemoteAddr,
+		}
+		if entry.userID != uuid.Nil {
+			attrs = append(attrs, "user_id", entry.userID.String())
+		}
+		level := slog.LevelInfo
+		if writer.status >= http.StatusInternalServerError {
+			level = slog.LevelError
+		}
+		slog.Log(ctx, level, "request", attrs...)
+	})
+}
+
+// setAccessLogUser records the authenticated user in the access log entry.
+func setAccessLogUser(ctx context.Context, userID uuid.UUID) {
+	if entry, ok := ctx.Value("access_log").(*accessLogEntry); ok {
+		entry.userID = userID
+	}
+}
+
+// routeTemplate returns the matched route, e.g. "/users/{id}", which unlike
+// the path does not vary per entity.
+func routeTemplate(r *http.Request) string {
+	if route := mux.CurrentRoute(r); route != nil {
+		if template, err := route.GetPathTemplate(); err == nil {
+			return template
+		}
+	}
+	return ""
+}
+
+// statusWriter remembers the status and size of a response.
+type statusWriter struct {
+	http.ResponseWriter
+	status      int
+	bytes       int
+	wroteHeader bool
+}
+
+func (w *statusWriter) WriteHeader(status int) {
+	if !w.wroteHeader {
+		w.status = status
+		w.wroteHeader = true
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *statusWriter) Write(b []byte) (int, error) {
+	w.wroteHeader = true
+	n, err := w.ResponseWriter.Write(b)
+	w.bytes += n
+	return n, err
+}
+
+func milliseconds(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000
+}
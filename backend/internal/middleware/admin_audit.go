@@ -0,0 +1,82 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+
+	"github.com/Vanv1k/BeautyTON/internal/domain/entity"
+	"github.com/Vanv1k/BeautyTON/internal/usecase"
+)
+
+type AdminAuditMiddlewareConfig struct {
+	AdminUsecase *usecase.AdminUsecase
+}
+
+// AdminAuditMiddleware records every successful mutating admin request in
+// the audit trail. The action is the method and route template, the details
+// are the request body.
+func AdminAuditMiddleware(config *AdminAuditMiddlewareConfig) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !isMutating(r.Method) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			body, _ := io.ReadAll(r.Body)
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			recorder := &responseRecorder{ResponseWriter: w, status: http.StatusOK}
+			next.ServeHTTP(recorder, r)
+			if recorder.status >= http.StatusBadRequest {
+				return
+			}
+
+			template := routeTemplate(r)
+			if template == "" {
+				template = r.URL.Path
+			}
+			adminID, _ := r.Context().Value("user_id").(uuid.UUID)
+			action := &entity.AdminAction{
+				AdminID:    adminID,
+				Action:     r.Method + " " + template,
+				EntityType: auditEntityType(template),
+				EntityID:   mux.Vars(r)["id"],
+				Details:    string(body),
+			}
+			// Created entities have their ID in the response only
+			if action.EntityID == "" {
+				var created struct {
+					ID string `json:"id"`
+				}
+				if json.Unmarshal(recorder.body.Bytes(), &created) == nil {
+					action.EntityID = created.ID
+				}
+			}
+			if err := config.AdminUsecase.RecordAction(context.WithoutCancel(r.Context()), action); err != nil {
+				slog.ErrorContext(r.Context(), "failed to record admin action", "error", err)
+			}
+		})
+	}
+}
+
+// auditEntityType derives the entity type from an admin route template,
+// e.g. "/admin/moderation/reviews/{id}/approve" gives "reviews".
+func auditEntityType(template string) string {
+	parts := strings.Split(strings.Trim(template, "/"), "/")
+	if len(parts) > 1 && parts[1] == "moderation" {
+		parts = parts[1:]
+	}
+	if len(parts) > 1 {
+		return parts[1]
+	}
+	return ""
+}
@@ -2,62 +2,65 @@ package middleware
 
 import (
 	"context"
-	"fmt"
 	"net/http"
 	"strings"
-	"time"
-
-	"github.com/telegram-mini-apps/init-data-golang"
 
+	er "github.com/Vanv1k/BeautyTON/internal/domain/errors"
+	"github.com/Vanv1k/BeautyTON/internal/infrastructure/http/response"
 	"github.com/Vanv1k/BeautyTON/internal/usecase"
 )
 
 type TelegramAuthMiddlewareConfig struct {
-	BotToken    string
-	UserUsecase *usecase.UserUsecase
+	AuthUsecase *usecase.AuthUsecase
 }
 
+// TelegramAuthMiddleware authenticates requests by either "tma <initData>"
+// or "Bearer <access token>" issued by POST /auth/session.
 func TelegramAuthMiddleware(config *TelegramAuthMiddlewareConfig) func(next http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			authHeader := r.Header.Get("Authorization")
 			if authHeader == "" {
-				http.Error(w, "Authorization header missing", http.StatusUnauthorized)
+				response.Error(w, r, er.Unauthorized("authorization header missing"))
 				return
 			}
 
-			// Expect format: "tma <initData>"
+			// Expect format: "tma <initData>" or "Bearer <token>"
 			parts := strings.SplitN(authHeader, " ", 2)
-			if len(parts) != 2 || parts[0] != "tma" {
-				http.Error(w, "Invalid Authorization header format", http.StatusUnauthorized)
-				return
-			}
-			initData := parts[1]
-
-			if err := initdata.Validate(initData, config.BotToken, 24*time.Hour); err != nil {
-				http.Error(w, fmt.Sprintf("Invalid initData: %v", err), http.StatusUnauthorized)
-				return
-			}
-
-			query, err := initdata.Parse(initData)
-			if err != nil {
-				http.Error(w, fmt.Sprintf("Failed to parse initData: %v", err), http.StatusUnauthorized)
+			if len(parts) != 2 {
+				response.Error(w, r, er.Unauthorized("invalid authorization header format"))
 				return
 			}
 
-			user := query.User
-
-			dbUser, err := config.UserUsecase.GetUserByTelegramID(r.Context(), user.ID)
-			if err != nil {
-				http.Error(w, "User not found or server error", http.StatusUnauthorized)
+			ctx := r.Context()
+			switch parts[0] {
+			case "tma":
+				user, err := config.AuthUsecase.AuthenticateInitData(ctx, parts[1])
+				if err != nil {
+					response.Error(w, r, err)
+					return
+				}
+				ctx = context.WithValue(ctx, "telegram_user_id", user.TgID)
+				ctx = context.WithValue(ctx, "user_id", user.ID)
+				ctx = context.WithValue(ctx, "user_role", string(user.Role))
+				setAccessLogUser(ctx, user.ID)
+			case "Bearer":
+				claims, err := config.AuthUsecase.AuthenticateAccessToken(ctx, parts[1])
+				if err != nil {
+					response.Error(w, r, err)
+					return
+				}
+				ctx = context.WithValue(ctx, "telegram_user_id", claims.TelegramID)
+				ctx = context.WithValue(ctx, "user_id", claims.UserID)
+				ctx = context.WithValue(ctx, "user_role", string(claims.Role))
+				ctx = context.WithValue(ctx, "session_id", claims.SessionID)
+				setAccessLogUser(ctx, claims.UserID)
+			default:
+				response.Error(w, r, er.Unauthorized("invalid authorization header format"))
 				return
 			}
 
-			ctx := context.WithValue(r.Context(), "telegram_user_id", user.ID)
-			ctx = context.WithValue(ctx, "user_role", dbUser.Role)
-			r = r.WithContext(ctx)
-
-			next.ServeHTTP(w, r)
+			next.ServeHTTP(w, r.WithContext(ctx))
 		})
 	}
 }
@@ -68,7 +71,7 @@ func RoleMiddleware(requiredRole string) func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			role, ok := r.Context().Value("user_role").(string)
 			if !ok || role != requiredRole {
-				http.Error(w, "Forbidden: insufficient permissions", http.StatusForbidden)
+				response.Error(w, r, er.Forbidden("insufficient permissions"))
 				return
 			}
 			next.ServeHTTP(w, r)
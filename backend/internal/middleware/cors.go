@@ -0,0 +1,177 @@
+package middleware
+
+import (
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+const (
+	corsAllowHeaders  = "Content-Type, Authorization, If-Match, If-None-Match, If-Modified-Since, Range, Idempotency-Key, X-Request-ID, traceparent, tracestate"
+	corsExposeHeaders = "Accept-Ranges, Content-Range, Deprecation, ETag, Idempotent-Replayed, Link, Retry-After, Sunset, X-Request-ID"
+)
+
+type CORSMiddlewareConfig struct {
+	// AllowedOrigins are exact origins, e.g. https://miniapp.beautyton.com,
+	// patterns with a wildcard subdomain, e.g. https://*.pinggy.link, or *
+	AllowedOrigins   []string
+	AllowCredentials bool
+	// MaxAge is how long browsers may cache a preflight response
+	MaxAge time.Duration
+	// Router is used to answer preflights with the methods registered for
+	// the requested route
+	Router *mux.Router
+}
+
+// originPattern matches origins with the same scheme and port and either
+// the same host or, for a wildcard pattern, a subdomain of it.
+type originPattern struct {
+	scheme   string
+	host     string
+	port     string
+	wildcard bool
+}
+
+func parseOrigin(origin string) (originPattern, bool) {
+	u, err := url.Parse(origin)
+	if err != nil || u.Scheme == "" || u.Host == "" || u.User != nil ||
+		(u.Path != "" && u.Path != "/") || u.RawQuery != "" || u.Fragment != "" {
+		return originPattern{}, false
+	}
+	p := originPattern{
+		scheme: strings.ToLower(u.Scheme),
+		host:   strings.ToLower(u.Hostname()),
+		port:   u.Port(),
+	}
+	if p.port == "" {
+		p.port = map[string]string{"http": "80", "https": "443"}[p.scheme]
+	}
+	if rest, ok := strings.CutPrefix(p.host, "*."); ok {
+		p.host, p.wildcard = rest, true
+	}
+	if p.host == "" || strings.Contains(p.host, "*") {
+		return originPattern{}, false
+	}
+	return p, true
+}
+
+func (p originPattern) matches(origin originPattern) bool {
+	if origin.wildcard || origin.scheme != p.scheme || origin.port != p.port {
+		return false
+	}
+	if !p.wildcard {
+		return origin.host == p.host
+	}
+	// Только настоящие поддомены: evilpinggy.link не подходит под *.pinggy.link
+	return strings.HasSuffix(origin.host, "."+p.host)
+}
+
+// CORSMiddleware answers preflight requests and adds CORS headers for the
+// allowed origins. Routes must accept OPTIONS for preflights to reach it.
+func CORSMiddleware(cfg *CORSMiddlewareConfig) mux.MiddlewareFunc {
+	anyOrigin := slices.Contains(cfg.AllowedOrigins, "*")
+	var patterns []originPattern
+	for _, origin := range cfg.AllowedOrigins {
+		if pattern, ok := parseOrigin(origin); ok {
+			patterns = append(patterns, pattern)
+		}
+	}
+	allowed := func(origin string) bool {
+		if anyOrigin {
+			return true
+		}
+		parsed, ok := parseOrigin(origin)
+		if !ok {
+			return false
+		}
+		for _, pattern := range patterns {
+			if pattern.matches(parsed) {
+				return true
+			}
+		}
+		return false
+	}
+
+	// Методы маршрутов известны только после регистрации всех маршрутов
+	var routeMethods map[string][]string
+	var once sync.Once
+	methodsFor := func(r *http.Request) []string {
+		once.Do(func() { routeMethods = collectRouteMethods(cfg.Router) })
+		return routeMethods[routeTemplate(r)]
+	}
+
+	maxAge := strconv.Itoa(int(cfg.MaxAge.Seconds()))
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			origin := r.Header.Get("Origin")
+			header := w.Header()
+			if !anyOrigin || cfg.AllowCredentials {
+				header.Add("Vary", "Origin")
+			}
+			originAllowed := origin != "" && allowed(origin)
+			if originAllowed {
+				// С credentials браузер не принимает *, поэтому origin возвращается как есть
+				if anyOrigin && !cfg.AllowCredentials {
+					header.Set("Access-Control-Allow-Origin", "*")
+				} else {
+					header.Set("Access-Control-Allow-Origin", origin)
+				}
+				if cfg.AllowCredentials {
+					header.Set("Access-Control-Allow-Credentials", "true")
+				}
+			}
+
+			if r.Method != http.MethodOptions {
+				if originAllowed {
+					header.Set("Access-Control-Expose-Headers", corsExposeHeaders)
+				}
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			methods := methodsFor(r)
+			header.Set("Allow", strings.Join(methods, ", "))
+			requested := r.Header.Get("Access-Control-Request-Method")
+			if originAllowed && requested != "" {
+				header.Add("Vary", "Access-Control-Request-Method")
+				header.Add("Vary", "Access-Control-Request-Headers")
+				if slices.Contains(methods, requested) {
+					header.Set("Access-Control-Allow-Methods", strings.Join(methods, ", "))
+					header.Set("Access-Control-Allow-Headers", corsAllowHeaders)
+					header.Set("Access-Control-Max-Age", maxAge)
+				}
+			}
+			w.WriteHeader(http.StatusNoContent)
+		})
+	}
+}
+
+// collectRouteMethods maps each path template to the methods registered
+// for it across all of its routes.
+func collectRouteMethods(router *mux.Router) map[string][]string {
+	methods := map[string][]string{}
+	router.Walk(func(route *mux.Route, _ *mux.Router, _ []*mux.Route) error {
+		template, err := route.GetPathTemplate()
+		if err != nil {
+			return nil
+		}
+		routeMethods, err := route.GetMethods()
+		if err != nil {
+			return nil
+		}
+		for _, method := range routeMethods {
+			if !slices.Contains(methods[template], method) {
+				methods[template] = append(methods[template], method)
+			}
+		}
+		return nil
+	})
+	return methods
+}
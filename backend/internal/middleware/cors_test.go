@@ -0,0 +1,148 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// newCORSRouter serves a route accepting GET and PUT behind the CORS
+// middleware.
+func newCORSRouter(origins ...string) *mux.Router {
+	router := mux.NewRouter()
+	router.HandleFunc("/things/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}).Methods("GET", "PUT", "OPTIONS")
+	router.Use(CORSMiddleware(&CORSMiddlewareConfig{
+		AllowedOrigins: origins,
+		MaxAge:         10 * time.Minute,
+		Router:         router,
+	}))
+	return router
+}
+
+func TestCORSAllowedOrigins(t *testing.T) {
+	router := newCORSRouter("https://*.example.com", "http://localhost:5173")
+
+	tests := []struct {
+		origin  string
+		allowed bool
+	}{
+		{origin: "https://app.example.com", allowed: true},
+		{origin: "https://a.b.example.com", allowed: true},
+		{origin: "https://app.example.com:443", allowed: true},
+		{origin: "HTTPS://App.Example.COM", allowed: true},
+		{origin: "http://localhost:5173", allowed: true},
+		// A wildcard does not match the apex
+		{origin: "https://example.com", allowed: false},
+		// Look-alikes ending with the pattern host
+		{origin: "https://evil-example.com", allowed: false},
+		{origin: "https://evilexample.com", allowed: false},
+		{origin: "https://example.com.evil.com", allowed: false},
+		{origin: "https://app.example.com.evil.com", allowed: false},
+		{origin: "http://app.example.com", allowed: false},
+		{origin: "https://app.example.com:8443", allowed: false},
+		{origin: "http://localhost", allowed: false},
+		{origin: "http://localhost:5174", allowed: false},
+		{origin: "https://localhost:5173", allowed: false},
+		{origin: "https://*.example.com", allowed: false},
+		{origin: "https://user@app.example.com", allowed: false},
+		{origin: "https://app.example.com/path", allowed: false},
+		{origin: "null", allowed: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.origin, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/things/1", nil)
+			r.Header.Set("Origin", tt.origin)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, r)
+
+			got := w.Header().Get("Access-Control-Allow-Origin")
+			if tt.allowed && got != tt.origin {
+				t.Fatalf("Access-Control-Allow-Origin is %q, want the origin", got)
+			}
+			if !tt.allowed && got != "" {
+				t.Fatalf("Access-Control-Allow-Origin is %q, want none", got)
+			}
+			if w.Header().Get("Vary") != "Origin" {
+				t.Fatalf("Vary is %q, want Origin", w.Header().Get("Vary"))
+			}
+		})
+	}
+}
+
+func TestCORSPreflight(t *testing.T) {
+	router := newCORSRouter("https://*.example.com")
+
+	tests := []struct {
+		name      string
+		origin    string
+		method    string
+		wantAllow bool
+	}{
+		{name: "registered method", origin: "https://app.example.com", method: "PUT", wantAllow: true},
+		{name: "unregistered method", origin: "https://app.example.com", method: "DELETE"},
+		{name: "foreign origin", origin: "https://evil-example.com", method: "PUT"},
+		{name: "no requested method", origin: "https://app.example.com"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("OPTIONS", "/things/1", nil)
+			r.Header.Set("Origin", tt.origin)
+			if tt.method != "" {
+				r.Header.Set("Access-Control-Request-Method", tt.method)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, r)
+
+			if w.Code != http.StatusNoContent {
+				t.Fatalf("preflight returned %d, want 204", w.Code)
+			}
+			if allow := w.Header().Get("Allow"); allow != "GET, PUT, OPTIONS" {
+				t.Fatalf("Allow is %q, want the methods of the route", allow)
+			}
+			methods := w.Header().Get("Access-Control-Allow-Methods")
+			maxAge := w.Header().Get("Access-Control-Max-Age")
+			headers := w.Header().Get("Access-Control-Allow-Headers")
+			if !tt.wantAllow {
+				if methods != "" || maxAge != "" || headers != "" {
+					t.Fatalf("preflight allowed methods %q, headers %q, max age %q, want none", methods, headers, maxAge)
+				}
+				return
+			}
+			if methods != "GET, PUT, OPTIONS" {
+				t.Fatalf("Access-Control-Allow-Methods is %q, want the methods of the route", methods)
+			}
+			if maxAge != "600" {
+				t.Fatalf("Access-Control-Max-Age is %q, want 600", maxAge)
+			}
+			if headers != corsAllowHeaders {
+				t.Fatalf("Access-Control-Allow-Headers is %q, want %q", headers, corsAllowHeaders)
+			}
+		})
+	}
+}
+
+func TestCORSPreflightAllowsConditionalAndRangeHeaders(t *testing.T) {
+	router := newCORSRouter("https://*.example.com")
+	r := httptest.NewRequest("OPTIONS", "/things/1", nil)
+	r.Header.Set("Origin", "https://app.example.com")
+	r.Header.Set("Access-Control-Request-Method", "GET")
+	r.Header.Set("Access-Control-Request-Headers", "range, if-none-match, if-modified-since, if-match")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, r)
+
+	allowed := map[string]bool{}
+	for _, name := range strings.Split(w.Header().Get("Access-Control-Allow-Headers"), ",") {
+		allowed[http.CanonicalHeaderKey(strings.TrimSpace(name))] = true
+	}
+	for _, name := range []string{"Range", "If-None-Match", "If-Modified-Since", "If-Match"} {
+		if !allowed[name] {
+			t.Errorf("preflight does not allow %s", name)
+		}
+	}
+}
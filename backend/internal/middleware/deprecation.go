@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+type DeprecationMiddlewareConfig struct {
+	DeprecatedAt time.Time
+	Sunset       time.Time
+	// SuccessorPrefix is prepended to the request path to link to the route
+	// that replaces it, e.g. /v1
+	SuccessorPrefix string
+}
+
+// DeprecationMiddleware announces that a route is deprecated with the
+// Deprecation (RFC 9745) and Sunset (RFC 8594) headers and links to its
+// successor. The route keeps working.
+func DeprecationMiddleware(cfg *DeprecationMiddlewareConfig) func(next http.Handler) http.Handler {
+	deprecation := "@" + strconv.FormatInt(cfg.DeprecatedAt.Unix(), 10)
+	sunset := cfg.Sunset.UTC().Format(http.TimeFormat)
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			header := w.Header()
+			header.Set("Deprecation", deprecation)
+			header.Set("Sunset", sunset)
+			header.Add("Link", "<"+cfg.SuccessorPrefix+r.URL.EscapedPath()+`>; rel="successor-version"`)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,167 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/Vanv1k/BeautyTON/internal/domain/entity"
+	er "github.com/Vanv1k/BeautyTON/internal/domain/errors"
+	"github.com/Vanv1k/BeautyTON/internal/infrastructure/http/response"
+	"github.com/Vanv1k/BeautyTON/internal/usecase"
+)
+
+const maxIdempotencyKeyLength = 255
+
+type IdempotencyMiddlewareConfig struct {
+	IdempotencyUsecase *usecase.IdempotencyUsecase
+}
+
+// IdempotencyMiddleware makes mutating requests sent with an Idempotency-Key
+// header safe to retry. The first request with a key is processed and its
+// response stored; duplicates get the stored response replayed. A key reused
+// with a different request is rejected with 422, a duplicate arriving while
+// the first request is still running with 409. Server errors and other
+// transient responses, such as 429 from a rate limiter, are not stored, so the
+// client may retry them with the same key. Must run after
+// TelegramAuthMiddleware, keys are scoped to the Telegram user.
+func IdempotencyMiddleware(config *IdempotencyMiddlewareConfig) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := r.Header.Get("Idempotency-Key")
+			if key == "" || !isMutating(r.Method) {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if len(key) > maxIdempotencyKeyLength {
+				response.Error(w, r, er.BadRequest("invalid idempotency key"))
+				return
+			}
+			userID, _ := r.Context().Value("telegram_user_id").(int64)
+
+			body, err := io.ReadAll(r.Body)
+			if err != nil {
+				response.Error(w, r, er.BadRequest("invalid request body"))
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			stored, err := config.IdempotencyUsecase.Begin(r.Context(), userID, key, fingerprint(r, body))
+			if err != nil {
+				if e, ok := er.As(err); ok && e.Code == er.CodeConflict {
+					w.Header().Set("Retry-After", "1")
+				}
+				response.Error(w, r, err)
+				return
+			}
+			if stored != nil {
+				replay(w, stored)
+				return
+			}
+
+			recorder := &responseRecorder{ResponseWriter: w, status: http.StatusOK}
+			next.ServeHTTP(recorder, r)
+
+			// The response is already sent, the client going away must not
+			// leave the key locked
+			ctx := context.WithoutCancel(r.Context())
+			if isTransient(recorder.status) {
+				if err := config.IdempotencyUsecase.Release(ctx, userID, key); err != nil {
+					slog.ErrorContext(ctx, "failed to release idempotency key", "error", err)
+				}
+				return
+			}
+			headers, _ := json.Marshal(storedHeaders(w.Header()))
+			record := &entity.IdempotencyKey{
+				UserID:          userID,
+				Key:             key,
+				ResponseStatus:  recorder.status,
+				ResponseHeaders: string(headers),
+				ResponseBody:    recorder.body.Bytes(),
+			}
+			if err := config.IdempotencyUsecase.Complete(ctx, record); err != nil {
+				slog.ErrorContext(ctx, "failed to store idempotent response", "error", err)
+			}
+		})
+	}
+}
+
+func isMutating(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// isTransient reports whether a response says nothing final about the
+// request, so a retry with the same key must process it again.
+func isTransient(status int) bool {
+	switch status {
+	case http.StatusRequestTimeout, http.StatusTooEarly, http.StatusTooManyRequests:
+		return true
+	}
+	return status >= http.StatusInternalServerError
+}
+
+// fingerprint identifies the request a key was first used with.
+func fingerprint(r *http.Request, body []byte) string {
+	hash := sha256.New()
+	io.WriteString(hash, r.Method+" "+r.URL.RequestURI()+"\n")
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// storedHeaders returns the headers set by the handler. CORS headers are
+// left out, they depend on the request and are set again on replay.
+func storedHeaders(header http.Header) http.Header {
+	stored := http.Header{}
+	for name, values := range header {
+		if strings.HasPrefix(name, "Access-Control-") || name == "Vary" {
+			continue
+		}
+		stored[name] = values
+	}
+	return stored
+}
+
+func replay(w http.ResponseWriter, stored *entity.IdempotencyKey) {
+	var headers http.Header
+	if err := json.Unmarshal([]byte(stored.ResponseHeaders), &headers); err != nil {
+		slog.Error("failed to decode stored response headers", "error", err)
+	}
+	for name, values := range headers {
+		w.Header()[name] = values
+	}
+	w.Header().Set("Idempotent-Replayed", "true")
+	w.WriteHeader(stored.ResponseStatus)
+	w.Write(stored.ResponseBody)
+}
+
+// responseRecorder passes the response through and keeps a copy of it.
+type responseRecorder struct {
+	http.ResponseWriter
+	status      int
+	body        bytes.Buffer
+	wroteHeader bool
+}
+
+func (r *responseRecorder) WriteHeader(status int) {
+	if !r.wroteHeader {
+		r.status = status
+		r.wroteHeader = true
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	r.wroteHeader = true
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
@@ -0,0 +1,87 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Vanv1k/BeautyTON/internal/domain/entity"
+	"github.com/Vanv1k/BeautyTON/internal/usecase"
+)
+
+// fakeIdempotencyKeyRepo keeps keys in a map and never expires them.
+type fakeIdempotencyKeyRepo struct {
+	keys map[string]*entity.IdempotencyKey
+}
+
+func (r *fakeIdempotencyKeyRepo) Acquire(ctx context.Context, key *entity.IdempotencyKey) (*entity.IdempotencyKey, error) {
+	if existing, ok := r.keys[key.Key]; ok {
+		return existing, nil
+	}
+	r.keys[key.Key] = key
+	return nil, nil
+}
+
+// Complete stores the response on the acquired record, as the Postgres
+// repository does.
+func (r *fakeIdempotencyKeyRepo) Complete(ctx context.Context, key *entity.IdempotencyKey) error {
+	stored := r.keys[key.Key]
+	stored.Status = key.Status
+	stored.ResponseStatus = key.ResponseStatus
+	stored.ResponseHeaders = key.ResponseHeaders
+	stored.ResponseBody = key.ResponseBody
+	return nil
+}
+
+func (r *fakeIdempotencyKeyRepo) Release(ctx context.Context, userID int64, key string) error {
+	delete(r.keys, key)
+	return nil
+}
+
+func (r *fakeIdempotencyKeyRepo) DeleteExpired(ctx context.Context) (int64, error) {
+	return 0, nil
+}
+
+func TestIdempotencyDoesNotStoreTransientResponses(t *testing.T) {
+	tests := []struct {
+		status int
+		stored bool
+	}{
+		{status: http.StatusCreated, stored: true},
+		{status: http.StatusUnprocessableEntity, stored: true},
+		{status: http.StatusRequestTimeout},
+		{status: http.StatusTooManyRequests},
+		{status: http.StatusInternalServerError},
+		{status: http.StatusServiceUnavailable},
+	}
+	for _, tt := range tests {
+		t.Run(http.StatusText(tt.status), func(t *testing.T) {
+			calls := 0
+			handler := IdempotencyMiddleware(&IdempotencyMiddlewareConfig{
+				IdempotencyUsecase: usecase.NewIdempotencyUsecase(&fakeIdempotencyKeyRepo{keys: map[string]*entity.IdempotencyKey{}}, time.Hour, time.Minute),
+			})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls++
+				w.WriteHeader(tt.status)
+			}))
+
+			for i := 0; i < 2; i++ {
+				r := httptest.NewRequest("POST", "/bookings", nil)
+				r.Header.Set("Idempotency-Key", "key")
+				w := httptest.NewRecorder()
+				handler.ServeHTTP(w, r)
+				if w.Code != tt.status {
+					t.Fatalf("request %d returned %d, want %d", i+1, w.Code, tt.status)
+				}
+			}
+			want := 2
+			if tt.stored {
+				want = 1
+			}
+			if calls != want {
+				t.Fatalf("handler ran %d times, want %d", calls, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Vanv1k/BeautyTON/internal/metrics"
+)
+
+// MetricsMiddleware records the duration of every request by route
+// template, so that requests for different entities share a series.
+func MetricsMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		metrics.HTTPRequestsInFlight.Inc()
+		defer metrics.HTTPRequestsInFlight.Dec()
+
+		writer := &statusWriter{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(writer, r)
+
+		metrics.HTTPRequestDuration.
+			WithLabelValues(r.Method, routeTemplate(r), strconv.Itoa(writer.status)).
+			Observe(time.Since(start).Seconds())
+	})
+}
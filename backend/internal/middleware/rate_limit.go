@@ -0,0 +1,68 @@
+package middleware
+
+import (
+	"log/slog"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+
+	er "github.com/Vanv1k/BeautyTON/internal/domain/errors"
+	"github.com/Vanv1k/BeautyTON/internal/infrastructure/http/response"
+	"github.com/Vanv1k/BeautyTON/internal/usecase"
+)
+
+type RateLimitMiddlewareConfig struct {
+	RateLimitUsecase *usecase.RateLimitUsecase
+	// Group selects the limit, e.g. uploads
+	Group string
+	// TrustForwardedFor takes the client IP from the last X-Forwarded-For
+	// entry. Enable it only behind a proxy that appends that header
+	TrustForwardedFor bool
+}
+
+// RateLimitMiddleware limits requests per authenticated user or, before
+// authentication, per client IP. Exceeding the limit returns 429 with
+// Retry-After. If the limit store fails, requests are let through.
+func RateLimitMiddleware(config *RateLimitMiddlewareConfig) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			subject := "ip:" + clientIP(r, config.TrustForwardedFor)
+			if userID, ok := r.Context().Value("user_id").(uuid.UUID); ok {
+				subject = "user:" + userID.String()
+			}
+
+			retryAfter, err := config.RateLimitUsecase.Allow(r.Context(), config.Group, subject)
+			if err != nil {
+				// Недоступность хранилища лимитов не должна останавливать API
+				slog.ErrorContext(r.Context(), "failed to check rate limit", "group", config.Group, "error", err)
+			}
+			if retryAfter > 0 {
+				w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+				response.Error(w, r, er.TooManyRequests("too many requests"))
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func clientIP(r *http.Request, trustForwardedFor bool) string {
+	if trustForwardedFor {
+		if forwarded := r.Header.Get("X-Forwarded-For"); forwarded != "" {
+			// Последний адрес добавлен нашим прокси, остальные мог подставить клиент
+			entries := strings.Split(forwarded, ",")
+			if ip := strings.TrimSpace(entries[len(entries)-1]); ip != "" {
+				return ip
+			}
+		}
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
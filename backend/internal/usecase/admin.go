@@ -0,0 +1,304 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Vanv1k/BeautyTON/internal/domain/entity"
+	er "github.com/Vanv1k/BeautyTON/internal/domain/errors"
+	"github.com/Vanv1k/BeautyTON/internal/domain/repository"
+)
+
+type AdminUsecase struct {
+	userRepo            repository.UserRepository
+	serviceRepo         repository.ServiceRepository
+	reviewRepo          repository.ReviewRepository
+	photoModerationRepo repository.PhotoModerationRepository
+	adminActionRepo     repository.AdminActionRepository
+	authSessionRepo     repository.AuthSessionRepository
+	fileMetadataRepo    repository.FileMetadataRepository
+}
+
+func NewAdminUsecase(
+	userRepo repository.UserRepository,
+	serviceRepo repository.ServiceRepository,
+	reviewRepo repository.ReviewRepository,
+	photoModerationRepo repository.PhotoModerationRepository,
+	adminActionRepo repository.AdminActionRepository,
+	authSessionRepo repository.AuthSessionRepository,
+	fileMetadataRepo repository.FileMetadataRepository,
+) *AdminUsecase {
+	return &AdminUsecase{
+		userRepo:            userRepo,
+		serviceRepo:         serviceRepo,
+		reviewRepo:          reviewRepo,
+		photoModerationRepo: photoModerationRepo,
+		adminActionRepo:     adminActionRepo,
+		authSessionRepo:     authSessionRepo,
+		fileMetadataRepo:    fileMetadataRepo,
+	}
+}
+
+// BootstrapAdmin grants the admin role to a registered user by Telegram ID.
+func (u *AdminUsecase) BootstrapAdmin(ctx context.Context, telegramID int64) (*entity.User, error) {
+	ctx, span := tracer.Start(ctx, "AdminUsecase.BootstrapAdmin")
+	defer span.End()
+
+	user, err := u.userRepo.GetByTelegramID(ctx, telegramID)
+	if err != nil {
+		if errors.Is(err, er.ErrRecordNotFound) {
+			return nil, er.NotFound("user not found")
+		}
+		return nil, err
+	}
+	if user.Role == entity.UserRoleAdmin {
+		return user, nil
+	}
+	user.Role = entity.UserRoleAdmin
+	if err := u.userRepo.Update(ctx, user); err != nil {
+		return nil, err
+	}
+	// Первый администратор назначает себя сам
+	err = u.RecordAction(ctx, &entity.AdminAction{
+		AdminID:    user.ID,
+		Action:     "bootstrap-admin",
+		EntityType: "users",
+		EntityID:   user.ID.String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+func (u *AdminUsecase) SetUserRole(ctx context.Context, adminID, userID uuid.UUID, role entity.UserRole) (*entity.User, error) {
+	ctx, span := tracer.Start(ctx, "AdminUsecase.SetUserRole")
+	defer span.End()
+
+	if role != entity.UserRoleMaster && role != entity.UserRoleClient && role != entity.UserRoleAdmin {
+		return nil, er.Validation("invalid user role")
+	}
+	// Администратор не может снять роль с самого себя
+	if adminID == userID && role != entity.UserRoleAdmin {
+		return nil, er.Validation("cannot change your own role")
+	}
+	user, err := u.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	user.Role = role
+	if err := u.userRepo.Update(ctx, user); err != nil {
+		return nil, err
+	}
+	// Токены доступа содержат роль, поэтому сессии завершаются
+	if err := u.authSessionRepo.RevokeByUser(ctx, user.ID); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// BanUser blocks a user until unbanned and ends all of their sessions.
+func (u *AdminUsecase) BanUser(ctx context.Context, adminID, userID uuid.UUID, reason string) (*entity.User, error) {
+	ctx, span := tracer.Start(ctx, "AdminUsecase.BanUser")
+	defer span.End()
+
+	now := time.Now()
+	return u.block(ctx, adminID, userID, func(user *entity.User) {
+		user.BannedAt = &now
+		user.BlockReason = reason
+	})
+}
+
+// SuspendUser blocks a user until the given time and ends all of their
+// sessions.
+func (u *AdminUsecase) SuspendUser(ctx context.Context, adminID, userID uuid.UUID, until time.Time, reason string) (*entity.User, error) {
+	ctx, span := tracer.Start(ctx, "AdminUsecase.SuspendUser")
+	defer span.End()
+
+	if !until.After(time.Now()) {
+		return nil, er.Validation("suspension must end in the future")
+	}
+	return u.block(ctx, adminID, userID, func(user *entity.User) {
+		user.SuspendedUntil = &until
+		user.BlockReason = reason
+	})
+}
+
+// UnblockUser lifts a ban and a suspension.
+func (u *AdminUsecase) UnblockUser(ctx context.Context, userID uuid.UUID) (*entity.User, error) {
+	ctx, span := tracer.Start(ctx, "AdminUsecase.UnblockUser")
+	defer span.End()
+
+	user, err := u.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	user.BannedAt = nil
+	user.SuspendedUntil = nil
+	user.BlockReason = ""
+	if err := u.userRepo.Update(ctx, user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+func (u *AdminUsecase) block(ctx context.Context, adminID, userID uuid.UUID, apply func(user *entity.User)) (*entity.User, error) {
+	if adminID == userID {
+		return nil, er.Validation("cannot block yourself")
+	}
+	user, err := u.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	apply(user)
+	if err := u.userRepo.Update(ctx, user); err != nil {
+		return nil, err
+	}
+	if err := u.authSessionRepo.RevokeByUser(ctx, user.ID); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+func (u *AdminUsecase) ListReviews(ctx context.Context, status entity.ModerationStatus, page, pageSize int) ([]entity.Review, int64, error) {
+	ctx, span := tracer.Start(ctx, "AdminUsecase.ListReviews")
+	defer span.End()
+
+	return u.reviewRepo.ListByModerationStatus(ctx, status, page, pageSize)
+}
+
+func (u *AdminUsecase) ModerateReview(ctx context.Context, id uuid.UUID, status entity.ModerationStatus, reason string) (*entity.Review, error) {
+	ctx, span := tracer.Start(ctx, "AdminUsecase.ModerateReview")
+	defer span.End()
+
+	if err := validateModeration(status, reason); err != nil {
+		return nil, err
+	}
+	review, err := u.reviewRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	review.ModerationStatus = status
+	review.ModerationReason = reason
+	if err := u.reviewRepo.Update(ctx, review); err != nil {
+		return nil, err
+	}
+	return review, nil
+}
+
+func (u *AdminUsecase) ListPhotos(ctx context.Context, status entity.ModerationStatus, page, pageSize int) ([]entity.PhotoModeration, int64, error) {
+	ctx, span := tracer.Start(ctx, "AdminUsecase.ListPhotos")
+	defer span.End()
+
+	return u.photoModerationRepo.ListByStatus(ctx, status, page, pageSize)
+}
+
+// ModeratePhoto approves or rejects a queued photo. A rejected photo is
+// removed from its owner unless it has been replaced already.
+func (u *AdminUsecase) ModeratePhoto(ctx context.Context, adminID, id uuid.UUID, status entity.ModerationStatus, reason string) (*entity.PhotoModeration, error) {
+	ctx, span := tracer.Start(ctx, "AdminUsecase.ModeratePhoto")
+	defer span.End()
+
+	if err := validateModeration(status, reason); err != nil {
+		return nil, err
+	}
+	moderation, err := u.photoModerationRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if status == entity.ModerationStatusRejected {
+		if err := u.removePhoto(ctx, moderation); err != nil {
+			return nil, err
+		}
+	}
+
+	now := time.Now()
+	moderation.Status = status
+	moderation.Reason = reason
+	moderation.ModeratorID = &adminID
+	moderation.ModeratedAt = &now
+	if err := u.photoModerationRepo.Update(ctx, moderation); err != nil {
+		return nil, err
+	}
+	return moderation, nil
+}
+
+func (u *AdminUsecase) removePhoto(ctx context.Context, moderation *entity.PhotoModeration) error {
+	switch moderation.OwnerType {
+	case entity.PhotoOwnerUser:
+		user, err := u.userRepo.GetByID(ctx, moderation.OwnerID)
+		if err != nil {
+			return ignoreNotFound(err)
+		}
+		if user.PhotoURL != moderation.FileID {
+			return nil
+		}
+		user.PhotoURL = ""
+		if err := u.userRepo.Update(ctx, user); err != nil {
+			return err
+		}
+	case entity.PhotoOwnerService:
+		service, err := u.serviceRepo.GetByID(ctx, moderation.OwnerID)
+		if err != nil {
+			return ignoreNotFound(err)
+		}
+		if service.PhotoURL != moderation.FileID {
+			return nil
+		}
+		service.PhotoURL = ""
+		if err := u.serviceRepo.Update(ctx, service); err != nil {
+			return err
+		}
+	}
+	// Отклоненное фото удалится после льготного периода
+	return u.fileMetadataRepo.Release(ctx, moderation.FileID, entity.FileReference(moderation.OwnerType, moderation.OwnerID))
+}
+
+func (u *AdminUsecase) RecordAction(ctx context.Context, action *entity.AdminAction) error {
+	ctx, span := tracer.Start(ctx, "AdminUsecase.RecordAction")
+	defer span.End()
+
+	if action.ID == uuid.Nil {
+		action.ID = uuid.New()
+	}
+	return u.adminActionRepo.Create(ctx, action)
+}
+
+func (u *AdminUsecase) ListActions(ctx context.Context, filter entity.AdminActionFilter) ([]entity.AdminAction, int64, error) {
+	ctx, span := tracer.Start(ctx, "AdminUsecase.ListActions")
+	defer span.End()
+
+	return u.adminActionRepo.List(ctx, filter)
+}
+
+func validateModeration(status entity.ModerationStatus, reason string) error {
+	if status != entity.ModerationStatusApproved && status != entity.ModerationStatusRejected {
+		return er.Validation("invalid moderation status")
+	}
+	if status == entity.ModerationStatusRejected && reason == "" {
+		return er.Validation("reason is required to reject")
+	}
+	return nil
+}
+
+func newPhotoModeration(fileID string, ownerType entity.PhotoOwnerType, ownerID uuid.UUID) *entity.PhotoModeration {
+	return &entity.PhotoModeration{
+		ID:        uuid.New(),
+		FileID:    fileID,
+		OwnerType: ownerType,
+		OwnerID:   ownerID,
+		Status:    entity.ModerationStatusPending,
+	}
+}
+
+// ignoreNotFound treats a deleted owner as nothing left to clean up.
+func ignoreNotFound(err error) error {
+	if errors.Is(err, er.ErrRecordNotFound) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,43 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/Vanv1k/BeautyTON/internal/domain/entity"
+	"github.com/Vanv1k/BeautyTON/internal/domain/repository"
+)
+
+type AuditUsecase struct {
+	auditLogRepo repository.AuditLogRepository
+	retention    time.Duration
+}
+
+func NewAuditUsecase(auditLogRepo repository.AuditLogRepository, retention time.Duration) *AuditUsecase {
+	return &AuditUsecase{auditLogRepo: auditLogRepo, retention: retention}
+}
+
+func (u *AuditUsecase) ListLogs(ctx context.Context, filter entity.AuditLogFilter) ([]entity.AuditLog, int64, error) {
+	ctx, span := tracer.Start(ctx, "AuditUsecase.ListLogs")
+	defer span.End()
+
+	return u.auditLogRepo.List(ctx, filter)
+}
+
+// RunRetention deletes entries older than the retention period every
+// interval until ctx is canceled.
+func (u *AuditUsecase) RunRetention(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := u.auditLogRepo.DeleteBefore(ctx, time.Now().Add(-u.retention)); err != nil {
+				slog.ErrorContext(ctx, "failed to delete old audit log entries", "error", err)
+			}
+		}
+	}
+}
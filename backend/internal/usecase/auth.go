@@ -0,0 +1,266 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/telegram-mini-apps/init-data-golang"
+
+	"github.com/Vanv1k/BeautyTON/internal/domain/entity"
+	er "github.com/Vanv1k/BeautyTON/internal/domain/errors"
+	"github.com/Vanv1k/BeautyTON/internal/domain/repository"
+)
+
+// AccessTokenSigner signs and verifies stateless access tokens.
+type AccessTokenSigner interface {
+	Sign(claims *entity.AccessClaims) (string, error)
+	Verify(token string) (*entity.AccessClaims, error)
+}
+
+type AuthUsecase struct {
+	authSessionRepo repository.AuthSessionRepository
+	userRepo        repository.UserRepository
+	signer          AccessTokenSigner
+	botToken        string
+	initDataMaxAge  time.Duration
+	accessTokenTTL  time.Duration
+	refreshTokenTTL time.Duration
+}
+
+func NewAuthUsecase(
+	authSessionRepo repository.AuthSessionRepository,
+	userRepo repository.UserRepository,
+	signer AccessTokenSigner,
+	botToken string,
+	initDataMaxAge time.Duration,
+	accessTokenTTL time.Duration,
+	refreshTokenTTL time.Duration,
+) *AuthUsecase {
+	return &AuthUsecase{
+		authSessionRepo: authSessionRepo,
+		userRepo:        userRepo,
+		signer:          signer,
+		botToken:        botToken,
+		initDataMaxAge:  initDataMaxAge,
+		accessTokenTTL:  accessTokenTTL,
+		refreshTokenTTL: refreshTokenTTL,
+	}
+}
+
+// AuthenticateInitData validates Telegram Mini App initData and returns the
+// registered user it was issued for.
+func (u *AuthUsecase) AuthenticateInitData(ctx context.Context, initData string) (*entity.User, error) {
+	ctx, span := tracer.Start(ctx, "AuthUsecase.AuthenticateInitData")
+	defer span.End()
+
+	if err := initdata.Validate(initData, u.botToken, u.initDataMaxAge); err != nil {
+		return nil, er.Unauthorized("invalid init data").Wrap(err)
+	}
+	data, err := initdata.Parse(initData)
+	if err != nil {
+		return nil, er.Unauthorized("invalid init data").Wrap(err)
+	}
+	user, err := u.userRepo.GetByTelegramID(ctx, data.User.ID)
+	if err != nil {
+		if errors.Is(err, er.ErrRecordNotFound) {
+			return nil, er.Unauthorized("user not registered")
+		}
+		return nil, err
+	}
+	if err := checkBlocked(user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// AuthenticateAccessToken verifies an access token and checks its session
+// has not been revoked.
+func (u *AuthUsecase) AuthenticateAccessToken(ctx context.Context, token string) (*entity.AccessClaims, error) {
+	ctx, span := tracer.Start(ctx, "AuthUsecase.AuthenticateAccessToken")
+	defer span.End()
+
+	claims, err := u.signer.Verify(token)
+	if err != nil {
+		return nil, er.Unauthorized("invalid access token").Wrap(err)
+	}
+	session, err := u.authSessionRepo.GetByID(ctx, claims.SessionID)
+	if err != nil {
+		if errors.Is(err, er.ErrRecordNotFound) {
+			return nil, er.Unauthorized("session revoked")
+		}
+		return nil, err
+	}
+	if session.RevokedAt != nil {
+		return nil, er.Unauthorized("session revoked")
+	}
+	return claims, nil
+}
+
+// CreateSession exchanges initData for a new session.
+func (u *AuthUsecase) CreateSession(ctx context.Context, initData string) (*entity.AuthTokens, error) {
+	ctx, span := tracer.Start(ctx, "AuthUsecase.CreateSession")
+	defer span.End()
+
+	user, err := u.AuthenticateInitData(ctx, initData)
+	if err != nil {
+		return nil, err
+	}
+
+	session := &entity.AuthSession{
+		ID:         uuid.New(),
+		UserID:     user.ID,
+		TelegramID: user.TgID,
+	}
+	refreshToken, token, err := u.newRefreshToken(session.ID)
+	if err != nil {
+		return nil, err
+	}
+	session.ExpiresAt = token.ExpiresAt
+	if err := u.authSessionRepo.Create(ctx, session, token); err != nil {
+		return nil, err
+	}
+	return u.issue(session, user, refreshToken, token)
+}
+
+// RefreshSession rotates a refresh token. A token presented a second time
+// means it leaked, so the whole session is revoked.
+func (u *AuthUsecase) RefreshSession(ctx context.Context, refreshToken string) (*entity.AuthTokens, error) {
+	ctx, span := tracer.Start(ctx, "AuthUsecase.RefreshSession")
+	defer span.End()
+
+	token, err := u.authSessionRepo.GetRefreshToken(ctx, hashToken(refreshToken))
+	if err != nil {
+		if errors.Is(err, er.ErrRecordNotFound) {
+			return nil, er.Unauthorized("invalid refresh token")
+		}
+		return nil, err
+	}
+	if token.UsedAt != nil {
+		return nil, u.revokeReused(ctx, token.SessionID)
+	}
+	if !time.Now().Before(token.ExpiresAt) {
+		return nil, er.Unauthorized("invalid refresh token")
+	}
+
+	session, err := u.authSessionRepo.GetByID(ctx, token.SessionID)
+	if err != nil {
+		return nil, err
+	}
+	if session.RevokedAt != nil {
+		return nil, er.Unauthorized("session revoked")
+	}
+	// Роль могла измениться с момента входа
+	user, err := u.userRepo.GetByID(ctx, session.UserID)
+	if err != nil {
+		if errors.Is(err, er.ErrRecordNotFound) {
+			return nil, er.Unauthorized("user not registered")
+		}
+		return nil, err
+	}
+	if err := checkBlocked(user); err != nil {
+		return nil, err
+	}
+
+	nextToken, next, err := u.newRefreshToken(session.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.authSessionRepo.UseRefreshToken(ctx, token.ID, next); err != nil {
+		if errors.Is(err, er.ErrRecordNotFound) {
+			return nil, u.revokeReused(ctx, session.ID)
+		}
+		return nil, err
+	}
+	return u.issue(session, user, nextToken, next)
+}
+
+// RevokeSession ends a session, its tokens stop working immediately.
+func (u *AuthUsecase) RevokeSession(ctx context.Context, id uuid.UUID) error {
+	ctx, span := tracer.Start(ctx, "AuthUsecase.RevokeSession")
+	defer span.End()
+
+	return u.authSessionRepo.Revoke(ctx, id)
+}
+
+// RunCleanup deletes expired sessions every interval until ctx is canceled.
+func (u *AuthUsecase) RunCleanup(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := u.authSessionRepo.DeleteExpired(ctx); err != nil {
+				slog.ErrorContext(ctx, "failed to delete expired sessions", "error", err)
+			}
+		}
+	}
+}
+
+func (u *AuthUsecase) revokeReused(ctx context.Context, sessionID uuid.UUID) error {
+	if err := u.authSessionRepo.Revoke(ctx, sessionID); err != nil {
+		return err
+	}
+	return er.Unauthorized("refresh token reused, session revoked")
+}
+
+func (u *AuthUsecase) newRefreshToken(sessionID uuid.UUID) (string, *entity.RefreshToken, error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", nil, err
+	}
+	value := base64.RawURLEncoding.EncodeToString(raw)
+	return value, &entity.RefreshToken{
+		ID:        uuid.New(),
+		SessionID: sessionID,
+		TokenHash: hashToken(value),
+		ExpiresAt: time.Now().Add(u.refreshTokenTTL),
+	}, nil
+}
+
+func (u *AuthUsecase) issue(session *entity.AuthSession, user *entity.User, refreshToken string, token *entity.RefreshToken) (*entity.AuthTokens, error) {
+	now := time.Now()
+	claims := &entity.AccessClaims{
+		SessionID:  session.ID,
+		UserID:     user.ID,
+		TelegramID: user.TgID,
+		Role:       user.Role,
+		IssuedAt:   now,
+		ExpiresAt:  now.Add(u.accessTokenTTL),
+	}
+	accessToken, err := u.signer.Sign(claims)
+	if err != nil {
+		return nil, err
+	}
+	return &entity.AuthTokens{
+		SessionID:        session.ID,
+		AccessToken:      accessToken,
+		AccessExpiresAt:  claims.ExpiresAt,
+		RefreshToken:     refreshToken,
+		RefreshExpiresAt: token.ExpiresAt,
+	}, nil
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// checkBlocked rejects banned users and users with an active suspension.
+func checkBlocked(user *entity.User) error {
+	if user.BannedAt != nil {
+		return er.Forbidden("user is banned")
+	}
+	if user.SuspendedUntil != nil && time.Now().Before(*user.SuspendedUntil) {
+		return er.Forbidden("user is suspended").WithDetails(map[string]time.Time{"suspended_until": *user.SuspendedUntil})
+	}
+	return nil
+}
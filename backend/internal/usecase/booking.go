@@ -2,9 +2,10 @@ package usecase
 
 import (
 	"context"
-	"errors"
 	"github.com/Vanv1k/BeautyTON/internal/domain/entity"
+	er "github.com/Vanv1k/BeautyTON/internal/domain/errors"
 	"github.com/Vanv1k/BeautyTON/internal/domain/repository"
+	"github.com/Vanv1k/BeautyTON/internal/metrics"
 	"github.com/google/uuid"
 	"time"
 )
@@ -20,20 +21,21 @@ func NewBookingUsecase(bookingRepo repository.BookingRepository, userRepo reposi
 }
 
 func (u *BookingUsecase) GetBooking(ctx context.Context, id uuid.UUID) (*entity.Booking, error) {
+	ctx, span := tracer.Start(ctx, "BookingUsecase.GetBooking")
+	defer span.End()
+
 	return u.bookingRepo.GetByID(ctx, id)
 }
 
 func (u *BookingUsecase) CreateBooking(ctx context.Context, booking *entity.Booking) error {
-	// Валидация бизнес-логики
-	if booking.ClientID == uuid.Nil || booking.MasterID == uuid.Nil || booking.ServiceID == uuid.Nil {
-		return errors.New("client_id, master_id, and service_id are required")
-	}
-	if booking.BookingTime.Before(time.Now()) {
-		return errors.New("booking_time must be in the future")
+	ctx, span := tracer.Start(ctx, "BookingUsecase.CreateBooking")
+	defer span.End()
+
+	if err := validateBooking(booking); err != nil {
+		return err
 	}
-	if booking.Status != entity.BookingStatusPending && booking.Status != entity.BookingStatusConfirmed &&
-		booking.Status != entity.BookingStatusCompleted && booking.Status != entity.BookingStatusCanceled {
-		return errors.New("invalid booking status")
+	if booking.ID == uuid.Nil {
+		booking.ID = uuid.New()
 	}
 	// Проверяем, что клиент и мастер существуют
 	client, err := u.userRepo.GetByID(ctx, booking.ClientID)
@@ -41,54 +43,134 @@ func (u *BookingUsecase) CreateBooking(ctx context.Context, booking *entity.Book
 		return err
 	}
 	if client.Role != entity.UserRoleClient {
-		return errors.New("client_id must refer to a client")
+		return er.Validation("client_id must refer to a client")
 	}
 	master, err := u.userRepo.GetByID(ctx, booking.MasterID)
 	if err != nil {
 		return err
 	}
 	if master.Role != entity.UserRoleMaster {
-		return errors.New("master_id must refer to a master")
+		return er.Validation("master_id must refer to a master")
 	}
 	// Проверяем, что услуга существует
-	if _, err := u.serviceRepo.GetByID(ctx, booking.ServiceID); err != nil {
+	service, err := u.serviceRepo.GetByID(ctx, booking.ServiceID)
+	if err != nil {
+		return err
+	}
+	if err := setBookingPrice(booking, service); err != nil {
+		return err
+	}
+	if booking.IsModelBooking {
+		if !booking.PortfolioConsent {
+			return er.Validation("model booking requires portfolio_consent")
+		}
+		// Лимит проверяется в транзакции вместе с созданием
+		if err := u.bookingRepo.CreateModelBooking(ctx, booking); err != nil {
+			return err
+		}
+		metrics.BookingsCreated.Inc()
+		return nil
+	}
+	if err := u.bookingRepo.Create(ctx, booking); err != nil {
 		return err
 	}
-	return u.bookingRepo.Create(ctx, booking)
+	metrics.BookingsCreated.Inc()
+	return nil
 }
 
 func (u *BookingUsecase) UpdateBooking(ctx context.Context, booking *entity.Booking) error {
-	// Валидация бизнес-логики
-	if booking.ClientID == uuid.Nil || booking.MasterID == uuid.Nil || booking.ServiceID == uuid.Nil {
-		return errors.New("client_id, master_id, and service_id are required")
+	ctx, span := tracer.Start(ctx, "BookingUsecase.UpdateBooking")
+	defer span.End()
+
+	if err := validateBooking(booking); err != nil {
+		return err
 	}
-	if booking.BookingTime.Before(time.Now()) {
-		return errors.New("booking_time must be in the future")
+	if booking.IsModelBooking && !booking.PortfolioConsent {
+		return er.Validation("model booking requires portfolio_consent")
+	}
+	// Услуга могла смениться вместе с ценой
+	service, err := u.serviceRepo.GetByID(ctx, booking.ServiceID)
+	if err != nil {
+		return err
 	}
-	if booking.Status != entity.BookingStatusPending && booking.Status != entity.BookingStatusConfirmed &&
-		booking.Status != entity.BookingStatusCompleted && booking.Status != entity.BookingStatusCanceled {
-		return errors.New("invalid booking status")
+	if service.UserID == nil || *service.UserID != booking.MasterID {
+		return er.Validation("service must belong to the master")
 	}
-	return u.bookingRepo.Update(ctx, booking)
+	if err := setBookingPrice(booking, service); err != nil {
+		return err
+	}
+	// Лимит новой услуги проверяется в транзакции вместе с обновлением
+	return u.updateBooking(ctx, booking)
 }
 
-func (u *BookingUsecase) UpdateBookingStatus(ctx context.Context, id uuid.UUID, status entity.BookingStatus) (*entity.Booking, error) {
-	// Валидация бизнес-логики
-	if status != entity.BookingStatusPending && status != entity.BookingStatusConfirmed &&
-		status != entity.BookingStatusCompleted && status != entity.BookingStatusCanceled {
-		return nil, errors.New("invalid booking status")
-	}
-	booking, err := u.bookingRepo.GetByID(ctx, id)
-	if err != nil {
-		return nil, err
+// UpdateBookingStatus moves booking to status. The booking is saved only if
+// it is still at the version it was read at.
+func (u *BookingUsecase) UpdateBookingStatus(ctx context.Context, booking *entity.Booking, status entity.BookingStatus) error {
+	ctx, span := tracer.Start(ctx, "BookingUsecase.UpdateBookingStatus")
+	defer span.End()
+
+	if !validBookingStatus(status) {
+		return er.Validation("invalid booking status")
 	}
+	previous := booking.Status
 	booking.Status = status
-	if err := u.bookingRepo.Update(ctx, booking); err != nil {
-		return nil, err
+	if err := u.updateBooking(ctx, booking); err != nil {
+		return err
 	}
-	return booking, nil
+	if status == entity.BookingStatusCanceled && previous != status {
+		metrics.BookingsCanceled.Inc()
+	}
+	return nil
 }
 
 func (u *BookingUsecase) DeleteBooking(ctx context.Context, id uuid.UUID) error {
+	ctx, span := tracer.Start(ctx, "BookingUsecase.DeleteBooking")
+	defer span.End()
+
 	return u.bookingRepo.Delete(ctx, id)
 }
+
+// updateBooking saves booking. Model bookings go through the repository
+// check of the model bookings limit.
+func (u *BookingUsecase) updateBooking(ctx context.Context, booking *entity.Booking) error {
+	if booking.IsModelBooking {
+		return u.bookingRepo.UpdateModelBooking(ctx, booking)
+	}
+	return u.bookingRepo.Update(ctx, booking)
+}
+
+// setBookingPrice charges a model booking the model price of the service and
+// any other booking its regular price.
+func setBookingPrice(booking *entity.Booking, service *entity.Service) error {
+	if !booking.IsModelBooking {
+		booking.Price = service.Price
+		return nil
+	}
+	if !service.ModelOffer || service.ModelPrice == nil {
+		return er.Validation("service has no model offer")
+	}
+	booking.Price = *service.ModelPrice
+	return nil
+}
+
+func validateBooking(booking *entity.Booking) error {
+	// Валидация бизнес-логики
+	if booking.ClientID == uuid.Nil || booking.MasterID == uuid.Nil || booking.ServiceID == uuid.Nil {
+		return er.Validation("client_id, master_id, and service_id are required")
+	}
+	if booking.BookingTime.Before(time.Now()) {
+		return er.Validation("booking_time must be in the future")
+	}
+	if !validBookingStatus(booking.Status) {
+		return er.Validation("invalid booking status")
+	}
+	return nil
+}
+
+func validBookingStatus(status entity.BookingStatus) bool {
+	switch status {
+	case entity.BookingStatusPending, entity.BookingStatusConfirmed, entity.BookingStatusCompleted, entity.BookingStatusCanceled:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,224 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Vanv1k/BeautyTON/internal/domain/entity"
+	er "github.com/Vanv1k/BeautyTON/internal/domain/errors"
+	"github.com/Vanv1k/BeautyTON/internal/domain/repository"
+)
+
+// The fakes below keep records in maps. Their embedded interfaces panic on
+// calls a test does not expect.
+
+type fakeUserRepo struct {
+	repository.UserRepository
+	users map[uuid.UUID]*entity.User
+}
+
+func (r *fakeUserRepo) GetByID(ctx context.Context, id uuid.UUID) (*entity.User, error) {
+	if user, ok := r.users[id]; ok {
+		return user, nil
+	}
+	return nil, er.ErrRecordNotFound
+}
+
+type fakeServiceRepo struct {
+	repository.ServiceRepository
+	services map[uuid.UUID]*entity.Service
+}
+
+func (r *fakeServiceRepo) GetByID(ctx context.Context, id uuid.UUID) (*entity.Service, error) {
+	if service, ok := r.services[id]; ok {
+		return service, nil
+	}
+	return nil, er.ErrRecordNotFound
+}
+
+type fakeBookingRepo struct {
+	repository.BookingRepository
+	bookings map[uuid.UUID]*entity.Booking
+	// modelLimitReached makes the checks of the model bookings limit fail
+	modelLimitReached bool
+}
+
+func (r *fakeBookingRepo) GetByID(ctx context.Context, id uuid.UUID) (*entity.Booking, error) {
+	if booking, ok := r.bookings[id]; ok {
+		return booking, nil
+	}
+	return nil, er.ErrRecordNotFound
+}
+
+func (r *fakeBookingRepo) Create(ctx context.Context, booking *entity.Booking) error {
+	r.bookings[booking.ID] = booking
+	return nil
+}
+
+func (r *fakeBookingRepo) CreateModelBooking(ctx context.Context, booking *entity.Booking) error {
+	if r.modelLimitReached {
+		return er.ErrModelBookingsLimitReached
+	}
+	r.bookings[booking.ID] = booking
+	return nil
+}
+
+func (r *fakeBookingRepo) Update(ctx context.Context, booking *entity.Booking) error {
+	r.bookings[booking.ID] = booking
+	return nil
+}
+
+func (r *fakeBookingRepo) UpdateModelBooking(ctx context.Context, booking *entity.Booking) error {
+	if r.modelLimitReached {
+		return er.ErrModelBookingsLimitReached
+	}
+	r.bookings[booking.ID] = booking
+	return nil
+}
+
+// bookingFixture is a client, a master and a service with a model offer.
+type bookingFixture struct {
+	users    *fakeUserRepo
+	services *fakeServiceRepo
+	bookings *fakeBookingRepo
+	client   uuid.UUID
+	master   uuid.UUID
+	service  *entity.Service
+}
+
+func newBookingFixture() *bookingFixture {
+	f := &bookingFixture{
+		users:    &fakeUserRepo{users: map[uuid.UUID]*entity.User{}},
+		services: &fakeServiceRepo{services: map[uuid.UUID]*entity.Service{}},
+		bookings: &fakeBookingRepo{bookings: map[uuid.UUID]*entity.Booking{}},
+		client:   uuid.New(),
+		master:   uuid.New(),
+	}
+	f.users.users[f.client] = &entity.User{ID: f.client, Role: entity.UserRoleClient}
+	f.users.users[f.master] = &entity.User{ID: f.master, Role: entity.UserRoleMaster}
+	modelPrice := 300.0
+	f.service = &entity.Service{
+		ID:                 uuid.New(),
+		UserID:             &f.master,
+		Price:              1000,
+		ModelOffer:         true,
+		ModelPrice:         &modelPrice,
+		ModelBookingsLimit: 5,
+	}
+	f.services.services[f.service.ID] = f.service
+	return f
+}
+
+func (f *bookingFixture) booking(model bool) *entity.Booking {
+	return &entity.Booking{
+		ClientID:         f.client,
+		MasterID:         f.master,
+		ServiceID:        f.service.ID,
+		BookingTime:      time.Now().Add(24 * time.Hour),
+		Status:           entity.BookingStatusPending,
+		IsModelBooking:   model,
+		PortfolioConsent: model,
+	}
+}
+
+func TestCreateBookingChargesModelPrice(t *testing.T) {
+	f := newBookingFixture()
+	u := NewBookingUsecase(f.bookings, f.users, f.services)
+
+	regular := f.booking(false)
+	if err := u.CreateBooking(context.Background(), regular); err != nil {
+		t.Fatalf("regular booking: %v", err)
+	}
+	if regular.Price != 1000 {
+		t.Fatalf("regular booking price is %v, want 1000", regular.Price)
+	}
+
+	model := f.booking(true)
+	if err := u.CreateBooking(context.Background(), model); err != nil {
+		t.Fatalf("model booking: %v", err)
+	}
+	if model.Price != 300 {
+		t.Fatalf("model booking price is %v, want 300", model.Price)
+	}
+
+	f.service.ModelOffer = false
+	err := u.CreateBooking(context.Background(), f.booking(true))
+	if !errors.Is(err, er.Validation("service has no model offer")) {
+		t.Fatalf("model booking without an offer: got %v", err)
+	}
+}
+
+func TestUpdateBookingRepricesNewService(t *testing.T) {
+	f := newBookingFixture()
+	u := NewBookingUsecase(f.bookings, f.users, f.services)
+
+	booking := f.booking(true)
+	if err := u.CreateBooking(context.Background(), booking); err != nil {
+		t.Fatalf("create booking: %v", err)
+	}
+	modelPrice := 0.0
+	other := &entity.Service{ID: uuid.New(), UserID: &f.master, Price: 2000, ModelOffer: true, ModelPrice: &modelPrice, ModelBookingsLimit: 1}
+	f.services.services[other.ID] = other
+
+	booking.ServiceID = other.ID
+	if err := u.UpdateBooking(context.Background(), booking); err != nil {
+		t.Fatalf("update booking: %v", err)
+	}
+	if booking.Price != 0 {
+		t.Fatalf("model booking price is %v, want 0", booking.Price)
+	}
+}
+
+func TestUpdateBookingStatusChecksModelLimit(t *testing.T) {
+	f := newBookingFixture()
+	u := NewBookingUsecase(f.bookings, f.users, f.services)
+
+	booking := f.booking(true)
+	booking.Status = entity.BookingStatusCanceled
+	if err := u.CreateBooking(context.Background(), booking); err != nil {
+		t.Fatalf("create booking: %v", err)
+	}
+
+	f.bookings.modelLimitReached = true
+	stored, err := u.GetBooking(context.Background(), booking.ID)
+	if err != nil {
+		t.Fatalf("get booking: %v", err)
+	}
+	err = u.UpdateBookingStatus(context.Background(), stored, entity.BookingStatusPending)
+	if !errors.Is(err, er.ErrModelBookingsLimitReached) {
+		t.Fatalf("restore canceled model booking: got %v, want model bookings limit reached", err)
+	}
+}
+
+func TestUpdateBookingChecksNewService(t *testing.T) {
+	f := newBookingFixture()
+	u := NewBookingUsecase(f.bookings, f.users, f.services)
+
+	booking := f.booking(true)
+	if err := u.CreateBooking(context.Background(), booking); err != nil {
+		t.Fatalf("create booking: %v", err)
+	}
+	modelPrice := 0.0
+	stranger := uuid.New()
+	foreign := &entity.Service{ID: uuid.New(), UserID: &stranger, Price: 2000, ModelOffer: true, ModelPrice: &modelPrice, ModelBookingsLimit: 1}
+	f.services.services[foreign.ID] = foreign
+
+	booking.ServiceID = foreign.ID
+	err := u.UpdateBooking(context.Background(), booking)
+	if !errors.Is(err, er.Validation("service must belong to the master")) {
+		t.Fatalf("move to a service of another master: got %v", err)
+	}
+
+	full := &entity.Service{ID: uuid.New(), UserID: &f.master, Price: 2000, ModelOffer: true, ModelPrice: &modelPrice, ModelBookingsLimit: 1}
+	f.services.services[full.ID] = full
+	f.bookings.modelLimitReached = true
+	booking.ServiceID = full.ID
+	err = u.UpdateBooking(context.Background(), booking)
+	if !errors.Is(err, er.ErrModelBookingsLimitReached) {
+		t.Fatalf("move to a full model offer: got %v, want model bookings limit reached", err)
+	}
+}
@@ -2,12 +2,12 @@ package usecase
 
 import (
 	"context"
-	"errors"
 	"strings"
 
 	"github.com/google/uuid"
 
 	"github.com/Vanv1k/BeautyTON/internal/domain/entity"
+	er "github.com/Vanv1k/BeautyTON/internal/domain/errors"
 	"github.com/Vanv1k/BeautyTON/internal/domain/repository"
 )
 
@@ -24,42 +24,46 @@ func NewCityUsecase(cityRepo repository.CityRepository, countryRepo repository.C
 }
 
 func (u *CityUsecase) GetCity(ctx context.Context, id uuid.UUID) (*entity.City, error) {
+	ctx, span := tracer.Start(ctx, "CityUsecase.GetCity")
+	defer span.End()
+
 	return u.cityRepo.GetByID(ctx, id)
 }
 
 func (u *CityUsecase) CreateCity(ctx context.Context, city *entity.City) error {
-	// Валидация бизнес-логики
-	if city.Name == "" {
-		return errors.New("name is required")
-	}
-	if city.CountryID == uuid.Nil {
-		return errors.New("country_id is required")
+	ctx, span := tracer.Start(ctx, "CityUsecase.CreateCity")
+	defer span.End()
+
+	if err := u.validateCity(ctx, city); err != nil {
+		return err
 	}
-	if _, err := u.countryRepo.GetByID(ctx, city.CountryID); err != nil {
-		return errors.New("invalid country_id")
+	if city.ID == uuid.Nil {
+		city.ID = uuid.New()
 	}
 	return u.cityRepo.Create(ctx, city)
 }
 
 func (u *CityUsecase) UpdateCity(ctx context.Context, city *entity.City) error {
-	// Валидация бизнес-логики
-	if city.Name == "" {
-		return errors.New("name is required")
-	}
-	if city.CountryID == uuid.Nil {
-		return errors.New("country_id is required")
-	}
-	if _, err := u.countryRepo.GetByID(ctx, city.CountryID); err != nil {
-		return errors.New("invalid country_id")
+	ctx, span := tracer.Start(ctx, "CityUsecase.UpdateCity")
+	defer span.End()
+
+	if err := u.validateCity(ctx, city); err != nil {
+		return err
 	}
 	return u.cityRepo.Update(ctx, city)
 }
 
 func (u *CityUsecase) DeleteCity(ctx context.Context, id uuid.UUID) error {
+	ctx, span := tracer.Start(ctx, "CityUsecase.DeleteCity")
+	defer span.End()
+
 	return u.cityRepo.Delete(ctx, id)
 }
 
 func (u *CityUsecase) ListCities(ctx context.Context, query string, page, pageSize int) ([]entity.City, int64, error) {
+	ctx, span := tracer.Start(ctx, "CityUsecase.ListCities")
+	defer span.End()
+
 	// Валидация
 	if page < 1 {
 		page = 1
@@ -74,3 +78,17 @@ func (u *CityUsecase) ListCities(ctx context.Context, query string, page, pageSi
 
 	return u.cityRepo.ListCities(ctx, query, page, pageSize)
 }
+
+func (u *CityUsecase) validateCity(ctx context.Context, city *entity.City) error {
+	// Валидация бизнес-логики
+	if city.Name == "" {
+		return er.Validation("name is required")
+	}
+	if city.CountryID == uuid.Nil {
+		return er.Validation("country_id is required")
+	}
+	if _, err := u.countryRepo.GetByID(ctx, city.CountryID); err != nil {
+		return er.Validation("invalid country_id")
+	}
+	return nil
+}
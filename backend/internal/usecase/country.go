@@ -2,11 +2,11 @@ package usecase
 
 import (
 	"context"
-	"errors"
 
 	"github.com/google/uuid"
 
 	"github.com/Vanv1k/BeautyTON/internal/domain/entity"
+	er "github.com/Vanv1k/BeautyTON/internal/domain/errors"
 	"github.com/Vanv1k/BeautyTON/internal/domain/repository"
 )
 
@@ -21,31 +21,49 @@ func NewCountryUsecase(countryRepo repository.CountryRepository) *CountryUsecase
 }
 
 func (u *CountryUsecase) GetCountry(ctx context.Context, id uuid.UUID) (*entity.Country, error) {
+	ctx, span := tracer.Start(ctx, "CountryUsecase.GetCountry")
+	defer span.End()
+
 	return u.countryRepo.GetByID(ctx, id)
 }
 
 func (u *CountryUsecase) CreateCountry(ctx context.Context, country *entity.Country) error {
-	// Валидация бизнес-логики
-	if country.Name == "" {
-		return errors.New("name is required")
+	ctx, span := tracer.Start(ctx, "CountryUsecase.CreateCountry")
+	defer span.End()
+
+	if err := validateCountry(country); err != nil {
+		return err
 	}
-	if country.Code == "" {
-		return errors.New("code is required")
+	if country.ID == uuid.Nil {
+		country.ID = uuid.New()
 	}
 	return u.countryRepo.Create(ctx, country)
 }
 
 func (u *CountryUsecase) UpdateCountry(ctx context.Context, country *entity.Country) error {
-	// Валидация бизнес-логики
-	if country.Name == "" {
-		return errors.New("name is required")
-	}
-	if country.Code == "" {
-		return errors.New("code is required")
+	ctx, span := tracer.Start(ctx, "CountryUsecase.UpdateCountry")
+	defer span.End()
+
+	if err := validateCountry(country); err != nil {
+		return err
 	}
 	return u.countryRepo.Update(ctx, country)
 }
 
 func (u *CountryUsecase) DeleteCountry(ctx context.Context, id uuid.UUID) error {
+	ctx, span := tracer.Start(ctx, "CountryUsecase.DeleteCountry")
+	defer span.End()
+
 	return u.countryRepo.Delete(ctx, id)
 }
+
+func validateCountry(country *entity.Country) error {
+	// Валидация бизнес-логики
+	if country.Name == "" {
+		return er.Validation("name is required")
+	}
+	if country.Code == "" {
+		return er.Validation("code is required")
+	}
+	return nil
+}
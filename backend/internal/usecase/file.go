@@ -4,36 +4,175 @@ import (
 	"context"
 	"errors"
 	"io"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
 
 	"github.com/Vanv1k/BeautyTON/internal/domain/entity"
-	"github.com/Vanv1k/BeautyTON/internal/domain/repository"
+	er "github.com/Vanv1k/BeautyTON/internal/domain/errors"
 )
 
+// fileCleanupBatch bounds how many files one cleanup run deletes.
+const fileCleanupBatch = 100
+
 type FileUsecase struct {
-	fileRepo repository.FileRepository
+	files *FileStore
+	// orphanGrace is how long an unreferenced file is kept before it is
+	// deleted
+	orphanGrace time.Duration
 }
 
-func NewFileUsecase(fileRepo repository.FileRepository) *FileUsecase {
-	return &FileUsecase{fileRepo: fileRepo}
+func NewFileUsecase(files *FileStore, orphanGrace time.Duration) *FileUsecase {
+	return &FileUsecase{files: files, orphanGrace: orphanGrace}
 }
 
+// UploadFile stores a private attachment of its owner.
 func (u *FileUsecase) UploadFile(ctx context.Context, file *entity.File, content io.Reader) error {
-	// Валидация бизнес-логики
-	if file.ID == "" {
-		return errors.New("file ID is required")
+	ctx, span := tracer.Start(ctx, "FileUsecase.UploadFile")
+	defer span.End()
+
+	file.Purpose = entity.FilePurposeAttachment
+	return u.files.storeFile(ctx, file, content, entity.FileReference(entity.PhotoOwnerUser, file.OwnerID))
+}
+
+// PresignUpload registers a private attachment the owner uploads to the
+// storage directly and returns the request to upload it with.
+func (u *FileUsecase) PresignUpload(ctx context.Context, file *entity.File) (*entity.PresignedRequest, error) {
+	ctx, span := tracer.Start(ctx, "FileUsecase.PresignUpload")
+	defer span.End()
+
+	file.Purpose = entity.FilePurposeAttachment
+	return u.files.presignUpload(ctx, file)
+}
+
+// CompleteUpload registers a presigned upload of the owner once the storage
+// holds it as declared.
+func (u *FileUsecase) CompleteUpload(ctx context.Context, ownerID uuid.UUID, id string) (*entity.File, error) {
+	ctx, span := tracer.Start(ctx, "FileUsecase.CompleteUpload")
+	defer span.End()
+
+	return u.files.completeFile(ctx, ownerID, id, entity.FilePurposeAttachment, entity.FileReference(entity.PhotoOwnerUser, ownerID))
+}
+
+// StatFile describes a variant of a file readable by the user, see
+// readableFile, without reading its content. A file without the requested
+// variant is described whole.
+func (u *FileUsecase) StatFile(ctx context.Context, userID uuid.UUID, role entity.UserRole, id string, variant entity.ImageVariant) (*entity.File, error) {
+	ctx, span := tracer.Start(ctx, "FileUsecase.StatFile")
+	defer span.End()
+
+	file, err := u.readableFile(ctx, userID, role, id)
+	if err != nil {
+		return nil, err
 	}
-	if file.Size <= 0 {
-		return errors.New("file size must be positive")
+	stored, err := u.files.fileRepo.Stat(ctx, file.ObjectKey(variant))
+	if err != nil {
+		return nil, err
 	}
-	if file.MimeType == "" {
-		return errors.New("file MIME type is required")
+	return describeStored(file, stored), nil
+}
+
+// ReadFile reads a variant of a file described by StatFile, only the bytes
+// in rng when it is set. The caller closes the content.
+func (u *FileUsecase) ReadFile(ctx context.Context, file *entity.File, variant entity.ImageVariant, rng *entity.ByteRange) (*entity.File, io.ReadCloser, error) {
+	ctx, span := tracer.Start(ctx, "FileUsecase.ReadFile")
+	defer span.End()
+
+	stored, content, err := u.files.fileRepo.Get(ctx, file.ObjectKey(variant), rng)
+	if err != nil {
+		return nil, nil, err
 	}
-	return u.fileRepo.Upload(ctx, file, content)
+	return describeStored(file, stored), content, nil
 }
 
-func (u *FileUsecase) GetFile(ctx context.Context, id string) (*entity.File, io.Reader, error) {
+// GetFileURL issues a presigned URL downloading a variant of a file
+// readable by the user, see GetFile.
+func (u *FileUsecase) GetFileURL(ctx context.Context, userID uuid.UUID, role entity.UserRole, id string, variant entity.ImageVariant) (*entity.PresignedRequest, error) {
+	ctx, span := tracer.Start(ctx, "FileUsecase.GetFileURL")
+	defer span.End()
+
+	file, err := u.readableFile(ctx, userID, role, id)
+	if err != nil {
+		return nil, err
+	}
+	return u.files.fileRepo.PresignDownload(ctx, file.ObjectKey(variant), u.files.downloadTTL)
+}
+
+// describeStored completes a variant as the storage describes it with the
+// metadata of its file. Size, type and version of the variant are known
+// only to the storage.
+func describeStored(file, stored *entity.File) *entity.File {
+	stored.ID = file.ID
+	stored.Name = file.Name
+	stored.OwnerID = file.OwnerID
+	stored.Purpose = file.Purpose
+	stored.Variants = file.Variants
+	return stored
+}
+
+// readableFile returns a file if the user may read it: public files to
+// anyone, private ones to their owner and admins. Other files are reported
+// as not found so that their existence is not disclosed.
+func (u *FileUsecase) readableFile(ctx context.Context, userID uuid.UUID, role entity.UserRole, id string) (*entity.File, error) {
 	if id == "" {
-		return nil, nil, errors.New("file ID is required")
+		return nil, er.Validation("file ID is required")
+	}
+	file, err := u.files.fileMetadataRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !file.Purpose.Public() && file.OwnerID != userID && role != entity.UserRoleAdmin {
+		return nil, er.ErrRecordNotFound
+	}
+	return file, nil
+}
+
+// RunCleanup deletes files that have been unreferenced for longer than the
+// grace period every interval until ctx is canceled.
+func (u *FileUsecase) RunCleanup(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := u.deleteOrphans(ctx); err != nil {
+				slog.ErrorContext(ctx, "failed to delete unreferenced files", "error", err)
+			}
+		}
+	}
+}
+
+func (u *FileUsecase) deleteOrphans(ctx context.Context) error {
+	ctx, span := tracer.Start(ctx, "FileUsecase.deleteOrphans")
+	defer span.End()
+
+	before := time.Now().Add(-u.orphanGrace)
+	files, err := u.files.fileMetadataRepo.ListUnreferenced(ctx, before, fileCleanupBatch)
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		// Сначала запись: файл, на который успели сослаться, не удаляется,
+		// а объект без записи в худшем случае остается в хранилище
+		deleted, err := u.files.fileMetadataRepo.DeleteUnreferenced(ctx, file.ID, before)
+		if err != nil {
+			return err
+		}
+		if !deleted {
+			continue
+		}
+		keys := []string{file.ID}
+		for _, variant := range file.Variants {
+			keys = append(keys, file.ObjectKey(variant))
+		}
+		for _, key := range keys {
+			if err := u.files.fileRepo.Delete(ctx, key); err != nil && !errors.Is(err, er.ErrRecordNotFound) {
+				slog.ErrorContext(ctx, "failed to delete file from storage", "file_id", file.ID, "key", key, "error", err)
+			}
+		}
 	}
-	return u.fileRepo.Get(ctx, id)
+	return nil
 }
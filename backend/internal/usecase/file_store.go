@@ -0,0 +1,309 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log/slog"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Vanv1k/BeautyTON/internal/domain/entity"
+	er "github.com/Vanv1k/BeautyTON/internal/domain/errors"
+	"github.com/Vanv1k/BeautyTON/internal/domain/repository"
+)
+
+// maxUploadSize bounds a presigned upload like the multipart endpoints do.
+const maxUploadSize = 10 << 20
+
+// photoMimeTypes are the photo formats ImageProcessor accepts.
+var photoMimeTypes = []string{"image/jpeg", "image/png", "image/webp"}
+
+// ImageProcessor turns an uploaded photo into one image per
+// entity.ImageVariants, upright and without metadata.
+type ImageProcessor interface {
+	Process(content io.Reader) ([]entity.Image, error)
+}
+
+// FileStore keeps the objects in the storage and their registry entries in
+// step. It is shared by the usecases that store files.
+//
+// Every file is registered unreferenced before its content is stored and is
+// referenced only once everything is stored, so a failed or abandoned
+// upload is deleted by the cleanup together with whatever was stored.
+type FileStore struct {
+	fileRepo         repository.FileRepository
+	fileMetadataRepo repository.FileMetadataRepository
+	images           ImageProcessor
+	// publicURL is the CDN serving the storage, download URLs are presigned
+	// without it
+	publicURL   string
+	uploadTTL   time.Duration
+	downloadTTL time.Duration
+}
+
+func NewFileStore(fileRepo repository.FileRepository, fileMetadataRepo repository.FileMetadataRepository, images ImageProcessor, publicURL string, uploadTTL, downloadTTL time.Duration) *FileStore {
+	return &FileStore{
+		fileRepo:         fileRepo,
+		fileMetadataRepo: fileMetadataRepo,
+		images:           images,
+		publicURL:        strings.TrimSuffix(publicURL, "/"),
+		uploadTTL:        uploadTTL,
+		downloadTTL:      downloadTTL,
+	}
+}
+
+// storeFile uploads the content as is.
+func (s *FileStore) storeFile(ctx context.Context, file *entity.File, content io.Reader, reference string) error {
+	// Валидация бизнес-логики
+	if err := validateFile(file); err != nil {
+		return err
+	}
+	if file.Size <= 0 {
+		return er.Validation("file size must be positive")
+	}
+	if file.MimeType == "" {
+		return er.Validation("file MIME type is required")
+	}
+
+	file.ReferencedBy = ""
+	if err := s.fileMetadataRepo.Create(ctx, file); err != nil {
+		return err
+	}
+	hash := sha256.New()
+	if err := s.fileRepo.Upload(ctx, file, io.TeeReader(content, hash)); err != nil {
+		return err
+	}
+	file.Checksum = hex.EncodeToString(hash.Sum(nil))
+	file.ReferencedBy = reference
+	return s.fileMetadataRepo.Update(ctx, file)
+}
+
+// storePhoto stores the variants of a photo instead of the upload: the full
+// variant under the file ID and the smaller ones under derived keys.
+func (s *FileStore) storePhoto(ctx context.Context, file *entity.File, content io.Reader, reference string) error {
+	if err := validateFile(file); err != nil {
+		return err
+	}
+	variants, err := s.images.Process(content)
+	if err != nil {
+		return err
+	}
+
+	setVariants(file, variants)
+	file.ReferencedBy = ""
+	if err := s.fileMetadataRepo.Create(ctx, file); err != nil {
+		return err
+	}
+	if err := s.uploadVariants(ctx, file, variants); err != nil {
+		return err
+	}
+	file.ReferencedBy = reference
+	return s.fileMetadataRepo.Update(ctx, file)
+}
+
+// presignUpload registers a file the client uploads to the storage itself
+// and returns the request to upload it with. The storage accepts only the
+// declared size, type and checksum; the upload is finished by completeFile
+// or completePhoto.
+func (s *FileStore) presignUpload(ctx context.Context, file *entity.File) (*entity.PresignedRequest, error) {
+	// Валидация бизнес-логики
+	if err := validateFile(file); err != nil {
+		return nil, err
+	}
+	if file.Size <= 0 {
+		return nil, er.Validation("file size must be positive")
+	}
+	if file.Size > maxUploadSize {
+		return nil, er.Validation("file is too large")
+	}
+	if file.MimeType == "" {
+		return nil, er.Validation("file MIME type is required")
+	}
+	if file.Purpose != entity.FilePurposeAttachment && !slices.Contains(photoMimeTypes, file.MimeType) {
+		return nil, er.Unprocessable("unsupported image format")
+	}
+	if sum, err := hex.DecodeString(file.Checksum); err != nil || len(sum) != sha256.Size {
+		return nil, er.Validation("invalid checksum")
+	}
+
+	file.ReferencedBy = ""
+	if err := s.fileMetadataRepo.Create(ctx, file); err != nil {
+		return nil, err
+	}
+	return s.fileRepo.PresignUpload(ctx, file, s.uploadTTL)
+}
+
+// completeFile references a presigned upload once it is verified.
+func (s *FileStore) completeFile(ctx context.Context, ownerID uuid.UUID, id string, purpose entity.FilePurpose, reference string) (*entity.File, error) {
+	file, err := s.verifyUpload(ctx, ownerID, id, purpose)
+	if err != nil {
+		return nil, err
+	}
+	file.ReferencedBy = reference
+	if err := s.fileMetadataRepo.Update(ctx, file); err != nil {
+		return nil, err
+	}
+	return file, nil
+}
+
+// completePhoto replaces a presigned upload of a photo with its variants,
+// as storePhoto does, and references it.
+func (s *FileStore) completePhoto(ctx context.Context, ownerID uuid.UUID, id string, purpose entity.FilePurpose, reference string) (*entity.File, error) {
+	file, err := s.verifyUpload(ctx, ownerID, id, purpose)
+	if err != nil {
+		return nil, err
+	}
+	_, content, err := s.fileRepo.Get(ctx, file.ID, nil)
+	if err != nil {
+		return nil, err
+	}
+	variants, err := s.images.Process(content)
+	content.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	setVariants(file, variants)
+	if err := s.uploadVariants(ctx, file, variants); err != nil {
+		return nil, err
+	}
+	file.ReferencedBy = reference
+	if err := s.fileMetadataRepo.Update(ctx, file); err != nil {
+		return nil, err
+	}
+	return file, nil
+}
+
+// verifyUpload checks that a pending presigned upload of the owner was
+// stored as declared. A file that does not match stays unreferenced and is
+// cleaned up.
+func (s *FileStore) verifyUpload(ctx context.Context, ownerID uuid.UUID, id string, purpose entity.FilePurpose) (*entity.File, error) {
+	if id == "" {
+		return nil, er.Validation("file ID is required")
+	}
+	// Отличаем от отсутствия записи, к которой привязывается файл; чужие
+	// загрузки не раскрываются
+	file, err := s.fileMetadataRepo.GetByID(ctx, id)
+	if errors.Is(err, er.ErrRecordNotFound) {
+		return nil, er.NotFound("file not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if file.OwnerID != ownerID || file.Purpose != purpose {
+		return nil, er.NotFound("file not found")
+	}
+	if file.ReferencedBy != "" {
+		return nil, er.Conflict("file upload is already completed")
+	}
+	stored, err := s.fileRepo.Stat(ctx, file.ID)
+	if errors.Is(err, er.ErrRecordNotFound) {
+		return nil, er.Unprocessable("file is not uploaded")
+	}
+	if err != nil {
+		return nil, err
+	}
+	// Хранилище может не знать контрольную сумму, тогда ее проверило
+	// условие подписанного запроса
+	if stored.Size != file.Size || stored.MimeType != file.MimeType ||
+		(stored.Checksum != "" && stored.Checksum != file.Checksum) {
+		return nil, er.Unprocessable("uploaded file does not match")
+	}
+	return file, nil
+}
+
+// uploadVariants stores the variants of a photo, the full one under the
+// file ID.
+func (s *FileStore) uploadVariants(ctx context.Context, file *entity.File, variants []entity.Image) error {
+	for _, image := range variants {
+		object := &entity.File{
+			ID:       file.ObjectKey(image.Variant),
+			Name:     file.Name,
+			Size:     int64(len(image.Data)),
+			MimeType: image.MimeType,
+		}
+		if err := s.fileRepo.Upload(ctx, object, bytes.NewReader(image.Data)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// photoURLs maps every variant of a photo to the URL it is downloaded from:
+// under the public URL when there is one, presigned otherwise. It returns
+// nil when the URLs cannot be issued, the photo is then downloaded through
+// the API.
+func (s *FileStore) photoURLs(ctx context.Context, id string) map[entity.ImageVariant]string {
+	if id == "" {
+		return nil
+	}
+	file, err := s.fileMetadataRepo.GetByID(ctx, id)
+	if err != nil {
+		if !errors.Is(err, er.ErrRecordNotFound) {
+			slog.WarnContext(ctx, "failed to get photo", "file_id", id, "error", err)
+			return nil
+		}
+		// Незарегистрированное фото хранится целиком
+		file = &entity.File{ID: id}
+	}
+	urls := make(map[entity.ImageVariant]string, len(entity.ImageVariants))
+	for _, variant := range entity.ImageVariants {
+		key := file.ObjectKey(variant)
+		if s.publicURL != "" {
+			urls[variant] = s.publicURL + "/" + url.PathEscape(key)
+			continue
+		}
+		request, err := s.fileRepo.PresignDownload(ctx, key, s.downloadTTL)
+		if errors.Is(err, er.ErrPresignNotSupported) {
+			return nil
+		}
+		if err != nil {
+			slog.WarnContext(ctx, "failed to presign photo download", "file_id", id, "error", err)
+			return nil
+		}
+		urls[variant] = request.URL
+	}
+	return urls
+}
+
+func (s *FileStore) release(ctx context.Context, id, reference string) error {
+	return s.fileMetadataRepo.Release(ctx, id, reference)
+}
+
+func (s *FileStore) releaseAll(ctx context.Context, reference string) error {
+	return s.fileMetadataRepo.ReleaseAll(ctx, reference)
+}
+
+func validateFile(file *entity.File) error {
+	if file.ID == "" {
+		return er.Validation("file ID is required")
+	}
+	if file.OwnerID == uuid.Nil {
+		return er.Validation("file owner is required")
+	}
+	return nil
+}
+
+// setVariants describes the file by its full variant and lists the smaller
+// ones.
+func setVariants(file *entity.File, variants []entity.Image) {
+	file.Variants = nil
+	for _, image := range variants {
+		if image.Variant != entity.ImageVariantFull {
+			file.Variants = append(file.Variants, image.Variant)
+			continue
+		}
+		file.Size = int64(len(image.Data))
+		file.MimeType = image.MimeType
+		sum := sha256.Sum256(image.Data)
+		file.Checksum = hex.EncodeToString(sum[:])
+	}
+}
@@ -0,0 +1,93 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/Vanv1k/BeautyTON/internal/domain/entity"
+	er "github.com/Vanv1k/BeautyTON/internal/domain/errors"
+	"github.com/Vanv1k/BeautyTON/internal/domain/repository"
+)
+
+type IdempotencyUsecase struct {
+	idempotencyKeyRepo repository.IdempotencyKeyRepository
+	ttl                time.Duration
+	lockTimeout        time.Duration
+}
+
+func NewIdempotencyUsecase(
+	idempotencyKeyRepo repository.IdempotencyKeyRepository,
+	ttl time.Duration,
+	lockTimeout time.Duration,
+) *IdempotencyUsecase {
+	return &IdempotencyUsecase{
+		idempotencyKeyRepo: idempotencyKeyRepo,
+		ttl:                ttl,
+		lockTimeout:        lockTimeout,
+	}
+}
+
+// Begin locks key for a new request. It returns nil if the caller should
+// process the request, or the stored response of an earlier request with the
+// same key and fingerprint.
+func (u *IdempotencyUsecase) Begin(ctx context.Context, userID int64, key, fingerprint string) (*entity.IdempotencyKey, error) {
+	ctx, span := tracer.Start(ctx, "IdempotencyUsecase.Begin")
+	defer span.End()
+
+	record := &entity.IdempotencyKey{
+		UserID:      userID,
+		Key:         key,
+		Fingerprint: fingerprint,
+		Status:      entity.IdempotencyKeyStatusInProgress,
+		CreatedAt:   time.Now(),
+		ExpiresAt:   time.Now().Add(u.lockTimeout),
+	}
+	existing, err := u.idempotencyKeyRepo.Acquire(ctx, record)
+	if err != nil || existing == nil {
+		return nil, err
+	}
+
+	// Ключ нельзя переиспользовать для другого запроса
+	if existing.Fingerprint != fingerprint {
+		return nil, er.Unprocessable("idempotency key was used with a different request")
+	}
+	if existing.Status != entity.IdempotencyKeyStatusCompleted {
+		return nil, er.Conflict("request with this idempotency key is in progress")
+	}
+	return existing, nil
+}
+
+// Complete stores the response of a request locked by Begin for the TTL.
+func (u *IdempotencyUsecase) Complete(ctx context.Context, record *entity.IdempotencyKey) error {
+	ctx, span := tracer.Start(ctx, "IdempotencyUsecase.Complete")
+	defer span.End()
+
+	record.Status = entity.IdempotencyKeyStatusCompleted
+	record.ExpiresAt = time.Now().Add(u.ttl)
+	return u.idempotencyKeyRepo.Complete(ctx, record)
+}
+
+// Release unlocks key without storing a response, so the client may retry.
+func (u *IdempotencyUsecase) Release(ctx context.Context, userID int64, key string) error {
+	ctx, span := tracer.Start(ctx, "IdempotencyUsecase.Release")
+	defer span.End()
+
+	return u.idempotencyKeyRepo.Release(ctx, userID, key)
+}
+
+// RunCleanup deletes expired keys every interval until ctx is canceled.
+func (u *IdempotencyUsecase) RunCleanup(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := u.idempotencyKeyRepo.DeleteExpired(ctx); err != nil {
+				slog.ErrorContext(ctx, "failed to delete expired idempotency keys", "error", err)
+			}
+		}
+	}
+}
@@ -2,49 +2,68 @@ package usecase
 
 import (
 	"context"
-	"errors"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"slices"
 	"strings"
 
 	"github.com/google/uuid"
 
 	"github.com/Vanv1k/BeautyTON/internal/domain/entity"
+	er "github.com/Vanv1k/BeautyTON/internal/domain/errors"
 	"github.com/Vanv1k/BeautyTON/internal/domain/repository"
 )
 
+// CatalogHook adjusts the first page of catalog results before it is
+// returned, e.g. to inject promoted masters. profiles may hold more results
+// than fit in the page; the hook returns at most pageSize of them. Entries it
+// marks IsPromoted are left out of the organic results of the next pages.
+type CatalogHook interface {
+	Apply(ctx context.Context, filter entity.MasterProfileFilter, profiles []entity.CatalogMasterProfile, pageSize int) ([]entity.CatalogMasterProfile, error)
+}
+
 type MasterProfileUsecase struct {
 	masterProfileRepo repository.MasterProfileRepository
 	userRepo          repository.UserRepository
+	catalogHooks      []CatalogHook
 }
 
-func NewMasterProfileUsecase(masterProfileRepo repository.MasterProfileRepository, userRepo repository.UserRepository) *MasterProfileUsecase {
-	return &MasterProfileUsecase{masterProfileRepo: masterProfileRepo, userRepo: userRepo}
+func NewMasterProfileUsecase(masterProfileRepo repository.MasterProfileRepository, userRepo repository.UserRepository, catalogHooks ...CatalogHook) *MasterProfileUsecase {
+	return &MasterProfileUsecase{masterProfileRepo: masterProfileRepo, userRepo: userRepo, catalogHooks: catalogHooks}
 }
 
 func (u *MasterProfileUsecase) GetMasterProfile(ctx context.Context, id uuid.UUID) (*entity.MasterProfile, error) {
+	ctx, span := tracer.Start(ctx, "MasterProfileUsecase.GetMasterProfile")
+	defer span.End()
+
 	return u.masterProfileRepo.GetByID(ctx, id)
 }
 
 func (u *MasterProfileUsecase) CreateMasterProfile(ctx context.Context, profile *entity.MasterProfile) error {
-	// Валидация бизнес-логики
-	if profile.UserID != nil {
-		user, err := u.userRepo.GetByID(ctx, *profile.UserID)
-		if err != nil {
-			return err
-		}
-		if user.Role != entity.UserRoleMaster {
-			return errors.New("user must have master role")
-		}
+	ctx, span := tracer.Start(ctx, "MasterProfileUsecase.CreateMasterProfile")
+	defer span.End()
+
+	if err := u.validateMasterProfile(ctx, profile); err != nil {
+		return err
 	}
-	if profile.QRCode == "" {
-		return errors.New("qr_code is required")
-	}
-	if profile.Rating < 0 || profile.Rating > 5 {
-		return errors.New("rating must be between 0 and 5")
+	if profile.ID == uuid.Nil {
+		profile.ID = uuid.New()
 	}
 	return u.masterProfileRepo.Create(ctx, profile)
 }
 
 func (u *MasterProfileUsecase) UpdateMasterProfile(ctx context.Context, profile *entity.MasterProfile) error {
+	ctx, span := tracer.Start(ctx, "MasterProfileUsecase.UpdateMasterProfile")
+	defer span.End()
+
+	if err := u.validateMasterProfile(ctx, profile); err != nil {
+		return err
+	}
+	return u.masterProfileRepo.Update(ctx, profile)
+}
+
+func (u *MasterProfileUsecase) validateMasterProfile(ctx context.Context, profile *entity.MasterProfile) error {
 	// Валидация бизнес-логики
 	if profile.UserID != nil {
 		user, err := u.userRepo.GetByID(ctx, *profile.UserID)
@@ -52,22 +71,25 @@ func (u *MasterProfileUsecase) UpdateMasterProfile(ctx context.Context, profile
 			return err
 		}
 		if user.Role != entity.UserRoleMaster {
-			return errors.New("user must have master role")
+			return er.Validation("user must have master role")
 		}
 	}
 	if profile.QRCode == "" {
-		return errors.New("qr_code is required")
+		return er.Validation("qr_code is required")
 	}
 	if profile.Rating < 0 || profile.Rating > 5 {
-		return errors.New("rating must be between 0 and 5")
+		return er.Validation("rating must be between 0 and 5")
 	}
-	return u.masterProfileRepo.Update(ctx, profile)
+	return validateLocation(profile.Latitude, profile.Longitude)
 }
 
 func (u *MasterProfileUsecase) UpdateMasterProfileRating(ctx context.Context, id uuid.UUID, rating float64) (*entity.MasterProfile, error) {
+	ctx, span := tracer.Start(ctx, "MasterProfileUsecase.UpdateMasterProfileRating")
+	defer span.End()
+
 	// Валидация бизнес-логики
 	if rating < 0 || rating > 5 {
-		return nil, errors.New("rating must be between 0 and 5")
+		return nil, er.Validation("rating must be between 0 and 5")
 	}
 	profile, err := u.masterProfileRepo.GetByID(ctx, id)
 	if err != nil {
@@ -81,10 +103,16 @@ func (u *MasterProfileUsecase) UpdateMasterProfileRating(ctx context.Context, id
 }
 
 func (u *MasterProfileUsecase) DeleteMasterProfile(ctx context.Context, id uuid.UUID) error {
+	ctx, span := tracer.Start(ctx, "MasterProfileUsecase.DeleteMasterProfile")
+	defer span.End()
+
 	return u.masterProfileRepo.Delete(ctx, id)
 }
 
-func (u *MasterProfileUsecase) List(ctx context.Context, query, category, city string, priceFrom, priceTo int, rating float64, page, pageSize int) ([]entity.MasterProfile, int64, error) {
+func (u *MasterProfileUsecase) List(ctx context.Context, filter entity.MasterProfileFilter, cursor string, page, pageSize int) ([]entity.CatalogMasterProfile, int64, string, error) {
+	ctx, span := tracer.Start(ctx, "MasterProfileUsecase.List")
+	defer span.End()
+
 	if page < 1 {
 		page = 1
 	}
@@ -96,22 +124,192 @@ func (u *MasterProfileUsecase) List(ctx context.Context, query, category, city s
 	}
 
 	// Validate price range
-	if priceFrom < 0 || priceTo < 0 {
-		return nil, 0, errors.New("priceFrom and priceTo must be non-negative")
+	if filter.PriceFrom < 0 || filter.PriceTo < 0 {
+		return nil, 0, "", er.Validation("priceFrom and priceTo must be non-negative")
 	}
-	if priceFrom > priceTo && priceTo != 0 {
-		return nil, 0, errors.New("priceFrom cannot be greater than priceTo")
+	if filter.PriceFrom > filter.PriceTo && filter.PriceTo != 0 {
+		return nil, 0, "", er.Validation("priceFrom cannot be greater than priceTo")
 	}
 
 	// Validate rating
-	if rating < 0 || rating > 5 {
-		return nil, 0, errors.New("rating must be between 0 and 5")
+	if filter.Rating < 0 || filter.Rating > 5 {
+		return nil, 0, "", er.Validation("rating must be between 0 and 5")
 	}
 
 	// Trim query and city strings
-	query = strings.TrimSpace(query)
-	city = strings.TrimSpace(city)
-	category = strings.TrimSpace(category)
+	filter.Query = strings.TrimSpace(filter.Query)
+	filter.City = strings.TrimSpace(filter.City)
+	filter.Category = strings.TrimSpace(filter.Category)
+
+	// Validate sort
+	if filter.Sort == "" {
+		filter.Sort = entity.MasterProfileSortRating
+		if filter.Query != "" {
+			filter.Sort = entity.MasterProfileSortRelevance
+		}
+	}
+	switch filter.Sort {
+	case entity.MasterProfileSortRating, entity.MasterProfileSortReviewCount, entity.MasterProfileSortPriceAsc,
+		entity.MasterProfileSortPriceDesc, entity.MasterProfileSortNewest, entity.MasterProfileSortAvailability:
+	case entity.MasterProfileSortRelevance:
+		if filter.Query == "" {
+			return nil, 0, "", er.Validation("sorting by relevance requires a query")
+		}
+	case entity.MasterProfileSortDistance:
+		if filter.Lat == nil || filter.Lon == nil {
+			return nil, 0, "", er.Validation("sorting by distance requires lat and lon")
+		}
+	default:
+		return nil, 0, "", er.Validation("invalid sort")
+	}
+	if err := validateLocation(filter.Lat, filter.Lon); err != nil {
+		return nil, 0, "", err
+	}
+
+	var after *entity.MasterProfileCursor
+	if cursor != "" {
+		decoded, err := decodeMasterProfileCursor(cursor)
+		if err != nil {
+			return nil, 0, "", err
+		}
+		if decoded.Sort != filter.Sort {
+			return nil, 0, "", er.Validation("cursor does not match sort")
+		}
+		if decoded.Filter != hashMasterProfileFilter(filter) {
+			return nil, 0, "", er.Validation("cursor does not match filter")
+		}
+		after = decoded
+	}
+
+	// Fetch one extra profile to know whether there is a next page
+	fetched, total, err := u.masterProfileRepo.List(ctx, filter, after, page, pageSize+1)
+	if err != nil {
+		return nil, 0, "", err
+	}
+	profiles := fetched
+	if after == nil && page == 1 {
+		for _, hook := range u.catalogHooks {
+			if profiles, err = hook.Apply(ctx, filter, profiles, pageSize); err != nil {
+				return nil, 0, "", err
+			}
+		}
+	}
+	if len(profiles) > pageSize {
+		profiles = profiles[:pageSize]
+	}
 
-	return u.masterProfileRepo.List(ctx, query, category, city, priceFrom, priceTo, rating, page, pageSize)
+	// The cursor is taken from the last organic entry, so the ones pushed
+	// off the page by injected entries open the next page
+	var exclude []uuid.UUID
+	if after != nil {
+		exclude = after.Exclude
+	}
+	var last *entity.CatalogMasterProfile
+	shown := 0
+	for i := range profiles {
+		if profiles[i].IsPromoted {
+			exclude = append(exclude, profiles[i].ID)
+			continue
+		}
+		last = &profiles[i]
+		shown++
+	}
+	remaining := 0
+	for _, profile := range fetched {
+		if !slices.Contains(exclude, profile.ID) {
+			remaining++
+		}
+	}
+	next := ""
+	if remaining > shown && last != nil {
+		next, err = encodeMasterProfileCursor(&entity.MasterProfileCursor{
+			Sort:    filter.Sort,
+			Filter:  hashMasterProfileFilter(filter),
+			Key:     last.SortKey,
+			ID:      last.ID,
+			Exclude: exclude,
+		})
+		if err != nil {
+			return nil, 0, "", err
+		}
+	}
+	return profiles, total, next, nil
+}
+
+// Cursors are opaque to clients: base64url-encoded JSON of the last seen
+// sort key and profile ID and of the filter hash.
+func encodeMasterProfileCursor(cursor *entity.MasterProfileCursor) (string, error) {
+	data, err := json.Marshal(cursor)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// hashMasterProfileFilter identifies the normalized filter a cursor was
+// issued for.
+func hashMasterProfileFilter(filter entity.MasterProfileFilter) string {
+	// Маршалинг структуры без map детерминирован
+	data, _ := json.Marshal(filter)
+	sum := sha256.Sum256(data)
+	return base64.RawURLEncoding.EncodeToString(sum[:16])
+}
+
+func decodeMasterProfileCursor(cursor string) (*entity.MasterProfileCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, er.Validation("invalid cursor")
+	}
+	var decoded entity.MasterProfileCursor
+	if err := json.Unmarshal(data, &decoded); err != nil || decoded.ID == uuid.Nil {
+		return nil, er.Validation("invalid cursor")
+	}
+	return &decoded, nil
+}
+
+const (
+	defaultNearbyRadiusKm = 10
+	maxNearbyRadiusKm     = 100
+)
+
+func (u *MasterProfileUsecase) ListNearby(ctx context.Context, lat, lon, radiusKm float64, excludeID *uuid.UUID, limit int) ([]entity.NearbyMasterProfile, error) {
+	ctx, span := tracer.Start(ctx, "MasterProfileUsecase.ListNearby")
+	defer span.End()
+
+	if err := validateLocation(&lat, &lon); err != nil {
+		return nil, err
+	}
+	if radiusKm < 0 {
+		return nil, er.Validation("radius must be non-negative")
+	}
+	if radiusKm == 0 {
+		radiusKm = defaultNearbyRadiusKm
+	}
+	if radiusKm > maxNearbyRadiusKm {
+		radiusKm = maxNearbyRadiusKm
+	}
+	if limit < 1 {
+		limit = 10
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	return u.masterProfileRepo.ListNearby(ctx, lat, lon, radiusKm, excludeID, limit)
+}
+
+func validateLocation(lat, lon *float64) error {
+	if (lat == nil) != (lon == nil) {
+		return er.Validation("latitude and longitude must be set together")
+	}
+	if lat == nil {
+		return nil
+	}
+	if *lat < -90 || *lat > 90 {
+		return er.Validation("latitude must be between -90 and 90")
+	}
+	if *lon < -180 || *lon > 180 {
+		return er.Validation("longitude must be between -180 and 180")
+	}
+	return nil
 }
@@ -0,0 +1,145 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/Vanv1k/BeautyTON/internal/domain/entity"
+	er "github.com/Vanv1k/BeautyTON/internal/domain/errors"
+	"github.com/Vanv1k/BeautyTON/internal/domain/repository"
+)
+
+// catalogRepo pages through a fixed, already sorted catalog. The embedded
+// interface panics on any other call.
+type catalogRepo struct {
+	repository.MasterProfileRepository
+	profiles []entity.CatalogMasterProfile
+}
+
+func (r *catalogRepo) List(ctx context.Context, filter entity.MasterProfileFilter, cursor *entity.MasterProfileCursor, page, pageSize int) ([]entity.CatalogMasterProfile, int64, error) {
+	profiles := r.profiles
+	if cursor != nil {
+		for i, profile := range profiles {
+			if profile.ID == cursor.ID {
+				profiles = profiles[i+1:]
+				break
+			}
+		}
+	}
+	var result []entity.CatalogMasterProfile
+	for _, profile := range profiles {
+		if len(result) == pageSize {
+			break
+		}
+		if cursor == nil || !slices.Contains(cursor.Exclude, profile.ID) {
+			result = append(result, profile)
+		}
+	}
+	return result, int64(len(r.profiles)), nil
+}
+
+func TestCatalogCursorIsBoundToFilter(t *testing.T) {
+	repo := &catalogRepo{}
+	for i := 0; i < 3; i++ {
+		repo.profiles = append(repo.profiles, entity.CatalogMasterProfile{
+			MasterProfile: entity.MasterProfile{ID: uuid.New()},
+			SortKey:       float64(3 - i),
+		})
+	}
+	u := NewMasterProfileUsecase(repo, nil)
+
+	lat, lon := 55.75, 37.62
+	filter := entity.MasterProfileFilter{Query: "nails", Lat: &lat, Lon: &lon, Sort: entity.MasterProfileSortDistance}
+	_, _, cursor, err := u.List(context.Background(), filter, "", 1, 2)
+	if err != nil {
+		t.Fatalf("first page: %v", err)
+	}
+	if cursor == "" {
+		t.Fatal("first page: no next cursor")
+	}
+
+	// Whitespace is normalized before the filter is hashed
+	same := filter
+	same.Query = " nails "
+	if _, _, _, err := u.List(context.Background(), same, cursor, 1, 2); err != nil {
+		t.Fatalf("next page with the same filter: %v", err)
+	}
+
+	otherLat := 59.93
+	tests := []struct {
+		name   string
+		change func(f *entity.MasterProfileFilter)
+		want   error
+	}{
+		{name: "query", change: func(f *entity.MasterProfileFilter) { f.Query = "hair" }, want: er.Validation("cursor does not match filter")},
+		{name: "location", change: func(f *entity.MasterProfileFilter) { f.Lat = &otherLat }, want: er.Validation("cursor does not match filter")},
+		{name: "city", change: func(f *entity.MasterProfileFilter) { f.City = "Moscow" }, want: er.Validation("cursor does not match filter")},
+		{name: "price", change: func(f *entity.MasterProfileFilter) { f.PriceTo = 5000 }, want: er.Validation("cursor does not match filter")},
+		{name: "models", change: func(f *entity.MasterProfileFilter) { f.LookingForModels = true }, want: er.Validation("cursor does not match filter")},
+		{name: "sort", change: func(f *entity.MasterProfileFilter) { f.Sort = entity.MasterProfileSortRating }, want: er.Validation("cursor does not match sort")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changed := filter
+			tt.change(&changed)
+			_, _, _, err := u.List(context.Background(), changed, cursor, 1, 2)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("got %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestPromotedMastersStayWithinThePage(t *testing.T) {
+	f := newPromotionFixture()
+	ctx := context.Background()
+	promoted := f.profileOf(t, f.owner)
+	f.profiles.matching = map[uuid.UUID]bool{promoted.ID: true}
+	f.promotions.active = []entity.Promotion{{ID: uuid.New(), MasterID: promoted.ID}}
+
+	// The promoted master also ranks third organically
+	repo := &catalogRepo{}
+	for i := 0; i < 4; i++ {
+		id := uuid.New()
+		if i == 2 {
+			id = promoted.ID
+		}
+		repo.profiles = append(repo.profiles, entity.CatalogMasterProfile{
+			MasterProfile: entity.MasterProfile{ID: id},
+			SortKey:       float64(i),
+		})
+	}
+	u := NewMasterProfileUsecase(repo, nil, f.usecase)
+
+	var seen []uuid.UUID
+	cursor := ""
+	for page := 1; ; page++ {
+		profiles, _, next, err := u.List(ctx, entity.MasterProfileFilter{}, cursor, 1, 2)
+		if err != nil {
+			t.Fatalf("page %d: %v", page, err)
+		}
+		if len(profiles) > 2 {
+			t.Fatalf("page %d has %d results, want at most the page size", page, len(profiles))
+		}
+		if page == 1 && (!profiles[0].IsPromoted || profiles[0].ID != promoted.ID) {
+			t.Fatalf("first page starts with %+v, want the promoted master", profiles[0])
+		}
+		for _, profile := range profiles {
+			seen = append(seen, profile.ID)
+		}
+		if next == "" {
+			break
+		}
+		cursor = next
+	}
+
+	// Every master is shown once, the promoted one only in its slot
+	want := []uuid.UUID{promoted.ID, repo.profiles[0].ID, repo.profiles[1].ID, repo.profiles[3].ID}
+	if !slices.Equal(seen, want) {
+		t.Fatalf("pages listed %v, want %v", seen, want)
+	}
+}
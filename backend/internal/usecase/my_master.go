@@ -2,11 +2,11 @@ package usecase
 
 import (
 	"context"
-	"errors"
 
 	"github.com/google/uuid"
 
 	"github.com/Vanv1k/BeautyTON/internal/domain/entity"
+	er "github.com/Vanv1k/BeautyTON/internal/domain/errors"
 	"github.com/Vanv1k/BeautyTON/internal/domain/repository"
 )
 
@@ -23,53 +23,62 @@ func NewMyMasterUsecase(myMasterRepo repository.MyMasterRepository, userRepo rep
 }
 
 func (u *MyMasterUsecase) GetMyMaster(ctx context.Context, id uuid.UUID) (*entity.MyMaster, error) {
+	ctx, span := tracer.Start(ctx, "MyMasterUsecase.GetMyMaster")
+	defer span.End()
+
 	return u.myMasterRepo.GetByID(ctx, id)
 }
 
 func (u *MyMasterUsecase) CreateMyMaster(ctx context.Context, myMaster *entity.MyMaster) error {
-	// Валидация бизнес-логики
-	if myMaster.ClientID == uuid.Nil || myMaster.MasterID == uuid.Nil {
-		return errors.New("client_id and master_id are required")
-	}
-	client, err := u.userRepo.GetByID(ctx, myMaster.ClientID)
-	if err != nil {
-		return err
-	}
-	if client.Role != entity.UserRoleClient {
-		return errors.New("client_id must refer to a client")
-	}
-	master, err := u.userRepo.GetByID(ctx, myMaster.MasterID)
-	if err != nil {
+	ctx, span := tracer.Start(ctx, "MyMasterUsecase.CreateMyMaster")
+	defer span.End()
+
+	if err := validateClientMaster(ctx, u.userRepo, myMaster.ClientID, myMaster.MasterID); err != nil {
 		return err
 	}
-	if master.Role != entity.UserRoleMaster {
-		return errors.New("master_id must refer to a master")
+	if myMaster.ID == uuid.Nil {
+		myMaster.ID = uuid.New()
 	}
 	return u.myMasterRepo.Create(ctx, myMaster)
 }
 
 func (u *MyMasterUsecase) UpdateMyMaster(ctx context.Context, myMaster *entity.MyMaster) error {
+	ctx, span := tracer.Start(ctx, "MyMasterUsecase.UpdateMyMaster")
+	defer span.End()
+
+	if err := validateClientMaster(ctx, u.userRepo, myMaster.ClientID, myMaster.MasterID); err != nil {
+		return err
+	}
+	return u.myMasterRepo.Update(ctx, myMaster)
+}
+
+func (u *MyMasterUsecase) DeleteMyMaster(ctx context.Context, id uuid.UUID) error {
+	ctx, span := tracer.Start(ctx, "MyMasterUsecase.DeleteMyMaster")
+	defer span.End()
+
+	return u.myMasterRepo.Delete(ctx, id)
+}
+
+// validateClientMaster checks that clientID and masterID refer to existing
+// users with the client and master roles respectively.
+func validateClientMaster(ctx context.Context, userRepo repository.UserRepository, clientID, masterID uuid.UUID) error {
 	// Валидация бизнес-логики
-	if myMaster.ClientID == uuid.Nil || myMaster.MasterID == uuid.Nil {
-		return errors.New("client_id and master_id are required")
+	if clientID == uuid.Nil || masterID == uuid.Nil {
+		return er.Validation("client_id and master_id are required")
 	}
-	client, err := u.userRepo.GetByID(ctx, myMaster.ClientID)
+	client, err := userRepo.GetByID(ctx, clientID)
 	if err != nil {
 		return err
 	}
 	if client.Role != entity.UserRoleClient {
-		return errors.New("client_id must refer to a client")
+		return er.Validation("client_id must refer to a client")
 	}
-	master, err := u.userRepo.GetByID(ctx, myMaster.MasterID)
+	master, err := userRepo.GetByID(ctx, masterID)
 	if err != nil {
 		return err
 	}
 	if master.Role != entity.UserRoleMaster {
-		return errors.New("master_id must refer to a master")
+		return er.Validation("master_id must refer to a master")
 	}
-	return u.myMasterRepo.Update(ctx, myMaster)
-}
-
-func (u *MyMasterUsecase) DeleteMyMaster(ctx context.Context, id uuid.UUID) error {
-	return u.myMasterRepo.Delete(ctx, id)
+	return nil
 }
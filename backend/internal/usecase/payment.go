@@ -3,97 +3,232 @@ package usecase
 import (
 	"context"
 	"errors"
+	"math"
+	"slices"
 
 	"github.com/google/uuid"
 
 	"github.com/Vanv1k/BeautyTON/internal/domain/entity"
+	er "github.com/Vanv1k/BeautyTON/internal/domain/errors"
 	"github.com/Vanv1k/BeautyTON/internal/domain/repository"
+	"github.com/Vanv1k/BeautyTON/internal/metrics"
 )
 
+// promotionCurrency is the currency promotion campaigns are paid in.
+const promotionCurrency = "TON"
+
 type PaymentUsecase struct {
-	paymentRepo repository.PaymentRepository
-	userRepo    repository.UserRepository
+	paymentRepo   repository.PaymentRepository
+	userRepo      repository.UserRepository
+	bookingRepo   repository.BookingRepository
+	promotionRepo repository.PromotionRepository
+	currencies    []string
 }
 
-func NewPaymentUsecase(paymentRepo repository.PaymentRepository, userRepo repository.UserRepository) *PaymentUsecase {
+func NewPaymentUsecase(
+	paymentRepo repository.PaymentRepository,
+	userRepo repository.UserRepository,
+	bookingRepo repository.BookingRepository,
+	promotionRepo repository.PromotionRepository,
+	currencies []string,
+) *PaymentUsecase {
 	return &PaymentUsecase{
-		paymentRepo: paymentRepo,
-		userRepo:    userRepo,
+		paymentRepo:   paymentRepo,
+		userRepo:      userRepo,
+		bookingRepo:   bookingRepo,
+		promotionRepo: promotionRepo,
+		currencies:    currencies,
 	}
 }
 
 func (u *PaymentUsecase) GetPayment(ctx context.Context, id uuid.UUID) (*entity.Payment, error) {
+	ctx, span := tracer.Start(ctx, "PaymentUsecase.GetPayment")
+	defer span.End()
+
 	return u.paymentRepo.GetByID(ctx, id)
 }
 
 func (u *PaymentUsecase) CreatePayment(ctx context.Context, payment *entity.Payment) error {
-	// Валидация бизнес-логики
-	if payment.Amount <= 0 {
-		return errors.New("amount must be positive")
-	}
-	if payment.Type != entity.PaymentTypePayment && payment.Type != entity.PaymentTypeTip {
-		return errors.New("invalid payment type")
-	}
-	if payment.Status != entity.PaymentStatusPending && payment.Status != entity.PaymentStatusCompleted && payment.Status != entity.PaymentStatusFailed {
-		return errors.New("invalid payment status")
-	}
-	if payment.ClientID != nil {
-		if _, err := u.userRepo.GetByID(ctx, *payment.ClientID); err != nil {
-			return errors.New("invalid client_id")
-		}
+	ctx, span := tracer.Start(ctx, "PaymentUsecase.CreatePayment")
+	defer span.End()
+
+	if err := u.validatePayment(ctx, payment); err != nil {
+		return err
 	}
-	if payment.MasterID != nil {
-		master, err := u.userRepo.GetByID(ctx, *payment.MasterID)
+	if payment.PromotionID != nil {
+		promotion, err := u.promotionRepo.GetByID(ctx, *payment.PromotionID)
 		if err != nil {
-			return errors.New("invalid master_id")
+			return err
 		}
-		if master.Role != entity.UserRoleMaster {
-			return errors.New("master_id must refer to a master")
+		if promotion.Status != entity.PromotionStatusPending {
+			return er.Validation("promotion is not awaiting payment")
 		}
 	}
-	return u.paymentRepo.Create(ctx, payment)
+	if payment.ID == uuid.Nil {
+		payment.ID = uuid.New()
+	}
+	if err := u.paymentRepo.Create(ctx, payment); err != nil {
+		return err
+	}
+	if payment.Status == entity.PaymentStatusCompleted {
+		return u.activatePromotion(ctx, payment)
+	}
+	return nil
 }
 
 func (u *PaymentUsecase) UpdatePayment(ctx context.Context, payment *entity.Payment) error {
+	ctx, span := tracer.Start(ctx, "PaymentUsecase.UpdatePayment")
+	defer span.End()
+
+	if err := u.validatePayment(ctx, payment); err != nil {
+		return err
+	}
+	return u.paymentRepo.Update(ctx, payment)
+}
+
+func (u *PaymentUsecase) UpdatePaymentStatus(ctx context.Context, id uuid.UUID, status entity.PaymentStatus) (*entity.Payment, error) {
+	ctx, span := tracer.Start(ctx, "PaymentUsecase.UpdatePaymentStatus")
+	defer span.End()
+
+	if !validPaymentStatus(status) {
+		return nil, er.Validation("invalid payment status")
+	}
+	payment, err := u.paymentRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	previous := payment.Status
+	payment.Status = status
+	if err := u.paymentRepo.Update(ctx, payment); err != nil {
+		return nil, err
+	}
+	if status == entity.PaymentStatusCompleted && previous != status {
+		metrics.PaymentsCompleted.Inc()
+		if err := u.activatePromotion(ctx, payment); err != nil {
+			return nil, err
+		}
+	}
+	return payment, nil
+}
+
+// activatePromotion starts the campaign paid by a completed payment.
+func (u *PaymentUsecase) activatePromotion(ctx context.Context, payment *entity.Payment) error {
+	if payment.PromotionID == nil {
+		return nil
+	}
+	return u.promotionRepo.Activate(ctx, *payment.PromotionID)
+}
+
+func (u *PaymentUsecase) validatePayment(ctx context.Context, payment *entity.Payment) error {
 	// Валидация бизнес-логики
+	if payment.BookingID != nil && payment.PromotionID != nil {
+		return er.Validation("payment cannot be for both a booking and a promotion")
+	}
+	if payment.BookingID != nil {
+		if err := u.validateBookingPayment(ctx, payment); err != nil {
+			return err
+		}
+	}
+	if payment.PromotionID != nil {
+		if err := u.validatePromotionPayment(ctx, payment); err != nil {
+			return err
+		}
+	}
 	if payment.Amount <= 0 {
-		return errors.New("amount must be positive")
+		return er.Validation("amount must be positive")
 	}
 	if payment.Type != entity.PaymentTypePayment && payment.Type != entity.PaymentTypeTip {
-		return errors.New("invalid payment type")
+		return er.Validation("invalid payment type")
 	}
-	if payment.Status != entity.PaymentStatusPending && payment.Status != entity.PaymentStatusCompleted && payment.Status != entity.PaymentStatusFailed {
-		return errors.New("invalid payment status")
+	if !slices.Contains(u.currencies, payment.Currency) {
+		return er.Validation("unsupported currency")
+	}
+	if !validPaymentStatus(payment.Status) {
+		return er.Validation("invalid payment status")
 	}
 	if payment.ClientID != nil {
 		if _, err := u.userRepo.GetByID(ctx, *payment.ClientID); err != nil {
-			return errors.New("invalid client_id")
+			return er.Validation("invalid client_id")
 		}
 	}
 	if payment.MasterID != nil {
 		master, err := u.userRepo.GetByID(ctx, *payment.MasterID)
 		if err != nil {
-			return errors.New("invalid master_id")
+			return er.Validation("invalid master_id")
 		}
 		if master.Role != entity.UserRoleMaster {
-			return errors.New("master_id must refer to a master")
+			return er.Validation("master_id must refer to a master")
 		}
 	}
-	return u.paymentRepo.Update(ctx, payment)
+	return nil
 }
 
-func (u *PaymentUsecase) UpdatePaymentStatus(ctx context.Context, id uuid.UUID, status entity.PaymentStatus) (*entity.Payment, error) {
-	// Валидация бизнес-логики
-	if status != entity.PaymentStatusPending && status != entity.PaymentStatusCompleted && status != entity.PaymentStatusFailed {
-		return nil, errors.New("invalid payment status")
+// validateBookingPayment takes the participants of a booking payment from
+// the booking and charges the booking price, which is the model price for a
+// model booking. The amount of a tip is up to the client.
+func (u *PaymentUsecase) validateBookingPayment(ctx context.Context, payment *entity.Payment) error {
+	booking, err := u.bookingRepo.GetByID(ctx, *payment.BookingID)
+	if errors.Is(err, er.ErrRecordNotFound) {
+		return er.Validation("invalid booking_id")
 	}
-	payment, err := u.paymentRepo.GetByID(ctx, id)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	payment.Status = status
-	if err := u.paymentRepo.Update(ctx, payment); err != nil {
-		return nil, err
+	if payment.ClientID == nil {
+		payment.ClientID = &booking.ClientID
+	} else if *payment.ClientID != booking.ClientID {
+		return er.Validation("client_id does not match booking")
 	}
-	return payment, nil
+	if payment.MasterID == nil {
+		payment.MasterID = &booking.MasterID
+	} else if *payment.MasterID != booking.MasterID {
+		return er.Validation("master_id does not match booking")
+	}
+	if payment.Type != entity.PaymentTypePayment {
+		return nil
+	}
+	if booking.Price == 0 {
+		return er.Validation("booking is free")
+	}
+	if payment.Amount == 0 {
+		payment.Amount = booking.Price
+	}
+	// Суммы хранятся с точностью до сотых
+	if math.Round(payment.Amount*100) != math.Round(booking.Price*100) {
+		return er.Validation("amount does not match booking price")
+	}
+	return nil
+}
+
+// validatePromotionPayment charges the budget of a campaign, in TON.
+func (u *PaymentUsecase) validatePromotionPayment(ctx context.Context, payment *entity.Payment) error {
+	promotion, err := u.promotionRepo.GetByID(ctx, *payment.PromotionID)
+	if errors.Is(err, er.ErrRecordNotFound) {
+		return er.Validation("invalid promotion_id")
+	}
+	if err != nil {
+		return err
+	}
+	if payment.Type != entity.PaymentTypePayment {
+		return er.Validation("invalid payment type")
+	}
+	if payment.Currency != promotionCurrency {
+		return er.Validation("promotion must be paid in TON")
+	}
+	if payment.Amount == 0 {
+		payment.Amount = promotion.BudgetTON
+	}
+	// Суммы хранятся с точностью до сотых
+	if math.Round(payment.Amount*100) != math.Round(promotion.BudgetTON*100) {
+		return er.Validation("amount does not match promotion budget")
+	}
+	return nil
+}
+
+func validPaymentStatus(status entity.PaymentStatus) bool {
+	switch status {
+	case entity.PaymentStatusPending, entity.PaymentStatusCompleted, entity.PaymentStatusFailed:
+		return true
+	}
+	return false
 }
@@ -0,0 +1,137 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/Vanv1k/BeautyTON/internal/domain/entity"
+	er "github.com/Vanv1k/BeautyTON/internal/domain/errors"
+	"github.com/Vanv1k/BeautyTON/internal/domain/repository"
+)
+
+type fakePaymentRepo struct {
+	repository.PaymentRepository
+	payments map[uuid.UUID]*entity.Payment
+}
+
+func (r *fakePaymentRepo) GetByID(ctx context.Context, id uuid.UUID) (*entity.Payment, error) {
+	if payment, ok := r.payments[id]; ok {
+		copied := *payment
+		return &copied, nil
+	}
+	return nil, er.ErrRecordNotFound
+}
+
+func (r *fakePaymentRepo) Create(ctx context.Context, payment *entity.Payment) error {
+	r.payments[payment.ID] = payment
+	return nil
+}
+
+func (r *fakePaymentRepo) Update(ctx context.Context, payment *entity.Payment) error {
+	r.payments[payment.ID] = payment
+	return nil
+}
+
+func TestBookingPaymentChargesBookingPrice(t *testing.T) {
+	f := newBookingFixture()
+	bookings := NewBookingUsecase(f.bookings, f.users, f.services)
+	model := f.booking(true)
+	if err := bookings.CreateBooking(context.Background(), model); err != nil {
+		t.Fatalf("create booking: %v", err)
+	}
+	u := NewPaymentUsecase(&fakePaymentRepo{payments: map[uuid.UUID]*entity.Payment{}}, f.users, f.bookings, nil, []string{"TON"})
+
+	payment := func(amount float64, paymentType entity.PaymentType) *entity.Payment {
+		return &entity.Payment{
+			BookingID: &model.ID,
+			Amount:    amount,
+			Currency:  "TON",
+			Type:      paymentType,
+			Status:    entity.PaymentStatusPending,
+		}
+	}
+
+	// The amount defaults to the model price and the participants to those
+	// of the booking
+	derived := payment(0, entity.PaymentTypePayment)
+	if err := u.CreatePayment(context.Background(), derived); err != nil {
+		t.Fatalf("payment without amount: %v", err)
+	}
+	if derived.Amount != 300 {
+		t.Fatalf("payment amount is %v, want the model price 300", derived.Amount)
+	}
+	if derived.ClientID == nil || *derived.ClientID != f.client || derived.MasterID == nil || *derived.MasterID != f.master {
+		t.Fatalf("payment participants are %v and %v, want those of the booking", derived.ClientID, derived.MasterID)
+	}
+
+	err := u.CreatePayment(context.Background(), payment(1000, entity.PaymentTypePayment))
+	if !errors.Is(err, er.Validation("amount does not match booking price")) {
+		t.Fatalf("payment at the regular price: got %v", err)
+	}
+
+	// A tip is not tied to the price
+	if err := u.CreatePayment(context.Background(), payment(50, entity.PaymentTypeTip)); err != nil {
+		t.Fatalf("tip: %v", err)
+	}
+
+	stranger := uuid.New()
+	foreign := payment(0, entity.PaymentTypePayment)
+	foreign.ClientID = &stranger
+	err = u.CreatePayment(context.Background(), foreign)
+	if !errors.Is(err, er.Validation("client_id does not match booking")) {
+		t.Fatalf("payment by another client: got %v", err)
+	}
+}
+
+func TestCompletedPaymentStartsPromotion(t *testing.T) {
+	f := newPromotionFixture()
+	ctx := context.Background()
+	promotion := newPromotion(uuid.Nil)
+	if err := f.usecase.CreatePromotion(ctx, f.owner, promotion); err != nil {
+		t.Fatalf("create promotion: %v", err)
+	}
+	if promotion.Status != entity.PromotionStatusPending {
+		t.Fatalf("new promotion is %s, want pending", promotion.Status)
+	}
+	u := NewPaymentUsecase(&fakePaymentRepo{payments: map[uuid.UUID]*entity.Payment{}}, nil, nil, f.promotions, []string{"TON", "XTR"})
+
+	payment := func(amount float64, currency string) *entity.Payment {
+		return &entity.Payment{
+			PromotionID: &promotion.ID,
+			Amount:      amount,
+			Currency:    currency,
+			Type:        entity.PaymentTypePayment,
+			Status:      entity.PaymentStatusPending,
+		}
+	}
+	if err := u.CreatePayment(ctx, payment(0, "XTR")); !errors.Is(err, er.Validation("promotion must be paid in TON")) {
+		t.Fatalf("payment in stars: got %v", err)
+	}
+	if err := u.CreatePayment(ctx, payment(1, "TON")); !errors.Is(err, er.Validation("amount does not match promotion budget")) {
+		t.Fatalf("payment below the budget: got %v", err)
+	}
+
+	paid := payment(0, "TON")
+	if err := u.CreatePayment(ctx, paid); err != nil {
+		t.Fatalf("payment of the budget: %v", err)
+	}
+	if paid.Amount != promotion.BudgetTON {
+		t.Fatalf("payment amount is %v, want the budget %v", paid.Amount, promotion.BudgetTON)
+	}
+	if status := f.promotions.promotions[promotion.ID].Status; status != entity.PromotionStatusPending {
+		t.Fatalf("promotion is %s before the payment completes, want pending", status)
+	}
+
+	if _, err := u.UpdatePaymentStatus(ctx, paid.ID, entity.PaymentStatusCompleted); err != nil {
+		t.Fatalf("complete payment: %v", err)
+	}
+	if status := f.promotions.promotions[promotion.ID].Status; status != entity.PromotionStatusActive {
+		t.Fatalf("promotion is %s after the payment completed, want active", status)
+	}
+	if err := u.CreatePayment(ctx, payment(0, "TON")); !errors.Is(err, er.Validation("promotion is not awaiting payment")) {
+		t.Fatalf("second payment: got %v", err)
+	}
+}
@@ -0,0 +1,236 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Vanv1k/BeautyTON/internal/domain/entity"
+	er "github.com/Vanv1k/BeautyTON/internal/domain/errors"
+	"github.com/Vanv1k/BeautyTON/internal/domain/repository"
+)
+
+// promotionCandidatesPerPosition is how many running campaigns are
+// considered per promoted position, as some of them do not match the search.
+const promotionCandidatesPerPosition = 4
+
+type PromotionUsecase struct {
+	promotionRepo     repository.PromotionRepository
+	masterProfileRepo repository.MasterProfileRepository
+	positions         []int
+	impressionCostTON float64
+}
+
+func NewPromotionUsecase(
+	promotionRepo repository.PromotionRepository,
+	masterProfileRepo repository.MasterProfileRepository,
+	positions []int,
+	impressionCostTON float64,
+) *PromotionUsecase {
+	sorted := append([]int(nil), positions...)
+	sort.Ints(sorted)
+	return &PromotionUsecase{
+		promotionRepo:     promotionRepo,
+		masterProfileRepo: masterProfileRepo,
+		positions:         sorted,
+		impressionCostTON: impressionCostTON,
+	}
+}
+
+func (u *PromotionUsecase) GetPromotion(ctx context.Context, id uuid.UUID) (*entity.Promotion, error) {
+	ctx, span := tracer.Start(ctx, "PromotionUsecase.GetPromotion")
+	defer span.End()
+
+	return u.promotionRepo.GetByID(ctx, id)
+}
+
+// CreatePromotion creates a pending campaign of the master profile of userID.
+// It starts once a TON payment of its budget completes. A campaign for
+// another master is rejected.
+func (u *PromotionUsecase) CreatePromotion(ctx context.Context, userID uuid.UUID, promotion *entity.Promotion) error {
+	ctx, span := tracer.Start(ctx, "PromotionUsecase.CreatePromotion")
+	defer span.End()
+
+	profile, err := u.masterProfileRepo.GetByUserID(ctx, userID)
+	if errors.Is(err, er.ErrRecordNotFound) {
+		return er.NotFound("master profile not found")
+	}
+	if err != nil {
+		return err
+	}
+	if promotion.MasterID != uuid.Nil && promotion.MasterID != profile.ID {
+		return er.ErrAccessDenied
+	}
+	promotion.MasterID = profile.ID
+
+	// Валидация бизнес-логики
+	if !promotion.EndsAt.After(promotion.StartsAt) {
+		return er.Validation("ends_at must be after starts_at")
+	}
+	if !promotion.EndsAt.After(time.Now()) {
+		return er.Validation("ends_at must be in the future")
+	}
+	if promotion.BudgetTON <= 0 {
+		return er.Validation("budget_ton must be positive")
+	}
+
+	// Счетчики и статус управляются сервером
+	if promotion.ID == uuid.Nil {
+		promotion.ID = uuid.New()
+	}
+	promotion.SpentTON = 0
+	promotion.Impressions = 0
+	promotion.Clicks = 0
+	promotion.Status = entity.PromotionStatusPending
+	return u.promotionRepo.Create(ctx, promotion)
+}
+
+// CancelPromotion stops a pending or active campaign of the master profile of
+// userID.
+func (u *PromotionUsecase) CancelPromotion(ctx context.Context, userID, id uuid.UUID) (*entity.Promotion, error) {
+	ctx, span := tracer.Start(ctx, "PromotionUsecase.CancelPromotion")
+	defer span.End()
+
+	promotion, err := u.promotionRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	profile, err := u.masterProfileRepo.GetByID(ctx, promotion.MasterID)
+	if err != nil && !errors.Is(err, er.ErrRecordNotFound) {
+		return nil, err
+	}
+	if profile == nil || profile.UserID == nil || *profile.UserID != userID {
+		return nil, er.ErrAccessDenied
+	}
+	if promotion.Status != entity.PromotionStatusPending && promotion.Status != entity.PromotionStatusActive {
+		return nil, er.Conflict("only pending or active promotions can be canceled")
+	}
+	promotion.Status = entity.PromotionStatusCanceled
+	if err := u.promotionRepo.Update(ctx, promotion); err != nil {
+		return nil, err
+	}
+	return promotion, nil
+}
+
+// RecordClick counts a click of the user on a promoted listing, once per user
+// and campaign.
+func (u *PromotionUsecase) RecordClick(ctx context.Context, userID, id uuid.UUID) error {
+	ctx, span := tracer.Start(ctx, "PromotionUsecase.RecordClick")
+	defer span.End()
+
+	return u.promotionRepo.RecordClick(ctx, id, userID)
+}
+
+// Apply implements CatalogHook: it puts masters of running campaigns into
+// the configured positions of the first catalog page, in place of organic
+// results, and counts impressions. Positions past the page are skipped and
+// at least one organic result is kept, so the page can be continued.
+func (u *PromotionUsecase) Apply(ctx context.Context, filter entity.MasterProfileFilter, profiles []entity.CatalogMasterProfile, pageSize int) ([]entity.CatalogMasterProfile, error) {
+	ctx, span := tracer.Start(ctx, "PromotionUsecase.Apply")
+	defer span.End()
+
+	positions := u.positions
+	for len(positions) > 0 && positions[len(positions)-1] >= pageSize {
+		positions = positions[:len(positions)-1]
+	}
+	if len(positions) > pageSize-1 {
+		positions = positions[:max(pageSize-1, 0)]
+	}
+	if len(positions) == 0 {
+		return truncate(profiles, pageSize), nil
+	}
+
+	// Кандидатов берем с запасом: часть может не пройти фильтры выдачи
+	promotions, err := u.promotionRepo.ListActive(ctx, filter.City, filter.Category, len(positions)*promotionCandidatesPerPosition)
+	if err != nil {
+		return nil, err
+	}
+	masterIDs := make([]uuid.UUID, 0, len(promotions))
+	for _, promotion := range promotions {
+		masterIDs = append(masterIDs, promotion.MasterID)
+	}
+	// A promoted master must match the search like any other result
+	matching, err := u.masterProfileRepo.ListMatching(ctx, filter, masterIDs)
+	if err != nil {
+		return nil, err
+	}
+	candidates := make(map[uuid.UUID]entity.MasterProfile, len(matching))
+	for _, profile := range matching {
+		candidates[profile.ID] = profile
+	}
+
+	promoted := make([]entity.CatalogMasterProfile, 0, len(positions))
+	promotedMasters := make(map[uuid.UUID]bool, len(positions))
+	impressions := make([]uuid.UUID, 0, len(positions))
+	for _, promotion := range promotions {
+		if len(promoted) == len(positions) {
+			break
+		}
+		profile, ok := candidates[promotion.MasterID]
+		if !ok || promotedMasters[promotion.MasterID] {
+			continue
+		}
+		promotionID := promotion.ID
+		promoted = append(promoted, entity.CatalogMasterProfile{
+			MasterProfile: profile,
+			IsPromoted:    true,
+			PromotionID:   &promotionID,
+		})
+		promotedMasters[promotion.MasterID] = true
+		impressions = append(impressions, promotion.ID)
+	}
+	if len(promoted) == 0 {
+		return truncate(profiles, pageSize), nil
+	}
+
+	// Убираем продвигаемых мастеров из органической выдачи, чтобы не было дублей
+	result := make([]entity.CatalogMasterProfile, 0, len(profiles)+len(promoted))
+	for _, profile := range profiles {
+		if !promotedMasters[profile.ID] {
+			result = append(result, profile)
+		}
+	}
+
+	// Продвигаемые занимают места органических, лишние уходят на следующую страницу
+	for i, profile := range promoted {
+		pos := positions[i]
+		if pos > len(result) {
+			pos = len(result)
+		}
+		result = append(result, entity.CatalogMasterProfile{})
+		copy(result[pos+1:], result[pos:])
+		result[pos] = profile
+	}
+
+	if err := u.promotionRepo.RecordImpressions(ctx, impressions, u.impressionCostTON); err != nil {
+		return nil, err
+	}
+	return truncate(result, pageSize), nil
+}
+
+func truncate(profiles []entity.CatalogMasterProfile, n int) []entity.CatalogMasterProfile {
+	if len(profiles) > n {
+		return profiles[:n]
+	}
+	return profiles
+}
+
+// RunExpiry expires finished campaigns every interval until ctx is canceled.
+func (u *PromotionUsecase) RunExpiry(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := u.promotionRepo.ExpireFinished(ctx); err != nil {
+				slog.ErrorContext(ctx, "failed to expire promotions", "error", err)
+			}
+		}
+	}
+}
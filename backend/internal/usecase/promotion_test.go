@@ -0,0 +1,226 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Vanv1k/BeautyTON/internal/domain/entity"
+	er "github.com/Vanv1k/BeautyTON/internal/domain/errors"
+	"github.com/Vanv1k/BeautyTON/internal/domain/repository"
+)
+
+type fakeMasterProfileRepo struct {
+	repository.MasterProfileRepository
+	profiles map[uuid.UUID]*entity.MasterProfile
+	matching map[uuid.UUID]bool
+}
+
+func (r *fakeMasterProfileRepo) GetByID(ctx context.Context, id uuid.UUID) (*entity.MasterProfile, error) {
+	if profile, ok := r.profiles[id]; ok {
+		return profile, nil
+	}
+	return nil, er.ErrRecordNotFound
+}
+
+func (r *fakeMasterProfileRepo) GetByUserID(ctx context.Context, userID uuid.UUID) (*entity.MasterProfile, error) {
+	for _, profile := range r.profiles {
+		if profile.UserID != nil && *profile.UserID == userID {
+			return profile, nil
+		}
+	}
+	return nil, er.ErrRecordNotFound
+}
+
+// ListMatching treats the profiles in matching as the ones passing the
+// filter.
+func (r *fakeMasterProfileRepo) ListMatching(ctx context.Context, filter entity.MasterProfileFilter, ids []uuid.UUID) ([]entity.MasterProfile, error) {
+	var profiles []entity.MasterProfile
+	for _, id := range ids {
+		if profile, ok := r.profiles[id]; ok && r.matching[id] {
+			profiles = append(profiles, *profile)
+		}
+	}
+	return profiles, nil
+}
+
+type fakePromotionRepo struct {
+	repository.PromotionRepository
+	promotions  map[uuid.UUID]*entity.Promotion
+	active      []entity.Promotion
+	impressions []uuid.UUID
+}
+
+func (r *fakePromotionRepo) ListActive(ctx context.Context, city, category string, limit int) ([]entity.Promotion, error) {
+	if len(r.active) > limit {
+		return r.active[:limit], nil
+	}
+	return r.active, nil
+}
+
+func (r *fakePromotionRepo) RecordImpressions(ctx context.Context, ids []uuid.UUID, costTON float64) error {
+	r.impressions = append(r.impressions, ids...)
+	return nil
+}
+
+func (r *fakePromotionRepo) GetByID(ctx context.Context, id uuid.UUID) (*entity.Promotion, error) {
+	if promotion, ok := r.promotions[id]; ok {
+		copied := *promotion
+		return &copied, nil
+	}
+	return nil, er.ErrRecordNotFound
+}
+
+func (r *fakePromotionRepo) Create(ctx context.Context, promotion *entity.Promotion) error {
+	r.promotions[promotion.ID] = promotion
+	return nil
+}
+
+func (r *fakePromotionRepo) Update(ctx context.Context, promotion *entity.Promotion) error {
+	r.promotions[promotion.ID] = promotion
+	return nil
+}
+
+func (r *fakePromotionRepo) Activate(ctx context.Context, id uuid.UUID) error {
+	if promotion, ok := r.promotions[id]; ok && promotion.Status == entity.PromotionStatusPending {
+		promotion.Status = entity.PromotionStatusActive
+	}
+	return nil
+}
+
+// promotionFixture has two masters, each with their own profile.
+type promotionFixture struct {
+	profiles   *fakeMasterProfileRepo
+	promotions *fakePromotionRepo
+	usecase    *PromotionUsecase
+	owner      uuid.UUID
+	other      uuid.UUID
+}
+
+func newPromotionFixture() *promotionFixture {
+	f := &promotionFixture{
+		profiles:   &fakeMasterProfileRepo{profiles: map[uuid.UUID]*entity.MasterProfile{}},
+		promotions: &fakePromotionRepo{promotions: map[uuid.UUID]*entity.Promotion{}},
+		owner:      uuid.New(),
+		other:      uuid.New(),
+	}
+	for _, userID := range []uuid.UUID{f.owner, f.other} {
+		userID := userID
+		profile := &entity.MasterProfile{ID: uuid.New(), UserID: &userID}
+		f.profiles.profiles[profile.ID] = profile
+	}
+	f.usecase = NewPromotionUsecase(f.promotions, f.profiles, []int{0}, 0.001)
+	return f
+}
+
+func (f *promotionFixture) profileOf(t *testing.T, userID uuid.UUID) *entity.MasterProfile {
+	t.Helper()
+	profile, err := f.profiles.GetByUserID(context.Background(), userID)
+	if err != nil {
+		t.Fatalf("profile of %s: %v", userID, err)
+	}
+	return profile
+}
+
+func newPromotion(masterID uuid.UUID) *entity.Promotion {
+	return &entity.Promotion{
+		MasterID:  masterID,
+		StartsAt:  time.Now(),
+		EndsAt:    time.Now().Add(24 * time.Hour),
+		BudgetTON: 10,
+	}
+}
+
+func TestCreatePromotionIsOwnedByCaller(t *testing.T) {
+	f := newPromotionFixture()
+	ctx := context.Background()
+
+	// The master is taken from the caller when omitted
+	own := newPromotion(uuid.Nil)
+	if err := f.usecase.CreatePromotion(ctx, f.owner, own); err != nil {
+		t.Fatalf("create own promotion: %v", err)
+	}
+	if own.MasterID != f.profileOf(t, f.owner).ID {
+		t.Fatalf("promotion master is %s, want the profile of the caller", own.MasterID)
+	}
+
+	foreign := newPromotion(f.profileOf(t, f.other).ID)
+	if err := f.usecase.CreatePromotion(ctx, f.owner, foreign); !errors.Is(err, er.ErrAccessDenied) {
+		t.Fatalf("create promotion for another master: got %v, want access denied", err)
+	}
+	if len(f.promotions.promotions) != 1 {
+		t.Fatalf("%d promotions stored, want 1", len(f.promotions.promotions))
+	}
+
+	client := uuid.New()
+	err := f.usecase.CreatePromotion(ctx, client, newPromotion(uuid.Nil))
+	if !errors.Is(err, er.NotFound("master profile not found")) {
+		t.Fatalf("create promotion without a profile: got %v", err)
+	}
+}
+
+func TestCancelPromotionIsOwnedByCaller(t *testing.T) {
+	f := newPromotionFixture()
+	ctx := context.Background()
+	promotion := newPromotion(uuid.Nil)
+	if err := f.usecase.CreatePromotion(ctx, f.owner, promotion); err != nil {
+		t.Fatalf("create promotion: %v", err)
+	}
+
+	if _, err := f.usecase.CancelPromotion(ctx, f.other, promotion.ID); !errors.Is(err, er.ErrAccessDenied) {
+		t.Fatalf("cancel another master's promotion: got %v, want access denied", err)
+	}
+	if status := f.promotions.promotions[promotion.ID].Status; status != entity.PromotionStatusPending {
+		t.Fatalf("promotion is %s after a foreign cancel, want pending", status)
+	}
+
+	canceled, err := f.usecase.CancelPromotion(ctx, f.owner, promotion.ID)
+	if err != nil {
+		t.Fatalf("cancel own promotion: %v", err)
+	}
+	if canceled.Status != entity.PromotionStatusCanceled {
+		t.Fatalf("promotion is %s, want canceled", canceled.Status)
+	}
+}
+
+func TestApplyInjectsOnlyMastersMatchingFilter(t *testing.T) {
+	f := newPromotionFixture()
+	ctx := context.Background()
+	matching := f.profileOf(t, f.owner)
+	filtered := f.profileOf(t, f.other)
+	f.profiles.matching = map[uuid.UUID]bool{matching.ID: true}
+
+	// The campaign of the filtered out master comes first and must be skipped
+	skipped := entity.Promotion{ID: uuid.New(), MasterID: filtered.ID}
+	shown := entity.Promotion{ID: uuid.New(), MasterID: matching.ID}
+	f.promotions.active = []entity.Promotion{skipped, shown}
+
+	organic := []entity.CatalogMasterProfile{{MasterProfile: entity.MasterProfile{ID: uuid.New()}}}
+	filter := entity.MasterProfileFilter{Query: "nails", Rating: 4.5, LookingForModels: true, PriceTo: 1000}
+	result, err := f.usecase.Apply(ctx, filter, organic, 20)
+	if err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+	if len(result) != 2 {
+		t.Fatalf("got %d results, want the organic one and one promoted", len(result))
+	}
+	if !result[0].IsPromoted || result[0].ID != matching.ID || *result[0].PromotionID != shown.ID {
+		t.Fatalf("position 0 is %+v, want the matching promoted master", result[0])
+	}
+	if len(f.promotions.impressions) != 1 || f.promotions.impressions[0] != shown.ID {
+		t.Fatalf("impressions recorded for %v, want only %s", f.promotions.impressions, shown.ID)
+	}
+
+	// Nothing is injected when no campaign matches
+	f.profiles.matching = nil
+	result, err = f.usecase.Apply(ctx, filter, organic, 20)
+	if err != nil {
+		t.Fatalf("apply without matches: %v", err)
+	}
+	if len(result) != 1 || result[0].IsPromoted {
+		t.Fatalf("got %+v, want only the organic result", result)
+	}
+}
@@ -0,0 +1,60 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/Vanv1k/BeautyTON/internal/domain/entity"
+	"github.com/Vanv1k/BeautyTON/internal/domain/repository"
+)
+
+type RateLimitUsecase struct {
+	rateLimitRepo repository.RateLimitRepository
+	limits        map[string]entity.RateLimit
+}
+
+// NewRateLimitUsecase limits each route group by its entry in limits.
+// Groups without a limit are not limited.
+func NewRateLimitUsecase(rateLimitRepo repository.RateLimitRepository, limits map[string]entity.RateLimit) *RateLimitUsecase {
+	return &RateLimitUsecase{
+		rateLimitRepo: rateLimitRepo,
+		limits:        limits,
+	}
+}
+
+// Allow counts a request of subject, e.g. a user or an IP address, against
+// the limit of group. It returns how long the subject has to wait if the
+// limit is exceeded, or zero.
+func (u *RateLimitUsecase) Allow(ctx context.Context, group, subject string) (time.Duration, error) {
+	ctx, span := tracer.Start(ctx, "RateLimitUsecase.Allow")
+	defer span.End()
+
+	limit, ok := u.limits[group]
+	if !ok || limit.Requests == 0 {
+		return 0, nil
+	}
+	return u.rateLimitRepo.Take(ctx, group+":"+subject, limit)
+}
+
+// RunCleanup deletes refilled buckets every interval until ctx is canceled.
+func (u *RateLimitUsecase) RunCleanup(ctx context.Context, interval time.Duration) {
+	// Через самый длинный период любое ведро снова полное
+	var idle time.Duration
+	for _, limit := range u.limits {
+		idle = max(idle, limit.Period)
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := u.rateLimitRepo.DeleteIdle(ctx, time.Now().Add(-idle)); err != nil {
+				slog.ErrorContext(ctx, "failed to delete idle rate limit buckets", "error", err)
+			}
+		}
+	}
+}
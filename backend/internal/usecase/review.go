@@ -2,11 +2,11 @@ package usecase
 
 import (
 	"context"
-	"errors"
 
 	"github.com/google/uuid"
 
 	"github.com/Vanv1k/BeautyTON/internal/domain/entity"
+	er "github.com/Vanv1k/BeautyTON/internal/domain/errors"
 	"github.com/Vanv1k/BeautyTON/internal/domain/repository"
 )
 
@@ -23,37 +23,58 @@ func NewReviewUsecase(reviewRepo repository.ReviewRepository, bookingRepo reposi
 }
 
 func (u *ReviewUsecase) GetReview(ctx context.Context, id uuid.UUID) (*entity.Review, error) {
+	ctx, span := tracer.Start(ctx, "ReviewUsecase.GetReview")
+	defer span.End()
+
 	return u.reviewRepo.GetByID(ctx, id)
 }
 
 func (u *ReviewUsecase) CreateReview(ctx context.Context, review *entity.Review) error {
-	// Валидация бизнес-логики
-	if review.BookingID == uuid.Nil {
-		return errors.New("booking_id is required")
+	ctx, span := tracer.Start(ctx, "ReviewUsecase.CreateReview")
+	defer span.End()
+
+	if err := u.validateReview(ctx, review); err != nil {
+		return err
 	}
-	if _, err := u.bookingRepo.GetByID(ctx, review.BookingID); err != nil {
-		return errors.New("invalid booking_id")
-	}
-	if review.Rating < 1 || review.Rating > 5 {
-		return errors.New("rating must be between 1 and 5")
+	if review.ID == uuid.Nil {
+		review.ID = uuid.New()
 	}
+	// Отзыв публикуется после модерации
+	review.ModerationStatus = entity.ModerationStatusPending
+	review.ModerationReason = ""
 	return u.reviewRepo.Create(ctx, review)
 }
 
 func (u *ReviewUsecase) UpdateReview(ctx context.Context, review *entity.Review) error {
+	ctx, span := tracer.Start(ctx, "ReviewUsecase.UpdateReview")
+	defer span.End()
+
+	if err := u.validateReview(ctx, review); err != nil {
+		return err
+	}
+	// Измененный отзыв снова проходит модерацию
+	review.ModerationStatus = entity.ModerationStatusPending
+	review.ModerationReason = ""
+	return u.reviewRepo.Update(ctx, review)
+}
+
+func (u *ReviewUsecase) DeleteReview(ctx context.Context, id uuid.UUID) error {
+	ctx, span := tracer.Start(ctx, "ReviewUsecase.DeleteReview")
+	defer span.End()
+
+	return u.reviewRepo.Delete(ctx, id)
+}
+
+func (u *ReviewUsecase) validateReview(ctx context.Context, review *entity.Review) error {
 	// Валидация бизнес-логики
 	if review.BookingID == uuid.Nil {
-		return errors.New("booking_id is required")
+		return er.Validation("booking_id is required")
 	}
 	if _, err := u.bookingRepo.GetByID(ctx, review.BookingID); err != nil {
-		return errors.New("invalid booking_id")
+		return er.Validation("invalid booking_id")
 	}
 	if review.Rating < 1 || review.Rating > 5 {
-		return errors.New("rating must be between 1 and 5")
+		return er.Validation("rating must be between 1 and 5")
 	}
-	return u.reviewRepo.Update(ctx, review)
-}
-
-func (u *ReviewUsecase) DeleteReview(ctx context.Context, id uuid.UUID) error {
-	return u.reviewRepo.Delete(ctx, id)
+	return nil
 }
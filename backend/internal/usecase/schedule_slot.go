@@ -2,11 +2,11 @@ package usecase
 
 import (
 	"context"
-	"errors"
 
 	"github.com/google/uuid"
 
 	"github.com/Vanv1k/BeautyTON/internal/domain/entity"
+	er "github.com/Vanv1k/BeautyTON/internal/domain/errors"
 	"github.com/Vanv1k/BeautyTON/internal/domain/repository"
 )
 
@@ -35,52 +35,48 @@ func NewScheduleSlotUsecase(
 }
 
 func (u *ScheduleSlotUsecase) GetScheduleSlot(ctx context.Context, id uuid.UUID) (*entity.ScheduleSlot, error) {
+	ctx, span := tracer.Start(ctx, "ScheduleSlotUsecase.GetScheduleSlot")
+	defer span.End()
+
 	return u.scheduleSlotRepo.Get(ctx, id)
 }
 
-func (u *ScheduleSlotUsecase) ListScheduleSlots(ctx context.Context, masterID uuid.UUID) ([]entity.ScheduleSlot, error) {
+// ListScheduleSlots returns slots of a master. Model slots are only listed
+// when forModels is true; a nil forModels returns all slots.
+func (u *ScheduleSlotUsecase) ListScheduleSlots(ctx context.Context, masterID uuid.UUID, forModels *bool) ([]entity.ScheduleSlot, error) {
+	ctx, span := tracer.Start(ctx, "ScheduleSlotUsecase.ListScheduleSlots")
+	defer span.End()
+
 	// Проверка существования мастера
 	if _, err := u.masterRepo.GetByID(ctx, masterID); err != nil {
-		return nil, errors.New("master profile not found")
+		return nil, er.NotFound("master profile not found")
 	}
-	return u.scheduleSlotRepo.List(ctx, masterID)
+	return u.scheduleSlotRepo.List(ctx, masterID, forModels)
 }
 
 func (u *ScheduleSlotUsecase) CreateScheduleSlot(ctx context.Context, slot *entity.ScheduleSlot) error {
-	// Валидация бизнес-логики
-
-	// Проверка существования мастера
-	if _, err := u.masterRepo.GetByID(ctx, slot.MasterID); err != nil {
-		return errors.New("master profile not found")
-	}
+	ctx, span := tracer.Start(ctx, "ScheduleSlotUsecase.CreateScheduleSlot")
+	defer span.End()
 
-	// Проверка существования бронирования
-	if slot.BookingID != nil {
-		if _, err := u.bookingRepo.GetByID(ctx, *slot.BookingID); err != nil {
-			return errors.New("booking not found")
-		}
-	}
-
-	// Проверка пересечений слотов
-	overlappingSlots, err := u.scheduleSlotRepo.FindByTimeRange(ctx, slot.MasterID, slot.StartTime, slot.EndTime)
-	if err != nil {
+	if err := u.validateScheduleSlot(ctx, slot); err != nil {
 		return err
 	}
-	for _, s := range overlappingSlots {
-		if s.Status == entity.ScheduleSlotStatusBooked || s.Status == entity.ScheduleSlotStatusBusy || s.Status == entity.ScheduleSlotStatusReserved {
-			return errors.New("slot overlaps with existing booked, busy, or reserved slot")
-		}
-	}
 
 	// Проверка прав доступа
 	if !u.checkMasterAccess(ctx, slot.MasterID) {
-		return errors.New("unauthorized")
+		return er.ErrAccessDenied
 	}
 
+	if slot.ID == uuid.Nil {
+		slot.ID = uuid.New()
+	}
 	return u.scheduleSlotRepo.Create(ctx, slot)
 }
 
 func (u *ScheduleSlotUsecase) UpdateScheduleSlot(ctx context.Context, slot *entity.ScheduleSlot) error {
+	ctx, span := tracer.Start(ctx, "ScheduleSlotUsecase.UpdateScheduleSlot")
+	defer span.End()
+
 	// Проверка существования слота
 	existingSlot, err := u.scheduleSlotRepo.Get(ctx, slot.ID)
 	if err != nil {
@@ -89,48 +85,64 @@ func (u *ScheduleSlotUsecase) UpdateScheduleSlot(ctx context.Context, slot *enti
 
 	// Проверка прав доступа
 	if !u.checkMasterAccess(ctx, existingSlot.MasterID) {
-		return errors.New("unauthorized")
+		return er.ErrAccessDenied
+	}
+
+	if err := u.validateScheduleSlot(ctx, slot); err != nil {
+		return err
 	}
+	return u.scheduleSlotRepo.Update(ctx, slot)
+}
+
+func (u *ScheduleSlotUsecase) DeleteScheduleSlot(ctx context.Context, id uuid.UUID) error {
+	ctx, span := tracer.Start(ctx, "ScheduleSlotUsecase.DeleteScheduleSlot")
+	defer span.End()
 
+	// Проверка существования слота
+	slot, err := u.scheduleSlotRepo.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	// Проверка прав доступа
+	if !u.checkMasterAccess(ctx, slot.MasterID) {
+		return er.ErrAccessDenied
+	}
+
+	return u.scheduleSlotRepo.Delete(ctx, id)
+}
+
+func (u *ScheduleSlotUsecase) validateScheduleSlot(ctx context.Context, slot *entity.ScheduleSlot) error {
 	// Валидация бизнес-логики
+	if !slot.EndTime.After(slot.StartTime) {
+		return er.Validation("end_time must be after start_time")
+	}
 
 	// Проверка существования мастера
 	if _, err := u.masterRepo.GetByID(ctx, slot.MasterID); err != nil {
-		return errors.New("master profile not found")
+		return er.NotFound("master profile not found")
 	}
 
 	// Проверка существования бронирования
 	if slot.BookingID != nil {
-		if _, err := u.bookingRepo.GetByID(ctx, *slot.BookingID); err != nil {
-			return errors.New("booking not found")
+		booking, err := u.bookingRepo.GetByID(ctx, *slot.BookingID)
+		if err != nil {
+			return er.NotFound("booking not found")
+		}
+		if slot.ForModels && !booking.IsModelBooking {
+			return er.Validation("model slot can only hold a model booking")
 		}
 	}
 
-	// Проверка пересечений слотов
+	// Проверка пересечений слотов; при обновлении сам слот не учитывается
 	overlappingSlots, err := u.scheduleSlotRepo.FindByTimeRange(ctx, slot.MasterID, slot.StartTime, slot.EndTime)
 	if err != nil {
 		return err
 	}
 	for _, s := range overlappingSlots {
 		if s.ID != slot.ID && (s.Status == entity.ScheduleSlotStatusBooked || s.Status == entity.ScheduleSlotStatusBusy || s.Status == entity.ScheduleSlotStatusReserved) {
-			return errors.New("slot overlaps with existing booked, busy, or reserved slot")
+			return er.Conflict("slot overlaps with existing booked, busy, or reserved slot")
 		}
 	}
-
-	return u.scheduleSlotRepo.Update(ctx, slot)
-}
-
-func (u *ScheduleSlotUsecase) DeleteScheduleSlot(ctx context.Context, id uuid.UUID) error {
-	// Проверка существования слота
-	slot, err := u.scheduleSlotRepo.Get(ctx, id)
-	if err != nil {
-		return err
-	}
-
-	// Проверка прав доступа
-	if !u.checkMasterAccess(ctx, slot.MasterID) {
-		return errors.New("unauthorized")
-	}
-
-	return u.scheduleSlotRepo.Delete(ctx, id)
+	return nil
 }
@@ -8,6 +8,7 @@ import (
 	"github.com/google/uuid"
 
 	"github.com/Vanv1k/BeautyTON/internal/domain/entity"
+	er "github.com/Vanv1k/BeautyTON/internal/domain/errors"
 	"github.com/Vanv1k/BeautyTON/internal/domain/repository"
 )
 
@@ -15,54 +16,147 @@ type ServiceUsecase struct {
 	serviceRepo         repository.ServiceRepository
 	userRepo            repository.UserRepository
 	serviceCategoryRepo repository.ServiceCategoryRepository
-	fileRepo            repository.FileRepository
+	files               *FileStore
+	photoModerationRepo repository.PhotoModerationRepository
 }
 
-func NewServiceUsecase(serviceRepo repository.ServiceRepository, userRepo repository.UserRepository, serviceCategoryRepo repository.ServiceCategoryRepository, fileRepo repository.FileRepository) *ServiceUsecase {
+func NewServiceUsecase(serviceRepo repository.ServiceRepository, userRepo repository.UserRepository, serviceCategoryRepo repository.ServiceCategoryRepository, files *FileStore, photoModerationRepo repository.PhotoModerationRepository) *ServiceUsecase {
 	return &ServiceUsecase{
 		serviceRepo:         serviceRepo,
 		userRepo:            userRepo,
 		serviceCategoryRepo: serviceCategoryRepo,
-		fileRepo:            fileRepo,
+		files:               files,
+		photoModerationRepo: photoModerationRepo,
 	}
 }
 
 func (u *ServiceUsecase) GetService(ctx context.Context, id uuid.UUID) (*entity.Service, error) {
+	ctx, span := tracer.Start(ctx, "ServiceUsecase.GetService")
+	defer span.End()
+
 	return u.serviceRepo.GetByID(ctx, id)
 }
 
 func (u *ServiceUsecase) CreateService(ctx context.Context, service *entity.Service) error {
-	// Валидация бизнес-логики
-	if service.Title == "" {
-		return errors.New("title is required")
+	ctx, span := tracer.Start(ctx, "ServiceUsecase.CreateService")
+	defer span.End()
+
+	if err := u.validateService(ctx, service); err != nil {
+		return err
 	}
-	if service.Price < 0 {
-		return errors.New("price cannot be negative")
+	if service.ID == uuid.Nil {
+		service.ID = uuid.New()
 	}
-	if service.UserID != nil {
-		user, err := u.userRepo.GetByID(ctx, *service.UserID)
-		if err != nil {
-			return err
-		}
-		if user.Role != entity.UserRoleMaster {
-			return errors.New("user_id must refer to a master")
-		}
+	return u.serviceRepo.Create(ctx, service)
+}
+
+func (u *ServiceUsecase) UpdateService(ctx context.Context, service *entity.Service) error {
+	ctx, span := tracer.Start(ctx, "ServiceUsecase.UpdateService")
+	defer span.End()
+
+	if err := u.validateService(ctx, service); err != nil {
+		return err
 	}
-	if service.CategoryID != nil {
-		if _, err := u.serviceCategoryRepo.GetByID(ctx, *service.CategoryID); err != nil {
-			return errors.New("invalid category_id")
+	return u.serviceRepo.Update(ctx, service)
+}
+
+func (u *ServiceUsecase) DeleteService(ctx context.Context, id uuid.UUID) error {
+	ctx, span := tracer.Start(ctx, "ServiceUsecase.DeleteService")
+	defer span.End()
+
+	if err := u.serviceRepo.Delete(ctx, id); err != nil {
+		return err
+	}
+	return u.files.releaseAll(ctx, entity.FileReference(entity.PhotoOwnerService, id))
+}
+
+func (u *ServiceUsecase) UploadServicePhoto(ctx context.Context, serviceID uuid.UUID, file *entity.File, content io.Reader) error {
+	ctx, span := tracer.Start(ctx, "ServiceUsecase.UploadServicePhoto")
+	defer span.End()
+
+	// Проверяем, существует ли услуга
+	service, err := u.serviceRepo.GetByID(ctx, serviceID)
+	if err != nil {
+		return err
+	}
+	// Сохраняем варианты фото в хранилище
+	file.Purpose = entity.FilePurposeServicePhoto
+	reference := entity.FileReference(entity.PhotoOwnerService, service.ID)
+	if err := u.files.storePhoto(ctx, file, content, reference); err != nil {
+		return err
+	}
+	return u.attachPhoto(ctx, service, file.ID, reference)
+}
+
+// PresignServicePhoto registers a photo the client uploads to the storage
+// directly and returns the request to upload it with.
+func (u *ServiceUsecase) PresignServicePhoto(ctx context.Context, serviceID uuid.UUID, file *entity.File) (*entity.PresignedRequest, error) {
+	ctx, span := tracer.Start(ctx, "ServiceUsecase.PresignServicePhoto")
+	defer span.End()
+
+	if _, err := u.serviceRepo.GetByID(ctx, serviceID); err != nil {
+		return nil, err
+	}
+	file.Purpose = entity.FilePurposeServicePhoto
+	return u.files.presignUpload(ctx, file)
+}
+
+// CompleteServicePhoto processes a presigned upload of the owner into the photo
+// of the service, like UploadServicePhoto does with an upload.
+func (u *ServiceUsecase) CompleteServicePhoto(ctx context.Context, serviceID, ownerID uuid.UUID, fileID string) (*entity.File, error) {
+	ctx, span := tracer.Start(ctx, "ServiceUsecase.CompleteServicePhoto")
+	defer span.End()
+
+	service, err := u.serviceRepo.GetByID(ctx, serviceID)
+	if err != nil {
+		return nil, err
+	}
+	reference := entity.FileReference(entity.PhotoOwnerService, service.ID)
+	file, err := u.files.completePhoto(ctx, ownerID, fileID, entity.FilePurposeServicePhoto, reference)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.attachPhoto(ctx, service, file.ID, reference); err != nil {
+		return nil, err
+	}
+	return file, nil
+}
+
+// PhotoURLs maps the variants of a photo to their download URLs, nil when
+// they are downloaded through the API.
+func (u *ServiceUsecase) PhotoURLs(ctx context.Context, id string) map[entity.ImageVariant]string {
+	ctx, span := tracer.Start(ctx, "ServiceUsecase.PhotoURLs")
+	defer span.End()
+
+	return u.files.photoURLs(ctx, id)
+}
+
+// attachPhoto makes a stored photo the photo of the service and sends it to
+// moderation.
+func (u *ServiceUsecase) attachPhoto(ctx context.Context, service *entity.Service, fileID, reference string) error {
+	// Обновляем PhotoURL
+	previous := service.PhotoURL
+	service.PhotoURL = fileID
+	if err := u.serviceRepo.Update(ctx, service); err != nil {
+		return errors.Join(err, u.files.release(ctx, fileID, reference))
+	}
+	// Прежнее фото удалится после льготного периода
+	if previous != "" {
+		if err := u.files.release(ctx, previous, reference); err != nil {
+			return err
 		}
 	}
-	return u.serviceRepo.Create(ctx, service)
+	// Фото попадает в очередь модерации
+	return u.photoModerationRepo.Create(ctx, newPhotoModeration(fileID, entity.PhotoOwnerService, service.ID))
 }
 
-func (u *ServiceUsecase) UpdateService(ctx context.Context, service *entity.Service) error {
+func (u *ServiceUsecase) validateService(ctx context.Context, service *entity.Service) error {
 	// Валидация бизнес-логики
 	if service.Title == "" {
-		return errors.New("title is required")
+		return er.Validation("title is required")
 	}
 	if service.Price < 0 {
-		return errors.New("price cannot be negative")
+		return er.Validation("price cannot be negative")
 	}
 	if service.UserID != nil {
 		user, err := u.userRepo.GetByID(ctx, *service.UserID)
@@ -70,32 +164,29 @@ func (u *ServiceUsecase) UpdateService(ctx context.Context, service *entity.Serv
 			return err
 		}
 		if user.Role != entity.UserRoleMaster {
-			return errors.New("user_id must refer to a master")
+			return er.Validation("user_id must refer to a master")
 		}
 	}
 	if service.CategoryID != nil {
 		if _, err := u.serviceCategoryRepo.GetByID(ctx, *service.CategoryID); err != nil {
-			return errors.New("invalid category_id")
+			return er.Validation("invalid category_id")
 		}
 	}
-	return u.serviceRepo.Update(ctx, service)
+	return validateModelOffer(service)
 }
 
-func (u *ServiceUsecase) DeleteService(ctx context.Context, id uuid.UUID) error {
-	return u.serviceRepo.Delete(ctx, id)
-}
-
-func (u *ServiceUsecase) UploadServicePhoto(ctx context.Context, serviceID uuid.UUID, file *entity.File, content io.Reader) error {
-	// Проверяем, существует ли услуга
-	service, err := u.serviceRepo.GetByID(ctx, serviceID)
-	if err != nil {
-		return err
+func validateModelOffer(service *entity.Service) error {
+	if !service.ModelOffer {
+		return nil
 	}
-	// Загружаем файл в S3
-	if err := u.fileRepo.Upload(ctx, file, content); err != nil {
-		return err
+	if service.ModelPrice == nil {
+		return er.Validation("model_price is required for a model offer")
 	}
-	// Обновляем PhotoURL
-	service.PhotoURL = file.ID
-	return u.serviceRepo.Update(ctx, service)
+	if *service.ModelPrice < 0 || *service.ModelPrice > service.Price {
+		return er.Validation("model_price must be between 0 and price")
+	}
+	if service.ModelBookingsLimit < 1 {
+		return er.Validation("model_bookings_limit must be positive for a model offer")
+	}
+	return nil
 }
@@ -2,11 +2,11 @@ package usecase
 
 import (
 	"context"
-	"errors"
 
 	"github.com/google/uuid"
 
 	"github.com/Vanv1k/BeautyTON/internal/domain/entity"
+	er "github.com/Vanv1k/BeautyTON/internal/domain/errors"
 	"github.com/Vanv1k/BeautyTON/internal/domain/repository"
 )
 
@@ -21,25 +21,46 @@ func NewServiceCategoryUsecase(serviceCategoryRepo repository.ServiceCategoryRep
 }
 
 func (u *ServiceCategoryUsecase) GetServiceCategory(ctx context.Context, id uuid.UUID) (*entity.ServiceCategory, error) {
+	ctx, span := tracer.Start(ctx, "ServiceCategoryUsecase.GetServiceCategory")
+	defer span.End()
+
 	return u.serviceCategoryRepo.GetByID(ctx, id)
 }
 
 func (u *ServiceCategoryUsecase) CreateServiceCategory(ctx context.Context, category *entity.ServiceCategory) error {
-	// Валидация бизнес-логики
-	if category.Name == "" {
-		return errors.New("name is required")
+	ctx, span := tracer.Start(ctx, "ServiceCategoryUsecase.CreateServiceCategory")
+	defer span.End()
+
+	if err := validateServiceCategory(category); err != nil {
+		return err
+	}
+	if category.ID == uuid.Nil {
+		category.ID = uuid.New()
 	}
 	return u.serviceCategoryRepo.Create(ctx, category)
 }
 
 func (u *ServiceCategoryUsecase) UpdateServiceCategory(ctx context.Context, category *entity.ServiceCategory) error {
-	// Валидация бизнес-логики
-	if category.Name == "" {
-		return errors.New("name is required")
+	ctx, span := tracer.Start(ctx, "ServiceCategoryUsecase.UpdateServiceCategory")
+	defer span.End()
+
+	if err := validateServiceCategory(category); err != nil {
+		return err
 	}
 	return u.serviceCategoryRepo.Update(ctx, category)
 }
 
 func (u *ServiceCategoryUsecase) DeleteServiceCategory(ctx context.Context, id uuid.UUID) error {
+	ctx, span := tracer.Start(ctx, "ServiceCategoryUsecase.DeleteServiceCategory")
+	defer span.End()
+
 	return u.serviceCategoryRepo.Delete(ctx, id)
 }
+
+func validateServiceCategory(category *entity.ServiceCategory) error {
+	// Валидация бизнес-логики
+	if category.Name == "" {
+		return er.Validation("name is required")
+	}
+	return nil
+}
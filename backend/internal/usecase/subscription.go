@@ -2,7 +2,6 @@ package usecase
 
 import (
 	"context"
-	"errors"
 	"github.com/Vanv1k/BeautyTON/internal/domain/entity"
 	"github.com/Vanv1k/BeautyTON/internal/domain/repository"
 	"github.com/google/uuid"
@@ -21,53 +20,38 @@ func NewSubscriptionUsecase(subscriptionRepo repository.SubscriptionRepository,
 }
 
 func (u *SubscriptionUsecase) GetSubscription(ctx context.Context, id uuid.UUID) (*entity.Subscription, error) {
+	ctx, span := tracer.Start(ctx, "SubscriptionUsecase.GetSubscription")
+	defer span.End()
+
 	return u.subscriptionRepo.GetByID(ctx, id)
 }
 
 func (u *SubscriptionUsecase) CreateSubscription(ctx context.Context, subscription *entity.Subscription) error {
-	// Валидация бизнес-логики
-	if subscription.ClientID == uuid.Nil || subscription.MasterID == uuid.Nil {
-		return errors.New("client_id and master_id are required")
-	}
-	client, err := u.userRepo.GetByID(ctx, subscription.ClientID)
-	if err != nil {
-		return err
-	}
-	if client.Role != entity.UserRoleClient {
-		return errors.New("client_id must refer to a client")
-	}
-	master, err := u.userRepo.GetByID(ctx, subscription.MasterID)
-	if err != nil {
+	ctx, span := tracer.Start(ctx, "SubscriptionUsecase.CreateSubscription")
+	defer span.End()
+
+	if err := validateClientMaster(ctx, u.userRepo, subscription.ClientID, subscription.MasterID); err != nil {
 		return err
 	}
-	if master.Role != entity.UserRoleMaster {
-		return errors.New("master_id must refer to a master")
+	if subscription.ID == uuid.Nil {
+		subscription.ID = uuid.New()
 	}
 	return u.subscriptionRepo.Create(ctx, subscription)
 }
 
 func (u *SubscriptionUsecase) UpdateSubscription(ctx context.Context, subscription *entity.Subscription) error {
-	// Валидация бизнес-логики
-	if subscription.ClientID == uuid.Nil || subscription.MasterID == uuid.Nil {
-		return errors.New("client_id and master_id are required")
-	}
-	client, err := u.userRepo.GetByID(ctx, subscription.ClientID)
-	if err != nil {
-		return err
-	}
-	if client.Role != entity.UserRoleClient {
-		return errors.New("client_id must refer to a client")
-	}
-	master, err := u.userRepo.GetByID(ctx, subscription.MasterID)
-	if err != nil {
+	ctx, span := tracer.Start(ctx, "SubscriptionUsecase.UpdateSubscription")
+	defer span.End()
+
+	if err := validateClientMaster(ctx, u.userRepo, subscription.ClientID, subscription.MasterID); err != nil {
 		return err
 	}
-	if master.Role != entity.UserRoleMaster {
-		return errors.New("master_id must refer to a master")
-	}
 	return u.subscriptionRepo.Update(ctx, subscription)
 }
 
 func (u *SubscriptionUsecase) DeleteSubscription(ctx context.Context, id uuid.UUID) error {
+	ctx, span := tracer.Start(ctx, "SubscriptionUsecase.DeleteSubscription")
+	defer span.End()
+
 	return u.subscriptionRepo.Delete(ctx, id)
 }
@@ -0,0 +1,7 @@
+package usecase
+
+import "go.opentelemetry.io/otel"
+
+// tracer starts a span for every usecase method, named after the usecase
+// and the method.
+var tracer = otel.Tracer("github.com/Vanv1k/BeautyTON/internal/usecase")
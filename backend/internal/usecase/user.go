@@ -13,69 +13,157 @@ import (
 )
 
 type UserUsecase struct {
-	userRepo repository.UserRepository
-	fileRepo repository.FileRepository
+	userRepo            repository.UserRepository
+	files               *FileStore
+	photoModerationRepo repository.PhotoModerationRepository
 }
 
-func NewUserUsecase(userRepo repository.UserRepository, fileRepo repository.FileRepository) *UserUsecase {
-	return &UserUsecase{userRepo: userRepo, fileRepo: fileRepo}
+func NewUserUsecase(userRepo repository.UserRepository, files *FileStore, photoModerationRepo repository.PhotoModerationRepository) *UserUsecase {
+	return &UserUsecase{userRepo: userRepo, files: files, photoModerationRepo: photoModerationRepo}
 }
 
 func (u *UserUsecase) GetUser(ctx context.Context, id uuid.UUID) (*entity.User, error) {
+	ctx, span := tracer.Start(ctx, "UserUsecase.GetUser")
+	defer span.End()
+
 	return u.userRepo.GetByID(ctx, id)
 }
 
 func (u *UserUsecase) CreateUser(ctx context.Context, user *entity.User) error {
-	// Валидация бизнес-логики
-	if user.Role != entity.UserRoleMaster && user.Role != entity.UserRoleClient {
-		return errors.New("invalid user role")
-	}
-	if user.Username == "" {
-		return errors.New("username cannot be empty")
+	ctx, span := tracer.Start(ctx, "UserUsecase.CreateUser")
+	defer span.End()
+
+	if err := validateUser(user); err != nil {
+		return err
 	}
-	if user.CityID == uuid.Nil {
-		return errors.New("city_id is required")
+	if user.ID == uuid.Nil {
+		user.ID = uuid.New()
 	}
 	return u.userRepo.Create(ctx, user)
 }
 
 func (u *UserUsecase) UpdateUser(ctx context.Context, user *entity.User) error {
-	// Валидация бизнес-логики
-	if user.Role != entity.UserRoleMaster && user.Role != entity.UserRoleClient {
-		return errors.New("invalid user role")
-	}
-	if user.Username == "" {
-		return errors.New("username cannot be empty")
-	}
-	if user.CityID == uuid.Nil {
-		return errors.New("city_id is required")
+	ctx, span := tracer.Start(ctx, "UserUsecase.UpdateUser")
+	defer span.End()
+
+	if err := validateUser(user); err != nil {
+		return err
 	}
 	return u.userRepo.Update(ctx, user)
 }
 
 func (u *UserUsecase) DeleteUser(ctx context.Context, id uuid.UUID) error {
-	return u.userRepo.Delete(ctx, id)
+	ctx, span := tracer.Start(ctx, "UserUsecase.DeleteUser")
+	defer span.End()
+
+	if err := u.userRepo.Delete(ctx, id); err != nil {
+		return err
+	}
+	// Фото и вложения удаленного пользователя больше не используются
+	return u.files.releaseAll(ctx, entity.FileReference(entity.PhotoOwnerUser, id))
 }
 
 func (u *UserUsecase) UploadUserPhoto(ctx context.Context, userID uuid.UUID, file *entity.File, content io.Reader) error {
+	ctx, span := tracer.Start(ctx, "UserUsecase.UploadUserPhoto")
+	defer span.End()
+
 	// Проверяем, существует ли пользователь
 	user, err := u.userRepo.GetByID(ctx, userID)
 	if err != nil {
 		return err
 	}
-	// Загружаем файл в S3
-	if err := u.fileRepo.Upload(ctx, file, content); err != nil {
+	// Сохраняем варианты фото в хранилище
+	file.Purpose = entity.FilePurposeAvatar
+	reference := entity.FileReference(entity.PhotoOwnerUser, user.ID)
+	if err := u.files.storePhoto(ctx, file, content, reference); err != nil {
 		return err
 	}
+	return u.attachPhoto(ctx, user, file.ID, reference)
+}
+
+// PresignUserPhoto registers a photo the client uploads to the storage
+// directly and returns the request to upload it with.
+func (u *UserUsecase) PresignUserPhoto(ctx context.Context, userID uuid.UUID, file *entity.File) (*entity.PresignedRequest, error) {
+	ctx, span := tracer.Start(ctx, "UserUsecase.PresignUserPhoto")
+	defer span.End()
+
+	if _, err := u.userRepo.GetByID(ctx, userID); err != nil {
+		return nil, err
+	}
+	file.Purpose = entity.FilePurposeAvatar
+	return u.files.presignUpload(ctx, file)
+}
+
+// CompleteUserPhoto processes a presigned upload of the owner into the photo
+// of the user, like UploadUserPhoto does with an upload.
+func (u *UserUsecase) CompleteUserPhoto(ctx context.Context, userID, ownerID uuid.UUID, fileID string) (*entity.File, error) {
+	ctx, span := tracer.Start(ctx, "UserUsecase.CompleteUserPhoto")
+	defer span.End()
+
+	user, err := u.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	reference := entity.FileReference(entity.PhotoOwnerUser, user.ID)
+	file, err := u.files.completePhoto(ctx, ownerID, fileID, entity.FilePurposeAvatar, reference)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.attachPhoto(ctx, user, file.ID, reference); err != nil {
+		return nil, err
+	}
+	return file, nil
+}
+
+// PhotoURLs maps the variants of a photo to their download URLs, nil when
+// they are downloaded through the API.
+func (u *UserUsecase) PhotoURLs(ctx context.Context, id string) map[entity.ImageVariant]string {
+	ctx, span := tracer.Start(ctx, "UserUsecase.PhotoURLs")
+	defer span.End()
+
+	return u.files.photoURLs(ctx, id)
+}
+
+// attachPhoto makes a stored photo the photo of the user and sends it to
+// moderation.
+func (u *UserUsecase) attachPhoto(ctx context.Context, user *entity.User, fileID, reference string) error {
 	// Обновляем PhotoURL
-	user.PhotoURL = file.ID
-	return u.userRepo.Update(ctx, user)
+	previous := user.PhotoURL
+	user.PhotoURL = fileID
+	if err := u.userRepo.Update(ctx, user); err != nil {
+		return errors.Join(err, u.files.release(ctx, fileID, reference))
+	}
+	// Прежнее фото удалится после льготного периода
+	if previous != "" {
+		if err := u.files.release(ctx, previous, reference); err != nil {
+			return err
+		}
+	}
+	// Фото попадает в очередь модерации
+	return u.photoModerationRepo.Create(ctx, newPhotoModeration(fileID, entity.PhotoOwnerUser, user.ID))
 }
 
 func (u *UserUsecase) GetUserByTelegramID(ctx context.Context, telegramID int64) (*entity.User, error) {
+	ctx, span := tracer.Start(ctx, "UserUsecase.GetUserByTelegramID")
+	defer span.End()
+
 	user, err := u.userRepo.GetByTelegramID(ctx, telegramID)
 	if err != nil {
 		return nil, er.ErrRecordNotFound
 	}
 	return user, nil
 }
+
+func validateUser(user *entity.User) error {
+	// Валидация бизнес-логики
+	if user.Role != entity.UserRoleMaster && user.Role != entity.UserRoleClient && user.Role != entity.UserRoleAdmin {
+		return er.Validation("invalid user role")
+	}
+	if user.Username == "" {
+		return er.Validation("username cannot be empty")
+	}
+	if user.CityID == uuid.Nil {
+		return er.Validation("city_id is required")
+	}
+	return nil
+}
@@ -2,8 +2,8 @@ package usecase
 
 import (
 	"context"
-	"errors"
 	"github.com/Vanv1k/BeautyTON/internal/domain/entity"
+	er "github.com/Vanv1k/BeautyTON/internal/domain/errors"
 	"github.com/Vanv1k/BeautyTON/internal/domain/repository"
 	"github.com/google/uuid"
 )
@@ -23,53 +23,60 @@ func NewUserPreferencesUsecase(userPreferencesRepo repository.UserPreferencesRep
 }
 
 func (u *UserPreferencesUsecase) GetUserPreferences(ctx context.Context, id uuid.UUID) (*entity.UserPreferences, error) {
+	ctx, span := tracer.Start(ctx, "UserPreferencesUsecase.GetUserPreferences")
+	defer span.End()
+
 	return u.userPreferencesRepo.GetByID(ctx, id)
 }
 
 func (u *UserPreferencesUsecase) CreateUserPreferences(ctx context.Context, preferences *entity.UserPreferences) error {
-	// Валидация бизнес-логики
-	if preferences.UserID == uuid.Nil {
-		return errors.New("user_id is required")
-	}
-	if _, err := u.userRepo.GetByID(ctx, preferences.UserID); err != nil {
+	ctx, span := tracer.Start(ctx, "UserPreferencesUsecase.CreateUserPreferences")
+	defer span.End()
+
+	if err := u.validateUserPreferences(ctx, preferences); err != nil {
 		return err
 	}
-	if preferences.PreferredCategoryID != uuid.Nil {
-		if _, err := u.serviceCategoryRepo.GetByID(ctx, preferences.PreferredCategoryID); err != nil {
-			return errors.New("invalid preferred_category_id")
-		}
-	}
-	if preferences.MaxPrice < 0 {
-		return errors.New("max_price cannot be negative")
-	}
-	if preferences.MaxDistanceKm < 0 {
-		return errors.New("max_distance_km cannot be negative")
+	if preferences.ID == uuid.Nil {
+		preferences.ID = uuid.New()
 	}
 	return u.userPreferencesRepo.Create(ctx, preferences)
 }
 
 func (u *UserPreferencesUsecase) UpdateUserPreferences(ctx context.Context, preferences *entity.UserPreferences) error {
+	ctx, span := tracer.Start(ctx, "UserPreferencesUsecase.UpdateUserPreferences")
+	defer span.End()
+
+	if err := u.validateUserPreferences(ctx, preferences); err != nil {
+		return err
+	}
+	return u.userPreferencesRepo.Update(ctx, preferences)
+}
+
+func (u *UserPreferencesUsecase) DeleteUserPreferences(ctx context.Context, id uuid.UUID) error {
+	ctx, span := tracer.Start(ctx, "UserPreferencesUsecase.DeleteUserPreferences")
+	defer span.End()
+
+	return u.userPreferencesRepo.Delete(ctx, id)
+}
+
+func (u *UserPreferencesUsecase) validateUserPreferences(ctx context.Context, preferences *entity.UserPreferences) error {
 	// Валидация бизнес-логики
 	if preferences.UserID == uuid.Nil {
-		return errors.New("user_id is required")
+		return er.Validation("user_id is required")
 	}
 	if _, err := u.userRepo.GetByID(ctx, preferences.UserID); err != nil {
 		return err
 	}
 	if preferences.PreferredCategoryID != uuid.Nil {
 		if _, err := u.serviceCategoryRepo.GetByID(ctx, preferences.PreferredCategoryID); err != nil {
-			return errors.New("invalid preferred_category_id")
+			return er.Validation("invalid preferred_category_id")
 		}
 	}
 	if preferences.MaxPrice < 0 {
-		return errors.New("max_price cannot be negative")
+		return er.Validation("max_price cannot be negative")
 	}
 	if preferences.MaxDistanceKm < 0 {
-		return errors.New("max_distance_km cannot be negative")
+		return er.Validation("max_distance_km cannot be negative")
 	}
-	return u.userPreferencesRepo.Update(ctx, preferences)
-}
-
-func (u *UserPreferencesUsecase) DeleteUserPreferences(ctx context.Context, id uuid.UUID) error {
-	return u.userPreferencesRepo.Delete(ctx, id)
+	return nil
 }